@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	v1 "starland-account/api/grpc/v1"
+	"starland-account/configs"
+	"starland-account/internal/pkg/interceptors"
+	"starland-account/internal/service"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// NewGRPCServer serves the same services as the HTTP API. Metrics see the
+// final status of each call, Logging the full error before Errors maps it.
+func NewGRPCServer(config *configs.Config, us *service.Service) (*grpc.Server, error) {
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		interceptors.Metrics(),
+		interceptors.Recovery(),
+		interceptors.Errors(),
+		interceptors.Logging(),
+		interceptors.Auth(),
+	))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	v1.RegisterAccountServer(s, us.Account)
+	v1.RegisterActivityServer(s, us.Activity)
+	zap.S().Infof("grpc addr:%s", config.GRPC.Addr)
+	return s, nil
+}
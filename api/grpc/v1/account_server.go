@@ -0,0 +1,79 @@
+package v1
+
+//go:generate protoc -I ../../.. --go_out=../../.. --go_opt=paths=source_relative --go-grpc_out=../../.. --go-grpc_opt=paths=source_relative api/grpc/v1/account.proto
+
+import (
+	"context"
+	"starland-account/internal/service/account"
+
+	"google.golang.org/grpc"
+)
+
+type AccountGRPCServer interface {
+	Auth(context.Context, *account.AccountRequest) error
+	QueryAccount(context.Context, string) (*account.AccountResponse, error)
+	ClaimPoints(context.Context, *account.ClaimPointsRequest) (string, error)
+	SavePointsAddr(context.Context, string, string) error
+}
+
+type accountServer struct {
+	UnimplementedAccountServiceServer
+	service AccountGRPCServer
+}
+
+func RegisterAccountServer(s *grpc.Server, service AccountGRPCServer) {
+	RegisterAccountServiceServer(s, &accountServer{service: service})
+}
+
+func (s *accountServer) Auth(ctx context.Context, req *AuthRequest) (*AuthResponse, error) {
+	err := s.service.Auth(ctx, &account.AccountRequest{
+		AccountID: req.AccountId,
+		Email:     req.Email,
+		Name:      req.Name,
+		Provider:  req.Provider,
+		AvatarURL: req.AvatarUrl,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AuthResponse{}, nil
+}
+
+func (s *accountServer) GetAccount(ctx context.Context, req *GetAccountRequest) (*Account, error) {
+	res, err := s.service.QueryAccount(ctx, req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		AccountId:     res.AccountID,
+		Email:         res.Email,
+		Name:          res.Name,
+		Provider:      res.Provider,
+		AvatarUrl:     res.AvatarURL,
+		Integral:      int64(res.Integral),
+		Received:      int64(res.Received),
+		SolanaAddr:    res.SolanaAddr,
+		ClaimCount:    int64(res.ClaimCount),
+		State:         res.State,
+		EmailVerified: res.EmailVerified,
+	}, nil
+}
+
+func (s *accountServer) ClaimPoints(ctx context.Context, req *ClaimPointsRequest) (*ClaimPointsResponse, error) {
+	sig, err := s.service.ClaimPoints(ctx, &account.ClaimPointsRequest{
+		AccountID: req.AccountId,
+		Points:    int(req.Points),
+		IsOK:      req.IsOk,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ClaimPointsResponse{Signature: sig}, nil
+}
+
+func (s *accountServer) SavePointsAddr(ctx context.Context, req *SavePointsAddrRequest) (*SavePointsAddrResponse, error) {
+	if err := s.service.SavePointsAddr(ctx, req.AccountId, req.SolanaAddr); err != nil {
+		return nil, err
+	}
+	return &SavePointsAddrResponse{}, nil
+}
@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"context"
+	"starland-account/internal/service/activity"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ActivityGRPCServer interface {
+	Play(context.Context, *activity.PlayRequest) error
+	QueryActivityLogs(context.Context, *activity.ActivityLogQueryRequest) (*activity.ActivityLogPageResponse, error)
+	QueryActivitys(context.Context) ([]*activity.ActivityResponse, error)
+	QueryIsLimit(context.Context, int, string) (bool, error)
+}
+
+type activityServer struct {
+	UnimplementedActivityServiceServer
+	service ActivityGRPCServer
+}
+
+func RegisterActivityServer(s *grpc.Server, service ActivityGRPCServer) {
+	RegisterActivityServiceServer(s, &activityServer{service: service})
+}
+
+func (s *activityServer) ListActivities(ctx context.Context, req *ListActivitiesRequest) (*ListActivitiesResponse, error) {
+	acts, err := s.service.QueryActivitys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &ListActivitiesResponse{Activities: make([]*Activity, len(acts))}
+	for i := range acts {
+		res.Activities[i] = &Activity{
+			ActivityCode: int64(acts[i].ActivityCode),
+			ActivityName: acts[i].ActivityName,
+			Integral:     int64(acts[i].Integral),
+		}
+	}
+	return res, nil
+}
+
+func (s *activityServer) Play(ctx context.Context, req *PlayRequest) (*PlayResponse, error) {
+	err := s.service.Play(ctx, &activity.PlayRequest{
+		ActivityCode: int(req.ActivityCode),
+		Account:      req.AccountId,
+		IP:           req.Ip,
+		DeviceID:     req.DeviceId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &PlayResponse{}, nil
+}
+
+func (s *activityServer) GetLimit(ctx context.Context, req *GetLimitRequest) (*GetLimitResponse, error) {
+	b, err := s.service.QueryIsLimit(ctx, int(req.ActivityCode), req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	return &GetLimitResponse{IsLimit: b}, nil
+}
+
+func (s *activityServer) ListActivityLogs(ctx context.Context, req *ListActivityLogsRequest) (*ListActivityLogsResponse, error) {
+	alq := &activity.ActivityLogQueryRequest{
+		Account: req.AccountId,
+		Limit:   int(req.Limit),
+		Cursor:  req.Cursor,
+	}
+	if req.ActivityCode != nil {
+		code := int(*req.ActivityCode)
+		alq.ActivityCode = &code
+	}
+	if req.From != nil {
+		t := req.From.AsTime()
+		alq.From = &t
+	}
+	if req.To != nil {
+		t := req.To.AsTime()
+		alq.To = &t
+	}
+
+	page, err := s.service.QueryActivityLogs(ctx, alq)
+	if err != nil {
+		return nil, err
+	}
+	res := &ListActivityLogsResponse{
+		Logs:       make([]*ActivityLog, len(page.Data)),
+		Count:      page.Count,
+		NextCursor: page.NextCursor,
+		Totals:     make([]*ActivityLogTotal, len(page.Totals)),
+	}
+	for i, l := range page.Data {
+		res.Logs[i] = &ActivityLog{
+			AccountId:    l.Account,
+			ActivityCode: int64(l.ActivityCode),
+			ActivityName: l.ActivityName,
+			Integral:     int64(l.Integral),
+			ClientId:     l.ClientID,
+			CreateAt:     timestamppb.New(l.CreateAt),
+		}
+	}
+	for i, t := range page.Totals {
+		res.Totals[i] = &ActivityLogTotal{
+			ActivityCode: int64(t.ActivityCode),
+			ActivityName: t.ActivityName,
+			Count:        t.Count,
+			Integral:     t.Integral,
+		}
+	}
+	return res, nil
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"github.com/ansrivas/fiberprometheus/v2"
 	v1 "starland-account/api/http/v1"
+	v2 "starland-account/api/http/v2"
+	"starland-account/api/openapi"
 	"starland-account/configs"
 	"starland-account/internal/pkg/middlewares"
+	"starland-account/internal/pkg/storage"
 	"starland-account/internal/service"
 	"strings"
 	"time"
@@ -24,11 +27,20 @@ func NewHTTPServer(config *configs.Config, us *service.Service) (*fiber.App, err
 	app := fiber.New(fiber.Config{
 		ReadTimeout:  config.HTTP.ReadTimeout * time.Second,
 		WriteTimeout: config.HTTP.WriteTimeout * time.Second,
+		ErrorHandler: middlewares.ErrorHandler,
 	})
 	app.Use(recover.New(), pprof.New(), cors.New(), requestid.New())
 	prometheus := fiberprometheus.New("starland-account")
 	prometheus.RegisterAt(app, "/metrics")
-	app.Use(prometheus.Middleware)
+	// probes are neither logged, measured nor authenticated
+	app.Get("/health", func(c *fiber.Ctx) error {
+		status := fiber.StatusOK
+		if !us.Workers.Healthy() {
+			status = fiber.StatusServiceUnavailable
+		}
+		return c.Status(status).JSON(fiber.Map{"workers": us.Workers.Health()})
+	})
+	app.Use(prometheus.Middleware, middlewares.RouteUsage())
 	app.Use(logger.New(logger.Config{
 		Format: fmt.Sprintf("${time} | ${ip} | ${status} | ${locals:%s} | ${latency} | ${method} | ${path} | "+
 			"ResponseBody:${resBody} | Params:${queryParams} \n",
@@ -43,10 +55,35 @@ func NewHTTPServer(config *configs.Config, us *service.Service) (*fiber.App, err
 		TimeFormat: time.RFC3339,
 		TimeZone:   "Asia/Shanghai",
 	}))
-	app.Use(middlewares.Auth())
+	if sc := config.Storage; sc != nil && (sc.Driver == "" || sc.Driver == storage.DriverLocal) && sc.Local.Serve {
+		app.Static("/image", storage.NewLocalStorage(&sc.Local).Dir())
+	}
+	doc, err := openapi.Load()
+	if err != nil {
+		return nil, fmt.Errorf("NewHTTPServer: load openapi err: %w", err)
+	}
+	if err = openapi.Register(app, doc); err != nil {
+		return nil, fmt.Errorf("NewHTTPServer: serve openapi err: %w", err)
+	}
+	validate, err := middlewares.ValidateRequest(doc)
+	if err != nil {
+		return nil, fmt.Errorf("NewHTTPServer: openapi router err: %w", err)
+	}
+	app.Use(middlewares.RateLimitUnauthenticated(us.RateLimit))
+	app.Use(middlewares.Auth(v1.InternalPrefix))
+	app.Use(middlewares.RateLimit(us.RateLimit))
+	app.Use(validate)
 	r := app.Group("/")
 	v1.InitAccountRouter(r, us.Account, config)
+	v1.InitAccountAdminRouter(r, us.Account, config)
 	v1.InitActivityRouter(r, us.Activity, config)
+	v1.InitActivityAdminRouter(r, us.Activity, config)
+	v1.InitAirdropRouter(r, us.Airdrop, config)
+	v1.InitAnalyticsRouter(r, us.Analytics, config)
+	v1.InitWebhookRouter(r, us.Webhook, config)
+	v1.InitAwardRouter(r, us.Award, config)
+	v2.InitAccountRouter(r, us.Account, config)
+	v2.InitActivityRouter(r, us.Activity, config)
 	zap.S().Infof("addr:%s", config.HTTP.Addr)
 	return app, nil
 }
@@ -1,11 +1,18 @@
 package v1
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"net/http"
 	"starland-account/configs"
+	"starland-account/internal/pkg/middlewares"
+	"starland-account/internal/pkg/tenant"
 	"starland-account/internal/pkg/util"
 	"starland-account/internal/service/account"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -16,42 +23,90 @@ type AccountHTTPServer interface {
 	QueryAccount(context.Context, string) (*account.AccountResponse, error)
 	ClaimPoints(context.Context, *account.ClaimPointsRequest) (string, error)
 	SavePointsAddr(context.Context, string, string) error
+	Appeal(context.Context, string, string) error
+	ExportAccount(context.Context, string) (*account.AccountExport, error)
+	DeleteAccount(context.Context, string, string) error
+	UpdateProfile(context.Context, *account.UpdateProfileRequest) (*account.AccountResponse, error)
+	QueryProfileChanges(context.Context, string) ([]*account.ProfileChangeResponse, error)
+	UploadAvatar(context.Context, string, []byte) (*account.AvatarResponse, error)
+	SendEmailVerification(context.Context, string) error
+	VerifyEmail(context.Context, string, string) (*account.AccountResponse, error)
+	SendMagicLink(context.Context, string) error
+	LoginMagicLink(context.Context, string) (*account.AccountResponse, error)
+}
+
+type AccountAdminHTTPServer interface {
+	SuspendAccount(context.Context, *account.ChangeStateRequest) error
+	BanAccount(context.Context, *account.ChangeStateRequest) error
+	UnsuspendAccount(context.Context, *account.ChangeStateRequest) error
+	UnbanAccount(context.Context, *account.ChangeStateRequest) error
+	QueryAccountStateLogs(context.Context, string) ([]*account.AccountStateLogResponse, error)
+	QueryAppeals(context.Context, string) ([]*account.AppealResponse, error)
+	ResolveAppeal(context.Context, *account.ResolveAppealRequest) error
+	SearchAccounts(context.Context, *account.SearchAccountsRequest) (*account.AccountPageResponse, error)
+	WriteAccountsCSV(context.Context, *account.SearchAccountsRequest, io.Writer) error
 }
 
 func InitAccountRouter(app fiber.Router, service AccountHTTPServer, conf *configs.Config) {
 	router := app.Group("v1")
-	router.Post("/account", auth(service))
-	router.Post("/account/claim_points", claimPoints(service))
-	router.Get("/account/:id", queryAccounts(service))
-	router.Post("/account/:id/save_points_addr", savePointsAddr(service))
+	router.Post("/account", middlewares.Deprecated(conf, "/v2/accounts"), auth(service))
+	router.Post("/account/claim_points", middlewares.Deprecated(conf, "/v2/accounts/:id/claims"), claimPoints(service))
+	router.Post("/account/magic_link", middlewares.Deprecated(conf, "/v2/magic-links"), sendMagicLink(service))
+	router.Post("/account/magic_link/login", middlewares.Deprecated(conf, "/v2/sessions"), loginMagicLink(service))
+	router.Get("/account/:id", middlewares.Deprecated(conf, "/v2/accounts/:id"), queryAccounts(service))
+	router.Post("/account/:id/save_points_addr", middlewares.Deprecated(conf, "/v2/accounts/:id/wallets"), savePointsAddr(service))
+	router.Post("/account/:id/appeal", middlewares.Deprecated(conf, "/v2/accounts/:id/appeals"), appeal(service))
+	router.Get("/account/:id/export", middlewares.Deprecated(conf, "/v2/accounts/:id/export"), exportAccount(service))
+	router.Delete("/account/:id", middlewares.Deprecated(conf, "/v2/accounts/:id"), deleteAccount(service))
+	router.Patch("/account/:id", middlewares.Deprecated(conf, "/v2/accounts/:id"), updateProfile(service))
+	router.Get("/account/:id/profile_logs", middlewares.Deprecated(conf, "/v2/accounts/:id/profile-changes"), queryProfileChanges(service))
+	router.Post("/account/:id/avatar", middlewares.Deprecated(conf, "/v2/accounts/:id/avatar"), uploadAvatar(service))
+	router.Post("/account/:id/email/send_code", middlewares.Deprecated(conf, "/v2/accounts/:id/verification-codes"), sendEmailVerification(service))
+	router.Post("/account/:id/email/verify", middlewares.Deprecated(conf, "/v2/accounts/:id/email-verifications"), verifyEmail(service))
+}
+
+func InitAccountAdminRouter(app fiber.Router, service AccountAdminHTTPServer, conf *configs.Config) {
+	router := app.Group("v1/admin", middlewares.AdminAuth())
+	router.Post("/account/:id/suspend", changeAccountState(service.SuspendAccount))
+	router.Post("/account/:id/unsuspend", changeAccountState(service.UnsuspendAccount))
+	router.Post("/account/:id/ban", changeAccountState(service.BanAccount))
+	router.Post("/account/:id/unban", changeAccountState(service.UnbanAccount))
+	router.Get("/account/:id/state_logs", queryAccountStateLogs(service))
+	router.Get("/appeal", queryAppeals(service))
+	router.Post("/appeal/:id/resolve", resolveAppeal(service))
+	router.Get("/accounts", searchAccounts(service))
+	router.Get("/accounts/export", exportAccounts(service))
 }
 
 func auth(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
 		var (
 			req struct {
-				AccountID string `json:"account_id"`
-				Email     string `json:"email"`
-				Name      string `json:"name"`
-				Provider  string `json:"provider"`
-				AvatarURL string `json:"avatar_url"`
+				AccountID string `json:"account_id" validate:"required_without=Email,max=128"`
+				Email     string `json:"email" validate:"omitempty,email"`
+				Name      string `json:"name" validate:"max=64"`
+				Provider  string `json:"provider" validate:"required,max=32"`
+				AvatarURL string `json:"avatar_url" validate:"omitempty,url,max=1024"`
 			}
 		)
 
 		if err := ctx.BodyParser(&req); err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
 		}
 
 		act := &account.AccountRequest{
 			AccountID: req.AccountID,
 			Email:     req.Email,
-			Name:      req.Email,
+			Name:      req.Name,
 			Provider:  req.Provider,
 			AvatarURL: req.AvatarURL,
 		}
 
 		if err := service.Auth(ctx.Context(), act); err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return err
 		}
 		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
 	}
@@ -61,17 +116,20 @@ func queryAccounts(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
 		var (
 			req struct {
-				ID string `params:"id"`
+				ID string `params:"id" validate:"required,max=128"`
 			}
 		)
 
 		if err := ctx.ParamsParser(&req); err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
 		}
 
 		response, err := service.QueryAccount(ctx.Context(), req.ID)
 		if err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return err
 		}
 		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
 	}
@@ -81,14 +139,17 @@ func claimPoints(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
 		var (
 			req struct {
-				AccountID string `json:"account_id"`
-				Points    int    `json:"points"`
+				AccountID string `json:"account_id" validate:"required,max=128"`
+				Points    int    `json:"points" validate:"gt=0"`
 				IsOK      bool   `json:"is_ok"`
 			}
 		)
 
 		if err := ctx.BodyParser(&req); err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
 		}
 		zap.S().Info("req:", req.AccountID)
 		cpr := &account.ClaimPointsRequest{
@@ -99,7 +160,7 @@ func claimPoints(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
 
 		res, err := service.ClaimPoints(ctx.Context(), cpr)
 		if err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return err
 		}
 		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
 	}
@@ -109,19 +170,503 @@ func savePointsAddr(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
 		var (
 			req struct {
-				Addr    string `json:"addr"`
-				Account string `json:"account"`
+				Addr    string `json:"addr" validate:"required,solana_addr"`
+				Account string `json:"account" validate:"required,max=128"`
 			}
 		)
 
 		if err := ctx.BodyParser(&req); err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
 		}
 
 		err := service.SavePointsAddr(ctx.Context(), req.Account, req.Addr)
 		if err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func appeal(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID      string `params:"id" validate:"required,max=128"`
+				Content string `json:"content" validate:"required,max=2000"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		if err := service.Appeal(ctx.Context(), req.ID, req.Content); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func exportAccount(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID     string `params:"id" validate:"required,max=128"`
+				Format string `query:"format" validate:"omitempty,oneof=json zip"`
+			}
+			buf bytes.Buffer
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.QueryParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		export, err := service.ExportAccount(ctx.Context(), req.ID)
+		if err != nil {
+			return err
+		}
+		if req.Format != "zip" {
+			return ctx.Status(http.StatusOK).JSON(util.MakeResponse(export))
+		}
+
+		if err = account.WriteExportZip(&buf, export); err != nil {
+			return err
+		}
+		ctx.Set(fiber.HeaderContentType, "application/zip")
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="account-%s.zip"`, req.ID))
+		return ctx.Status(http.StatusOK).Send(buf.Bytes())
+	}
+}
+
+func deleteAccount(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+		// the actor is who authenticated: an admin, or else the tenant acting
+		// for the account itself
+		actor := middlewares.Admin(ctx)
+		if actor == "" {
+			actor = req.ID
+		}
+
+		if err := service.DeleteAccount(ctx.Context(), req.ID, actor); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func updateProfile(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID        string  `params:"id" validate:"required,max=128"`
+				Name      *string `json:"name"`
+				AvatarURL *string `json:"avatar_url"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		upr := &account.UpdateProfileRequest{
+			AccountID: req.ID,
+			Name:      req.Name,
+			AvatarURL: req.AvatarURL,
+			Actor:     req.ID,
+		}
+		response, err := service.UpdateProfile(ctx.Context(), upr)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func queryProfileChanges(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.QueryProfileChanges(ctx.Context(), req.ID)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func uploadAvatar(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+		fh, err := ctx.FormFile("file")
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return err
+		}
+
+		response, err := service.UploadAvatar(ctx.Context(), req.ID, data)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func sendEmailVerification(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		if err := service.SendEmailVerification(ctx.Context(), req.ID); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func verifyEmail(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID   string `params:"id" validate:"required,max=128"`
+				Code string `json:"code" validate:"required,max=16"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.VerifyEmail(ctx.Context(), req.ID, req.Code)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func sendMagicLink(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				Email string `json:"email" validate:"required,email"`
+			}
+		)
+
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		if err := service.SendMagicLink(ctx.Context(), req.Email); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func loginMagicLink(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				Token string `json:"token" validate:"required"`
+			}
+		)
+
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.LoginMagicLink(ctx.Context(), req.Token)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func changeAccountState(change func(context.Context, *account.ChangeStateRequest) error) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID       string `params:"id" validate:"required,max=128"`
+				Reason   string `json:"reason" validate:"max=500"`
+				Duration int64  `json:"duration" validate:"gte=0"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		csr := &account.ChangeStateRequest{
+			AccountID: req.ID,
+			Reason:    req.Reason,
+			Actor:     middlewares.Admin(ctx),
+			Duration:  time.Duration(req.Duration) * time.Second,
+		}
+		if err := change(ctx.Context(), csr); err != nil {
+			return err
 		}
 		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
 	}
 }
+
+func queryAccountStateLogs(service AccountAdminHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.QueryAccountStateLogs(ctx.Context(), req.ID)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func queryAppeals(service AccountAdminHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				State string `query:"state" validate:"omitempty,oneof=pending approved rejected"`
+			}
+		)
+
+		if err := ctx.QueryParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.QueryAppeals(ctx.Context(), req.State)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func resolveAppeal(service AccountAdminHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID      string `params:"id" validate:"required,max=128"`
+				Approve bool   `json:"approve"`
+				Reply   string `json:"reply" validate:"max=2000"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		rar := &account.ResolveAppealRequest{
+			AppealID: req.ID,
+			Approve:  req.Approve,
+			Actor:    middlewares.Admin(ctx),
+			Reply:    req.Reply,
+		}
+		if err := service.ResolveAppeal(ctx.Context(), rar); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func searchAccounts(service AccountAdminHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		sar, err := parseSearchAccounts(ctx)
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+
+		response, err := service.SearchAccounts(ctx.Context(), sar)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+// exportAccounts streams the CSV so large exports aren't buffered. The status
+// is sent before the first page is read, so a failure midway truncates the
+// file and is only logged.
+func exportAccounts(service AccountAdminHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		sar, err := parseSearchAccounts(ctx)
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+
+		ctx.Set(fiber.HeaderContentType, "text/csv")
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="accounts-%s.csv"`, time.Now().Format("20060102150405")))
+		bg := tenant.Detach(ctx.Context())
+		ctx.Status(http.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			if err := service.WriteAccountsCSV(bg, sar, w); err != nil {
+				zap.S().Errorf("exportAccounts: write csv err: %v", err)
+			}
+		})
+		return nil
+	}
+}
+
+func parseSearchAccounts(ctx *fiber.Ctx) (*account.SearchAccountsRequest, error) {
+	var (
+		req struct {
+			AccountID   string `query:"account_id"`
+			Email       string `query:"email"`
+			Name        string `query:"name"`
+			Provider    string `query:"provider"`
+			SolanaAddr  string `query:"solana_addr" validate:"omitempty,solana_addr"`
+			State       string `query:"state" validate:"omitempty,oneof=active suspended banned deleted"`
+			CreatedFrom string `query:"created_from"`
+			CreatedTo   string `query:"created_to"`
+			MinIntegral string `query:"min_integral"`
+			MaxIntegral string `query:"max_integral"`
+			Sort        string `query:"sort" validate:"omitempty,oneof=created_at integral"`
+			Order       string `query:"order" validate:"omitempty,oneof=asc desc"`
+			Limit       int    `query:"limit" validate:"gte=0,lte=500"`
+			Cursor      string `query:"cursor"`
+		}
+		err error
+	)
+
+	if err = ctx.QueryParser(&req); err != nil {
+		return nil, err
+	}
+	if err = util.Validate(&req); err != nil {
+		return nil, err
+	}
+	sar := &account.SearchAccountsRequest{
+		AccountIDPrefix: req.AccountID,
+		Email:           req.Email,
+		Name:            req.Name,
+		Provider:        req.Provider,
+		SolanaAddr:      req.SolanaAddr,
+		State:           req.State,
+		Sort:            req.Sort,
+		Desc:            req.Order == "desc",
+		Limit:           req.Limit,
+		Cursor:          req.Cursor,
+	}
+	if sar.CreatedFrom, err = util.ParseQueryTime("created_from", req.CreatedFrom); err != nil {
+		return nil, err
+	}
+	if sar.CreatedTo, err = util.ParseQueryTime("created_to", req.CreatedTo); err != nil {
+		return nil, err
+	}
+	if sar.MinIntegral, err = util.ParseQueryInt("min_integral", req.MinIntegral); err != nil {
+		return nil, err
+	}
+	if sar.MaxIntegral, err = util.ParseQueryInt("max_integral", req.MaxIntegral); err != nil {
+		return nil, err
+	}
+	return sar, nil
+}
@@ -1,10 +1,15 @@
 package v1
 
 import (
+	"bufio"
 	"context"
-	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"starland-account/configs"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/middlewares"
+	"starland-account/internal/pkg/tenant"
 	"starland-account/internal/pkg/util"
 	"starland-account/internal/service/activity"
 
@@ -13,38 +18,57 @@ import (
 )
 
 type ActivityHTTPServer interface {
-	Play(context.Context, int, string) error
-	QueryActivityLogs(context.Context, string, int, int) ([]*activity.ActivityLogResponse, int64, error)
+	Play(context.Context, *activity.PlayRequest) error
+	QueryActivityLogs(context.Context, *activity.ActivityLogQueryRequest) (*activity.ActivityLogPageResponse, error)
+	WriteActivityLogs(context.Context, *activity.ActivityLogQueryRequest, string, io.Writer) error
 	QueryActivitys(ctx context.Context) ([]*activity.ActivityResponse, error)
 	QueryIsLimit(context.Context, int, string) (bool, error)
 }
 
+type ActivityAdminHTTPServer interface {
+	QueryRiskReviews(context.Context, string) ([]*activity.RiskReviewResponse, error)
+	ResolveRiskReview(context.Context, *activity.ResolveRiskReviewRequest) error
+}
+
 func InitActivityRouter(app fiber.Router, service ActivityHTTPServer, conf *configs.Config) {
 	router := app.Group("v1")
-	router.Post("/activity", play(service))
-	router.Get("/activity/Limit", queryIsLimit(service))
-	router.Get("/activity", queryActivitys(service))
-	router.Get("/activity/log/:account", queryActivityLogs(service))
+	router.Post("/activity", middlewares.Deprecated(conf, "/v2/activities/:code/plays"), play(service))
+	router.Get("/activity/Limit", middlewares.Deprecated(conf, "/v2/activities/:code/limit"), queryIsLimit(service))
+	router.Get("/activity", middlewares.Deprecated(conf, "/v2/activities"), queryActivitys(service))
+	router.Get("/activity/log/:account", middlewares.Deprecated(conf, "/v2/accounts/:account/activity-logs"), queryActivityLogs(service))
+	router.Get("/activity/log/:account/export", middlewares.Deprecated(conf, "/v2/accounts/:account/activity-logs/export"), exportActivityLogs(service))
+}
+
+func InitActivityAdminRouter(app fiber.Router, service ActivityAdminHTTPServer, conf *configs.Config) {
+	router := app.Group("v1/admin", middlewares.AdminAuth())
+	router.Get("/risk/review", queryRiskReviews(service))
+	router.Post("/risk/review/:id/resolve", resolveRiskReview(service))
 }
 
 func play(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
 		var (
 			req struct {
-				ActivityCode int    `json:"activity_code"`
-				Account      string `json:"account"`
+				ActivityCode int    `json:"activity_code" validate:"gte=0"`
+				Account      string `json:"account" validate:"required,max=128"`
 			}
 		)
 
 		if err := ctx.BodyParser(&req); err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
 		}
 
-		if err := service.Play(ctx.Context(), req.ActivityCode, req.Account); err != nil {
-			if errors.Is(err, activity.LimitError) {
-				return ctx.Status(http.StatusOK).JSON(util.MakeResponse(err.Error()).SetCode("100"))
-			}
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+		pr := &activity.PlayRequest{
+			ActivityCode: req.ActivityCode,
+			Account:      req.Account,
+			IP:           ctx.IP(),
+			DeviceID:     ctx.Get("X-Device-ID"),
+		}
+		if err := service.Play(ctx.Context(), pr); err != nil {
+			return err
 		}
 		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
 	}
@@ -52,37 +76,91 @@ func play(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
 
 func queryActivityLogs(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
-		var (
-			req struct {
-				Account string `params:"account"`
-				Page    int    `query:"page"`
-				Limit   int    `query:"limit"`
-			}
-			res struct {
-				Data  []*activity.ActivityLogResponse `json:"data"`
-				Count int64                           `json:"count"`
-			}
-		)
-
-		if err := ctx.ParamsParser(&req); err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+		alq, err := parseActivityLogQuery(ctx)
+		if err != nil {
+			return util.InvalidRequest(err)
 		}
 
-		if err := ctx.QueryParser(&req); err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+		zap.S().Infof("queryActivityLogs: req: %+v", *alq)
+		response, err := service.QueryActivityLogs(ctx.Context(), alq)
+		if err != nil {
+			return err
 		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
 
-		zap.S().Infof("queryActivityLogs: req: %+v", req)
-		response, count, err := service.QueryActivityLogs(ctx.Context(), req.Account, req.Page, req.Limit)
+// exportActivityLogs streams the history as csv (default) or ndjson. The
+// status is sent before the first page is read, so a failure midway
+// truncates the output and is only logged.
+func exportActivityLogs(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		alq, err := parseActivityLogQuery(ctx)
 		if err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return util.InvalidRequest(err)
 		}
-		res.Count = count
-		res.Data = response
-		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
+		format := ctx.Query("format", activity.LogFormatCSV)
+		switch format {
+		case activity.LogFormatCSV:
+			ctx.Set(fiber.HeaderContentType, "text/csv")
+		case activity.LogFormatNDJSON:
+			ctx.Set(fiber.HeaderContentType, "application/x-ndjson")
+		default:
+			return bizerr.ErrBadRequest.Errorf("unknown format %s", format)
+		}
+
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="activity-log-%s.%s"`, alq.Account, format))
+		bg := tenant.Detach(ctx.Context())
+		ctx.Status(http.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			if err := service.WriteActivityLogs(bg, alq, format, w); err != nil {
+				zap.S().Errorf("exportActivityLogs: write %s err: %v", format, err)
+			}
+		})
+		return nil
 	}
 }
 
+func parseActivityLogQuery(ctx *fiber.Ctx) (*activity.ActivityLogQueryRequest, error) {
+	var (
+		req struct {
+			Account      string `params:"account" validate:"required,max=128"`
+			ActivityCode string `query:"activity_code"`
+			From         string `query:"from"`
+			To           string `query:"to"`
+			Page         int    `query:"page" validate:"gte=0"`
+			Limit        int    `query:"limit" validate:"gte=0,lte=1000"`
+			Cursor       string `query:"cursor"`
+		}
+		err error
+	)
+
+	if err = ctx.ParamsParser(&req); err != nil {
+		return nil, err
+	}
+	if err = ctx.QueryParser(&req); err != nil {
+		return nil, err
+	}
+	if err = util.Validate(&req); err != nil {
+		return nil, err
+	}
+	alq := &activity.ActivityLogQueryRequest{
+		Account: req.Account,
+		Page:    req.Page,
+		Limit:   req.Limit,
+		Cursor:  req.Cursor,
+	}
+	if alq.ActivityCode, err = util.ParseQueryInt("activity_code", req.ActivityCode); err != nil {
+		return nil, err
+	}
+	if alq.From, err = util.ParseQueryTime("from", req.From); err != nil {
+		return nil, err
+	}
+	if alq.To, err = util.ParseQueryTime("to", req.To); err != nil {
+		return nil, err
+	}
+	return alq, nil
+}
+
 func queryActivitys(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
 		var (
@@ -92,7 +170,7 @@ func queryActivitys(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
 		)
 		response, err := service.QueryActivitys(ctx.Context())
 		if err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return err
 		}
 		res.Data = response
 		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
@@ -103,8 +181,8 @@ func queryIsLimit(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
 		var (
 			req struct {
-				ActivityCode int    `query:"activity_code"`
-				Account      string `query:"account"`
+				ActivityCode int    `query:"activity_code" validate:"gte=0"`
+				Account      string `query:"account" validate:"required,max=128"`
 			}
 
 			res struct {
@@ -113,14 +191,72 @@ func queryIsLimit(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
 		)
 
 		if err := ctx.QueryParser(&req); err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
 		}
 
 		b, err := service.QueryIsLimit(ctx.Context(), req.ActivityCode, req.Account)
 		if err != nil {
-			return ctx.Status(http.StatusInternalServerError).JSON(util.MakeResponseWithMsg(err.Error()))
+			return err
 		}
 		res.IsLimit = b
 		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
 	}
 }
+
+func queryRiskReviews(service ActivityAdminHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				State string `query:"state" validate:"omitempty,oneof=pending cleared confirmed"`
+			}
+		)
+
+		if err := ctx.QueryParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.QueryRiskReviews(ctx.Context(), req.State)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func resolveRiskReview(service ActivityAdminHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID    uint   `params:"id" validate:"gt=0"`
+				Ban   bool   `json:"ban"`
+				Actor string `json:"actor" validate:"max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		rr := &activity.ResolveRiskReviewRequest{
+			ID:    req.ID,
+			Ban:   req.Ban,
+			Actor: req.Actor,
+		}
+		if err := service.ResolveRiskReview(ctx.Context(), rr); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"starland-account/configs"
+	"starland-account/internal/pkg/middlewares"
+	"starland-account/internal/pkg/util"
+	"starland-account/internal/service/airdrop"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AirdropHTTPServer interface {
+	CreateJob(context.Context, *airdrop.CreateAirdropJobRequest, io.Reader) (*airdrop.AirdropJobResponse, error)
+	StartJob(context.Context, string) bool
+	QueryJob(context.Context, string) (*airdrop.AirdropJobResponse, error)
+	WriteReport(context.Context, string, io.Writer) error
+}
+
+func InitAirdropRouter(app fiber.Router, service AirdropHTTPServer, conf *configs.Config) {
+	router := app.Group("v1/admin", middlewares.AdminAuth())
+	router.Post("/airdrop", createAirdropJob(service))
+	router.Get("/airdrop/:id", queryAirdropJob(service))
+	router.Get("/airdrop/:id/report", airdropReport(service))
+}
+
+func createAirdropJob(service AirdropHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		fh, err := ctx.FormFile("file")
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		req := &airdrop.CreateAirdropJobRequest{
+			Operator: middlewares.Admin(ctx),
+			FileName: fh.Filename,
+		}
+		job, err := service.CreateJob(ctx.Context(), req, f)
+		if err != nil {
+			return err
+		}
+		res := util.MakeResponse(job)
+		if !service.StartJob(ctx.Context(), job.JobID) {
+			res.Msg = "queued, will resume"
+		}
+		return ctx.Status(http.StatusOK).JSON(res)
+	}
+}
+
+func queryAirdropJob(service AirdropHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.QueryJob(ctx.Context(), req.ID)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func airdropReport(service AirdropHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+			buf bytes.Buffer
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		if err := service.WriteReport(ctx.Context(), req.ID, &buf); err != nil {
+			return err
+		}
+		ctx.Set(fiber.HeaderContentType, "text/csv")
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="airdrop-%s.csv"`, req.ID))
+		return ctx.Status(http.StatusOK).Send(buf.Bytes())
+	}
+}
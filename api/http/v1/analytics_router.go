@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"starland-account/configs"
+	"starland-account/internal/pkg/middlewares"
+	"starland-account/internal/pkg/util"
+	"starland-account/internal/service/analytics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsHTTPServer interface {
+	QueryDailyTotals(context.Context, *analytics.StatRangeRequest) ([]*analytics.DailyTotalResponse, error)
+	QueryActivityStats(context.Context, *analytics.StatRangeRequest) ([]*analytics.DailyActivityStatResponse, error)
+	QueryAccountStats(context.Context, *analytics.StatRangeRequest) ([]*analytics.DailyAccountStatResponse, error)
+	Rollup(context.Context, *analytics.StatRangeRequest) error
+}
+
+func InitAnalyticsRouter(app fiber.Router, service AnalyticsHTTPServer, conf *configs.Config) {
+	router := app.Group("v1/admin", middlewares.AdminAuth())
+	router.Get("/analytics/daily", queryDailyTotals(service))
+	router.Get("/analytics/activity", queryActivityStats(service))
+	router.Get("/analytics/account/:id", queryAccountStats(service))
+	router.Post("/analytics/rollup", rollupStats(service))
+}
+
+func queryDailyTotals(service AnalyticsHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		srr, err := parseStatRange(ctx)
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+
+		response, err := service.QueryDailyTotals(ctx.Context(), srr)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func queryActivityStats(service AnalyticsHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		srr, err := parseStatRange(ctx)
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+
+		response, err := service.QueryActivityStats(ctx.Context(), srr)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func queryAccountStats(service AnalyticsHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		srr, err := parseStatRange(ctx)
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+		srr.AccountID = ctx.Params("id")
+
+		response, err := service.QueryAccountStats(ctx.Context(), srr)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func rollupStats(service AnalyticsHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		srr, err := parseStatRange(ctx)
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+
+		if err = service.Rollup(ctx.Context(), srr); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func parseStatRange(ctx *fiber.Ctx) (*analytics.StatRangeRequest, error) {
+	var (
+		req struct {
+			From         string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+			To           string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+			ActivityCode string `query:"activity_code"`
+		}
+		err error
+	)
+
+	if err = ctx.QueryParser(&req); err != nil {
+		return nil, err
+	}
+	if err = util.Validate(&req); err != nil {
+		return nil, err
+	}
+	srr := &analytics.StatRangeRequest{From: req.From, To: req.To}
+	if srr.ActivityCode, err = util.ParseQueryInt("activity_code", req.ActivityCode); err != nil {
+		return nil, err
+	}
+	return srr, nil
+}
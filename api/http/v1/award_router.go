@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"starland-account/configs"
+	"starland-account/internal/pkg/middlewares"
+	"starland-account/internal/pkg/util"
+	"starland-account/internal/service/award"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AwardHTTPServer interface {
+	Award(context.Context, *award.AwardBatchRequest) (*award.AwardBatchResponse, error)
+}
+
+// InternalPrefix is the path of the routes authenticated by ClientAuth instead
+// of Auth.
+const InternalPrefix = "/v1/internal/"
+
+func InitAwardRouter(app fiber.Router, service AwardHTTPServer, conf *configs.Config) {
+	router := app.Group(strings.Trim(InternalPrefix, "/"), middlewares.ClientAuth())
+	router.Post("/awards", awardPoints(service))
+}
+
+func awardPoints(service AwardHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				Items []*award.AwardItem `json:"items" validate:"required,min=1"`
+			}
+		)
+
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		clientID, _ := ctx.Locals(middlewares.ClientKey).(string)
+		res, err := service.Award(ctx.Context(), &award.AwardBatchRequest{
+			ClientID: clientID,
+			Items:    req.Items,
+		})
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
+	}
+}
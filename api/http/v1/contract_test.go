@@ -0,0 +1,553 @@
+package v1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "starland-account/api/http/v1"
+	v2 "starland-account/api/http/v2"
+	"starland-account/api/openapi"
+	"starland-account/api/openapi/client"
+	"starland-account/configs"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/middlewares"
+	"starland-account/internal/service/account"
+	"starland-account/internal/service/activity"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+var now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func testAccount(id string) *account.AccountResponse {
+	return &account.AccountResponse{AccountID: id, Name: "name", Provider: "google", Integral: 10, State: "active"}
+}
+
+type fakeAccountService struct{}
+
+func (fakeAccountService) Auth(context.Context, *account.AccountRequest) error { return nil }
+func (fakeAccountService) QueryAccount(_ context.Context, id string) (*account.AccountResponse, error) {
+	return testAccount(id), nil
+}
+func (fakeAccountService) ClaimPoints(context.Context, *account.ClaimPointsRequest) (string, error) {
+	return "signature", nil
+}
+func (fakeAccountService) SavePointsAddr(context.Context, string, string) error { return nil }
+func (fakeAccountService) Appeal(context.Context, string, string) error         { return nil }
+func (fakeAccountService) ExportAccount(_ context.Context, id string) (*account.AccountExport, error) {
+	return &account.AccountExport{
+		ExportedAt:   now,
+		Profile:      testAccount(id),
+		Identities:   []*account.IdentityResponse{{Provider: "google", Email: "a@b.c"}},
+		ActivityLogs: []*account.ActivityLogExport{{ActivityCode: 1, ActivityName: "play", Integral: 5, CreateAt: now}},
+		Claims:       []*account.ClaimLogResponse{{Points: 5, Received: 5, ClaimCount: 1, CreateAt: now}},
+		StateLogs:    []*account.AccountStateLogResponse{{FromState: "active", ToState: "suspended", CreateAt: now}},
+	}, nil
+}
+func (fakeAccountService) DeleteAccount(context.Context, string, string) error { return nil }
+func (fakeAccountService) UpdateProfile(_ context.Context, req *account.UpdateProfileRequest) (*account.AccountResponse, error) {
+	return testAccount(req.AccountID), nil
+}
+func (fakeAccountService) QueryProfileChanges(context.Context, string) ([]*account.ProfileChangeResponse, error) {
+	return []*account.ProfileChangeResponse{{Field: "name", OldValue: "a", NewValue: "b", Actor: "a", CreateAt: now}}, nil
+}
+func (fakeAccountService) UploadAvatar(_ context.Context, id string, _ []byte) (*account.AvatarResponse, error) {
+	return &account.AvatarResponse{AvatarURL: "http://x/a.png", Thumbnails: map[string]string{"64": "http://x/a64.png"}, Account: testAccount(id)}, nil
+}
+func (fakeAccountService) SendEmailVerification(context.Context, string) error { return nil }
+func (fakeAccountService) VerifyEmail(_ context.Context, id, _ string) (*account.AccountResponse, error) {
+	return testAccount(id), nil
+}
+func (fakeAccountService) SendMagicLink(context.Context, string) error { return nil }
+func (fakeAccountService) LoginMagicLink(context.Context, string) (*account.AccountResponse, error) {
+	return testAccount("abc"), nil
+}
+
+type fakeActivityService struct {
+	playErr error
+}
+
+func (s fakeActivityService) Play(context.Context, *activity.PlayRequest) error { return s.playErr }
+func (fakeActivityService) QueryActivityLogs(_ context.Context, req *activity.ActivityLogQueryRequest) (*activity.ActivityLogPageResponse, error) {
+	return &activity.ActivityLogPageResponse{
+		Data:       []*activity.ActivityLogResponse{{CreateAt: now, Account: req.Account, ActivityCode: 1, ActivityName: "play", Integral: 5}},
+		Count:      1,
+		NextCursor: "",
+		Totals:     []*activity.ActivityLogTotalResponse{{ActivityCode: 1, ActivityName: "play", Count: 1, Integral: 5}},
+	}, nil
+}
+func (fakeActivityService) WriteActivityLogs(_ context.Context, _ *activity.ActivityLogQueryRequest, _ string, w io.Writer) error {
+	_, err := io.WriteString(w, "id,account_id\n")
+	return err
+}
+func (fakeActivityService) QueryActivitys(context.Context) ([]*activity.ActivityResponse, error) {
+	return []*activity.ActivityResponse{{ActivityName: "play", ActivityCode: 1, Integral: 5}}, nil
+}
+func (fakeActivityService) QueryIsLimit(context.Context, int, string) (bool, error) {
+	return false, nil
+}
+
+func newTestApp(t *testing.T) (*fiber.App, *openapi3.T) {
+	t.Helper()
+	return newTestAppWith(t, fakeActivityService{})
+}
+
+func newTestAppWith(t *testing.T, act fakeActivityService) (*fiber.App, *openapi3.T) {
+	t.Helper()
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	validate, err := middlewares.ValidateRequest(doc)
+	if err != nil {
+		t.Fatalf("spec router: %v", err)
+	}
+	conf := &configs.Config{HTTP: &configs.HTTPConfig{V1Sunset: "2027-06-30"}}
+	app := fiber.New(fiber.Config{ErrorHandler: middlewares.ErrorHandler})
+	app.Use(requestid.New(), middlewares.RouteUsage(), validate)
+	v1.InitAccountRouter(app, fakeAccountService{}, conf)
+	v1.InitActivityRouter(app, act, conf)
+	v2.InitAccountRouter(app, fakeAccountService{}, conf)
+	v2.InitActivityRouter(app, act, conf)
+	return app, doc
+}
+
+var fiberParam = regexp.MustCompile(`:(\w+)`)
+
+// TestRoutesMatchSpec checks the spec documents every public account and
+// activity route, and nothing else.
+func TestRoutesMatchSpec(t *testing.T) {
+	app, doc := newTestApp(t)
+
+	routes := map[string]bool{}
+	for _, r := range app.GetRoutes(true) {
+		if r.Method == fiber.MethodHead || r.Method == "USE" {
+			continue
+		}
+		routes[r.Method+" "+fiberParam.ReplaceAllString(r.Path, "{$1}")] = true
+	}
+	documented := map[string]bool{}
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			documented[method+" "+path] = true
+		}
+	}
+
+	for _, r := range sortedKeys(routes) {
+		if !documented[r] {
+			t.Errorf("route %s is missing from the spec", r)
+		}
+	}
+	for _, d := range sortedKeys(documented) {
+		if !routes[d] {
+			t.Errorf("spec operation %s has no route", d)
+		}
+	}
+}
+
+// TestResponsesMatchSpec sends a valid request to every operation and checks
+// both the request and the handler's response against the spec.
+func TestResponsesMatchSpec(t *testing.T) {
+	app, doc := newTestApp(t)
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avatar, avatarType := multipartFile(t)
+	cases := []struct {
+		method, target, contentType string
+		body                        []byte
+	}{
+		{http.MethodPost, "/v1/account", fiber.MIMEApplicationJSON, []byte(`{"account_id":"abc","provider":"google"}`)},
+		{http.MethodPost, "/v1/account/claim_points", fiber.MIMEApplicationJSON, []byte(`{"account_id":"abc","points":5,"is_ok":true}`)},
+		{http.MethodPost, "/v1/account/magic_link", fiber.MIMEApplicationJSON, []byte(`{"email":"a@b.c"}`)},
+		{http.MethodPost, "/v1/account/magic_link/login", fiber.MIMEApplicationJSON, []byte(`{"token":"t"}`)},
+		{http.MethodGet, "/v1/account/abc", "", nil},
+		{http.MethodPatch, "/v1/account/abc", fiber.MIMEApplicationJSON, []byte(`{"name":"new","avatar_url":null}`)},
+		{http.MethodDelete, "/v1/account/abc?actor=abc", "", nil},
+		{http.MethodPost, "/v1/account/abc/save_points_addr", fiber.MIMEApplicationJSON, []byte(`{"account":"abc","addr":"11111111111111111111111111111111"}`)},
+		{http.MethodPost, "/v1/account/abc/appeal", fiber.MIMEApplicationJSON, []byte(`{"content":"please"}`)},
+		{http.MethodGet, "/v1/account/abc/export", "", nil},
+		{http.MethodGet, "/v1/account/abc/export?format=zip", "", nil},
+		{http.MethodGet, "/v1/account/abc/profile_logs", "", nil},
+		{http.MethodPost, "/v1/account/abc/avatar", avatarType, avatar},
+		{http.MethodPost, "/v1/account/abc/email/send_code", "", nil},
+		{http.MethodPost, "/v1/account/abc/email/verify", fiber.MIMEApplicationJSON, []byte(`{"code":"123456"}`)},
+		{http.MethodGet, "/v1/activity", "", nil},
+		{http.MethodPost, "/v1/activity", fiber.MIMEApplicationJSON, []byte(`{"activity_code":1,"account":"abc"}`)},
+		{http.MethodGet, "/v1/activity/Limit?activity_code=1&account=abc", "", nil},
+		{http.MethodGet, "/v1/activity/log/abc?activity_code=1&from=2024-01-01&limit=10", "", nil},
+		{http.MethodGet, "/v1/activity/log/abc/export?format=csv", "", nil},
+		{http.MethodPost, "/v2/accounts", fiber.MIMEApplicationJSON, []byte(`{"account_id":"abc","provider":"google"}`)},
+		{http.MethodGet, "/v2/accounts/abc", "", nil},
+		{http.MethodPatch, "/v2/accounts/abc", fiber.MIMEApplicationJSON, []byte(`{"name":"new"}`)},
+		{http.MethodDelete, "/v2/accounts/abc", "", nil},
+		{http.MethodPost, "/v2/accounts/abc/claims", fiber.MIMEApplicationJSON, []byte(`{"points":5,"confirm":true}`)},
+		{http.MethodPost, "/v2/accounts/abc/wallets", fiber.MIMEApplicationJSON, []byte(`{"addr":"11111111111111111111111111111111"}`)},
+		{http.MethodPost, "/v2/accounts/abc/appeals", fiber.MIMEApplicationJSON, []byte(`{"content":"please"}`)},
+		{http.MethodGet, "/v2/accounts/abc/export?format=zip", "", nil},
+		{http.MethodGet, "/v2/accounts/abc/profile-changes", "", nil},
+		{http.MethodPut, "/v2/accounts/abc/avatar", avatarType, avatar},
+		{http.MethodPost, "/v2/accounts/abc/verification-codes", "", nil},
+		{http.MethodPost, "/v2/accounts/abc/email-verifications", fiber.MIMEApplicationJSON, []byte(`{"code":"123456"}`)},
+		{http.MethodGet, "/v2/accounts/abc/activity-logs?activity_code=1&limit=10", "", nil},
+		{http.MethodGet, "/v2/accounts/abc/activity-logs/export?format=csv", "", nil},
+		{http.MethodPost, "/v2/magic-links", fiber.MIMEApplicationJSON, []byte(`{"email":"a@b.c"}`)},
+		{http.MethodPost, "/v2/sessions", fiber.MIMEApplicationJSON, []byte(`{"token":"t"}`)},
+		{http.MethodGet, "/v2/activities", "", nil},
+		{http.MethodPost, "/v2/activities/1/plays", fiber.MIMEApplicationJSON, []byte(`{"account_id":"abc"}`)},
+		{http.MethodGet, "/v2/activities/1/limit?account_id=abc", "", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.method+" "+c.target, func(t *testing.T) {
+			req := httptest.NewRequest(c.method, c.target, bytes.NewReader(c.body))
+			if c.contentType != "" {
+				req.Header.Set(fiber.HeaderContentType, c.contentType)
+			}
+			route, params, err := router.FindRoute(req)
+			if err != nil {
+				t.Fatalf("find route: %v", err)
+			}
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: params,
+				Route:      route,
+				Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+			}
+			if err = openapi3filter.ValidateRequest(context.Background(), input); err != nil {
+				t.Fatalf("request does not match spec: %v", err)
+			}
+			req.Body = io.NopCloser(bytes.NewReader(c.body))
+
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status %d: %s", resp.StatusCode, body)
+			}
+			validateResponse(t, input, resp, body)
+		})
+	}
+}
+
+// TestValidateRequestRejectsBadBodies checks the middleware turns bodies
+// violating the spec away before the handler.
+func TestValidateRequestRejectsBadBodies(t *testing.T) {
+	app, _ := newTestApp(t)
+	for _, body := range []string{
+		`{"account":"abc"}`,
+		`{"activity_code":"1","account":"abc"}`,
+		`{"activity_code":1,"account":7}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/activity", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var res struct {
+			Msg string `json:"msg"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&res)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest || res.Msg == "" {
+			t.Errorf("%s: got status %d msg %q, want 400 with a reason", body, resp.StatusCode, res.Msg)
+		}
+	}
+}
+
+// TestValidationErrors checks invalid requests are refused with the failing
+// fields, whether the spec or the handler's validate tags catch them.
+func TestValidationErrors(t *testing.T) {
+	cases := []struct {
+		method, target, body, field string
+	}{
+		{http.MethodPost, "/v1/account/claim_points", `{"account_id":"abc","points":0}`, "points"},
+		{http.MethodPost, "/v1/account/claim_points", `{"account_id":"","points":5}`, "account_id"},
+		{http.MethodPost, "/v1/account/abc/save_points_addr", `{"account":"abc","addr":"zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz"}`, "addr"},
+		{http.MethodPost, "/v1/account/magic_link", `{"email":"not-an-email"}`, "email"},
+		{http.MethodPost, "/v1/account", `{"provider":"google"}`, "account_id"},
+		{http.MethodGet, "/v1/activity/log/abc?limit=5000", "", "limit"},
+		{http.MethodGet, "/v1/activity/Limit?activity_code=1", "", "account"},
+		{http.MethodGet, "/v1/account/abc/export?format=xml", "", "format"},
+		{http.MethodPost, "/v2/accounts/abc/claims", `{"points":-1}`, "points"},
+		{http.MethodPost, "/v2/accounts/abc/wallets", `{"addr":""}`, "addr"},
+		{http.MethodPost, "/v2/activities/1/plays", `{}`, "account_id"},
+		{http.MethodGet, "/v2/activities/1/limit", "", "account_id"},
+	}
+	app, _ := newTestApp(t)
+	for _, c := range cases {
+		t.Run(c.method+" "+c.target, func(t *testing.T) {
+			req := httptest.NewRequest(c.method, c.target, strings.NewReader(c.body))
+			if c.body != "" {
+				req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			}
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			var res struct {
+				Code   string `json:"code"`
+				Errors []struct {
+					Field  string `json:"field"`
+					Reason string `json:"reason"`
+				} `json:"errors"`
+			}
+			if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != http.StatusBadRequest || res.Code != "VALIDATION_FAILED" {
+				t.Fatalf("got %d %s, want 400 VALIDATION_FAILED", resp.StatusCode, res.Code)
+			}
+			if len(res.Errors) == 0 || res.Errors[0].Field != c.field || res.Errors[0].Reason == "" {
+				t.Errorf("errors %+v, want a reason for %s", res.Errors, c.field)
+			}
+		})
+	}
+}
+
+// TestErrorResponses checks service errors map to their status and stable
+// code, carry the request id and keep internal details out of the body.
+func TestErrorResponses(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   string
+		msg    string
+	}{
+		{fmt.Errorf("Play: %w", bizerr.ErrActivityLimitReached), http.StatusTooManyRequests, "ACTIVITY_LIMIT_REACHED", ""},
+		{fmt.Errorf("Play: check account state err: %w", bizerr.ErrAccountBanned), http.StatusForbidden, "ACCOUNT_BANNED", ""},
+		{bizerr.ErrActivityNotExist, http.StatusNotFound, "ACTIVITY_NOT_EXISTS", ""},
+		{bizerr.ErrNotEnoughPoints, http.StatusConflict, "NOT_ENOUGH_POINTS", ""},
+		{bizerr.ErrWalletMismatch, http.StatusConflict, "WALLET_MISMATCH", ""},
+		{bizerr.ErrBadRequest.Errorf("points must be positive"), http.StatusBadRequest, "BAD_REQUEST", "bad request: points must be positive"},
+		{bizerr.ErrBadRequest.Wrap(errors.New("json: secret offset 12")), http.StatusBadRequest, "BAD_REQUEST", "bad request"},
+		{bizerr.ErrInternalError.Wrap(errors.New("dial tcp 10.0.0.1:3306: secret")), http.StatusInternalServerError, "INTERNAL_ERROR", ""},
+		{errors.New("secret"), http.StatusInternalServerError, "INTERNAL_ERROR", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.code, func(t *testing.T) {
+			app, doc := newTestAppWith(t, fakeActivityService{playErr: c.err})
+			router, err := gorillamux.NewRouter(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body := []byte(`{"activity_code":1,"account":"abc"}`)
+			req := httptest.NewRequest(http.MethodPost, "/v1/activity", bytes.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			route, params, err := router.FindRoute(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			raw, _ := io.ReadAll(resp.Body)
+			var res struct {
+				Code      string `json:"code"`
+				Msg       string `json:"msg"`
+				RequestID string `json:"request_id"`
+			}
+			if err = json.Unmarshal(raw, &res); err != nil {
+				t.Fatalf("decode %s: %v", raw, err)
+			}
+			if resp.StatusCode != c.status || res.Code != c.code {
+				t.Errorf("got %d %s, want %d %s", resp.StatusCode, res.Code, c.status, c.code)
+			}
+			if res.RequestID == "" || res.RequestID != resp.Header.Get(fiber.HeaderXRequestID) {
+				t.Errorf("request_id %q does not match header %q", res.RequestID, resp.Header.Get(fiber.HeaderXRequestID))
+			}
+			if strings.Contains(res.Msg, "secret") || strings.Contains(res.Msg, "Play:") {
+				t.Errorf("msg leaks details: %q", res.Msg)
+			}
+			if c.msg != "" && res.Msg != c.msg {
+				t.Errorf("msg = %q, want %q", res.Msg, c.msg)
+			}
+			validateResponse(t, &openapi3filter.RequestValidationInput{Request: req, PathParams: params, Route: route}, resp, raw)
+		})
+	}
+}
+
+// TestDeprecationHeaders checks v1 routes announce their retirement and link
+// the v2 route replacing them, and v2 routes don't.
+func TestDeprecationHeaders(t *testing.T) {
+	app, _ := newTestApp(t)
+	cases := []struct {
+		method, target, link string
+	}{
+		{http.MethodGet, "/v1/account/abc", "</v2/accounts/abc>"},
+		{http.MethodGet, "/v1/activity/log/abc", "</v2/accounts/abc/activity-logs>"},
+		{http.MethodPost, "/v1/account/claim_points", "</v2/accounts/{id}/claims>"},
+		{http.MethodGet, "/v2/accounts/abc", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.method+" "+c.target, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(c.method, c.target, nil), -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if c.link == "" {
+				if h := resp.Header.Get("Deprecation"); h != "" {
+					t.Errorf("Deprecation %q on a current route", h)
+				}
+				return
+			}
+			if resp.Header.Get("Deprecation") != "true" {
+				t.Errorf("missing Deprecation header")
+			}
+			if got := resp.Header.Get("Sunset"); got != "Wed, 30 Jun 2027 00:00:00 GMT" {
+				t.Errorf("Sunset %q", got)
+			}
+			if got := resp.Header.Get(fiber.HeaderLink); got != c.link+`; rel="successor-version"` {
+				t.Errorf("Link %q, want %s", got, c.link)
+			}
+		})
+	}
+}
+
+// deleteRecorder records the actor of each account deletion.
+type deleteRecorder struct {
+	fakeAccountService
+	actors []string
+}
+
+func (r *deleteRecorder) DeleteAccount(_ context.Context, _, actor string) error {
+	// fiber reuses the buffer of the path params after the request
+	r.actors = append(r.actors, strings.Clone(actor))
+	return nil
+}
+
+// TestDeleteAccountActor checks a deletion is recorded as the account's own,
+// whatever actor the caller names.
+func TestDeleteAccountActor(t *testing.T) {
+	svc := &deleteRecorder{}
+	conf := &configs.Config{HTTP: &configs.HTTPConfig{}}
+	app := fiber.New(fiber.Config{ErrorHandler: middlewares.ErrorHandler})
+	v1.InitAccountRouter(app, svc, conf)
+	v2.InitAccountRouter(app, svc, conf)
+	for _, target := range []string{"/v1/account/abc?actor=admin", "/v2/accounts/abc?actor=admin"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodDelete, target, nil), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("DELETE %s = %d", target, resp.StatusCode)
+		}
+	}
+	if len(svc.actors) != 2 || svc.actors[0] != "abc" || svc.actors[1] != "abc" {
+		t.Errorf("actors = %v, want the account for both", svc.actors)
+	}
+}
+
+// TestGeneratedClient drives the handlers through the generated client.
+func TestGeneratedClient(t *testing.T) {
+	app, _ := newTestApp(t)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = app.Listener(ln) }()
+	defer func() { _ = app.Shutdown() }()
+
+	c, err := client.NewClientWithResponses("http://" + ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	acc, err := c.QueryAccountWithResponse(ctx, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc.JSON200 == nil || acc.JSON200.Data == nil || acc.JSON200.Data.AccountId != "abc" {
+		t.Fatalf("query account: unexpected response %s", acc.Body)
+	}
+
+	play, err := c.PlayWithResponse(ctx, nil, client.PlayJSONRequestBody{ActivityCode: 1, Account: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if play.JSON200 == nil || play.JSON200.Code != "0" {
+		t.Fatalf("play: unexpected response %s", play.Body)
+	}
+
+	code := 1
+	logs, err := c.QueryActivityLogsWithResponse(ctx, "abc", &client.QueryActivityLogsParams{ActivityCode: &code})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logs.JSON200 == nil || logs.JSON200.Data == nil || logs.JSON200.Data.Count != 1 {
+		t.Fatalf("query logs: unexpected response %s", logs.Body)
+	}
+
+	claim, err := c.V2CreateClaimWithResponse(ctx, "abc", client.V2CreateClaimJSONRequestBody{Points: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claim.JSON200 == nil || claim.JSON200.Data == nil || claim.JSON200.Data.Signature != "signature" {
+		t.Fatalf("create claim: unexpected response %s", claim.Body)
+	}
+}
+
+func validateResponse(t *testing.T, input *openapi3filter.RequestValidationInput, resp *http.Response, body []byte) {
+	t.Helper()
+	err := openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 resp.StatusCode,
+		Header:                 resp.Header,
+		Body:                   io.NopCloser(bytes.NewReader(body)),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	})
+	if err != nil {
+		t.Fatalf("response does not match spec: %v\n%s", err, body)
+	}
+}
+
+func multipartFile(t *testing.T) ([]byte, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", "a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = part.Write([]byte("png"))
+	_ = w.Close()
+	return buf.Bytes(), w.FormDataContentType()
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,156 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"starland-account/configs"
+	"starland-account/internal/pkg/middlewares"
+	"starland-account/internal/pkg/util"
+	"starland-account/internal/service/webhook"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookHTTPServer interface {
+	CreateSubscription(context.Context, *webhook.CreateSubscriptionRequest) (*webhook.SubscriptionResponse, error)
+	QuerySubscriptions(context.Context) ([]*webhook.SubscriptionResponse, error)
+	EnableSubscription(context.Context, string) error
+	DisableSubscription(context.Context, string, string) error
+	DeleteSubscription(context.Context, string) error
+	QueryDeliveries(context.Context, string, int) ([]*webhook.DeliveryResponse, error)
+	QueryAttempts(context.Context, string) ([]*webhook.AttemptResponse, error)
+	ReplayDelivery(context.Context, string) error
+}
+
+func InitWebhookRouter(app fiber.Router, service WebhookHTTPServer, conf *configs.Config) {
+	router := app.Group("v1/admin", middlewares.AdminAuth())
+	router.Post("/webhook", createWebhook(service))
+	router.Get("/webhook", queryWebhooks(service))
+	router.Post("/webhook/:id/enable", enableWebhook(service))
+	router.Post("/webhook/:id/disable", disableWebhook(service))
+	router.Delete("/webhook/:id", deleteWebhook(service))
+	router.Get("/webhook/:id/deliveries", queryWebhookDeliveries(service))
+	router.Get("/webhook/delivery/:id/attempts", queryWebhookAttempts(service))
+	router.Post("/webhook/delivery/:id/replay", replayWebhookDelivery(service))
+}
+
+func createWebhook(service WebhookHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				URL         string   `json:"url" validate:"required,http_url,max=1024"`
+				Secret      string   `json:"secret" validate:"omitempty,min=16,max=128"`
+				Events      []string `json:"events" validate:"required,min=1"`
+				Description string   `json:"description" validate:"max=256"`
+			}
+		)
+
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		res, err := service.CreateSubscription(ctx.Context(), &webhook.CreateSubscriptionRequest{
+			URL:         req.URL,
+			Secret:      req.Secret,
+			Events:      req.Events,
+			Description: req.Description,
+		})
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
+	}
+}
+
+func queryWebhooks(service WebhookHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		res, err := service.QuerySubscriptions(ctx.Context())
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
+	}
+}
+
+func enableWebhook(service WebhookHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		if err := service.EnableSubscription(ctx.Context(), ctx.Params("id")); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(nil))
+	}
+}
+
+func disableWebhook(service WebhookHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				Reason string `json:"reason" validate:"max=256"`
+			}
+		)
+
+		if len(ctx.Body()) > 0 {
+			if err := ctx.BodyParser(&req); err != nil {
+				return util.InvalidRequest(err)
+			}
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		if err := service.DisableSubscription(ctx.Context(), ctx.Params("id"), req.Reason); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(nil))
+	}
+}
+
+func deleteWebhook(service WebhookHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		if err := service.DeleteSubscription(ctx.Context(), ctx.Params("id")); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(nil))
+	}
+}
+
+func queryWebhookDeliveries(service WebhookHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		limit, err := util.ParseQueryInt("limit", ctx.Query("limit"))
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+		n := 0
+		if limit != nil {
+			n = *limit
+		}
+
+		res, err := service.QueryDeliveries(ctx.Context(), ctx.Params("id"), n)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
+	}
+}
+
+func queryWebhookAttempts(service WebhookHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		res, err := service.QueryAttempts(ctx.Context(), ctx.Params("id"))
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
+	}
+}
+
+func replayWebhookDelivery(service WebhookHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		if err := service.ReplayDelivery(ctx.Context(), ctx.Params("id")); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(nil))
+	}
+}
@@ -0,0 +1,451 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"starland-account/configs"
+	"starland-account/internal/pkg/middlewares"
+	"starland-account/internal/pkg/util"
+	"starland-account/internal/service/account"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AccountHTTPServer interface {
+	Auth(context.Context, *account.AccountRequest) error
+	QueryAccount(context.Context, string) (*account.AccountResponse, error)
+	ClaimPoints(context.Context, *account.ClaimPointsRequest) (string, error)
+	SavePointsAddr(context.Context, string, string) error
+	Appeal(context.Context, string, string) error
+	ExportAccount(context.Context, string) (*account.AccountExport, error)
+	DeleteAccount(context.Context, string, string) error
+	UpdateProfile(context.Context, *account.UpdateProfileRequest) (*account.AccountResponse, error)
+	QueryProfileChanges(context.Context, string) ([]*account.ProfileChangeResponse, error)
+	UploadAvatar(context.Context, string, []byte) (*account.AvatarResponse, error)
+	SendEmailVerification(context.Context, string) error
+	VerifyEmail(context.Context, string, string) (*account.AccountResponse, error)
+	SendMagicLink(context.Context, string) error
+	LoginMagicLink(context.Context, string) (*account.AccountResponse, error)
+}
+
+func InitAccountRouter(app fiber.Router, service AccountHTTPServer, conf *configs.Config) {
+	router := app.Group("v2")
+	router.Post("/accounts", signIn(service))
+	router.Get("/accounts/:id", queryAccount(service))
+	router.Patch("/accounts/:id", updateProfile(service))
+	router.Delete("/accounts/:id", deleteAccount(service))
+	router.Post("/accounts/:id/claims", createClaim(service))
+	router.Post("/accounts/:id/wallets", bindWallet(service))
+	router.Post("/accounts/:id/appeals", createAppeal(service))
+	router.Get("/accounts/:id/export", exportAccount(service))
+	router.Get("/accounts/:id/profile-changes", queryProfileChanges(service))
+	router.Put("/accounts/:id/avatar", uploadAvatar(service))
+	router.Post("/accounts/:id/verification-codes", sendVerificationCode(service))
+	router.Post("/accounts/:id/email-verifications", verifyEmail(service))
+	router.Post("/magic-links", sendMagicLink(service))
+	router.Post("/sessions", createSession(service))
+}
+
+// signIn registers the account on first use; account_id may be left out when
+// signing in by email.
+func signIn(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				AccountID string `json:"account_id" validate:"required_without=Email,max=128"`
+				Email     string `json:"email" validate:"omitempty,email"`
+				Name      string `json:"name" validate:"max=64"`
+				Provider  string `json:"provider" validate:"required,max=32"`
+				AvatarURL string `json:"avatar_url" validate:"omitempty,url,max=1024"`
+			}
+		)
+
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		act := &account.AccountRequest{
+			AccountID: req.AccountID,
+			Email:     req.Email,
+			Name:      req.Name,
+			Provider:  req.Provider,
+			AvatarURL: req.AvatarURL,
+		}
+		if err := service.Auth(ctx.Context(), act); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func queryAccount(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.QueryAccount(ctx.Context(), req.ID)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func updateProfile(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID        string  `params:"id" validate:"required,max=128"`
+				Name      *string `json:"name"`
+				AvatarURL *string `json:"avatar_url"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		upr := &account.UpdateProfileRequest{
+			AccountID: req.ID,
+			Name:      req.Name,
+			AvatarURL: req.AvatarURL,
+			Actor:     req.ID,
+		}
+		response, err := service.UpdateProfile(ctx.Context(), upr)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func deleteAccount(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+		// the actor is who authenticated: an admin, or else the tenant acting
+		// for the account itself
+		actor := middlewares.Admin(ctx)
+		if actor == "" {
+			actor = req.ID
+		}
+
+		if err := service.DeleteAccount(ctx.Context(), req.ID, actor); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+// createClaim returns the signature redeeming points on chain. The claim is
+// only recorded with confirm, which v1 called is_ok.
+func createClaim(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID      string `params:"id" validate:"required,max=128"`
+				Points  int    `json:"points" validate:"gt=0"`
+				Confirm bool   `json:"confirm"`
+			}
+			res struct {
+				Signature string `json:"signature"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		signature, err := service.ClaimPoints(ctx.Context(), &account.ClaimPointsRequest{
+			AccountID: req.ID,
+			Points:    req.Points,
+			IsOK:      req.Confirm,
+		})
+		if err != nil {
+			return err
+		}
+		res.Signature = signature
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
+	}
+}
+
+func bindWallet(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID   string `params:"id" validate:"required,max=128"`
+				Addr string `json:"addr" validate:"required,solana_addr"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		if err := service.SavePointsAddr(ctx.Context(), req.ID, req.Addr); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func createAppeal(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID      string `params:"id" validate:"required,max=128"`
+				Content string `json:"content" validate:"required,max=2000"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		if err := service.Appeal(ctx.Context(), req.ID, req.Content); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func exportAccount(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID     string `params:"id" validate:"required,max=128"`
+				Format string `query:"format" validate:"omitempty,oneof=json zip"`
+			}
+			buf bytes.Buffer
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.QueryParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		export, err := service.ExportAccount(ctx.Context(), req.ID)
+		if err != nil {
+			return err
+		}
+		if req.Format != "zip" {
+			return ctx.Status(http.StatusOK).JSON(util.MakeResponse(export))
+		}
+
+		if err = account.WriteExportZip(&buf, export); err != nil {
+			return err
+		}
+		ctx.Set(fiber.HeaderContentType, "application/zip")
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="account-%s.zip"`, req.ID))
+		return ctx.Status(http.StatusOK).Send(buf.Bytes())
+	}
+}
+
+func queryProfileChanges(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.QueryProfileChanges(ctx.Context(), req.ID)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func uploadAvatar(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+		fh, err := ctx.FormFile("file")
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return err
+		}
+
+		response, err := service.UploadAvatar(ctx.Context(), req.ID, data)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func sendVerificationCode(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID string `params:"id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		if err := service.SendEmailVerification(ctx.Context(), req.ID); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func verifyEmail(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				ID   string `params:"id" validate:"required,max=128"`
+				Code string `json:"code" validate:"required,max=16"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.VerifyEmail(ctx.Context(), req.ID, req.Code)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func sendMagicLink(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				Email string `json:"email" validate:"required,email"`
+			}
+		)
+
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		if err := service.SendMagicLink(ctx.Context(), req.Email); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+// createSession signs in with a magic link token.
+func createSession(service AccountHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				Token string `json:"token" validate:"required"`
+			}
+		)
+
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		response, err := service.LoginMagicLink(ctx.Context(), req.Token)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
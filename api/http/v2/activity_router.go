@@ -0,0 +1,192 @@
+package v2
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"starland-account/configs"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/tenant"
+	"starland-account/internal/pkg/util"
+	"starland-account/internal/service/activity"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type ActivityHTTPServer interface {
+	Play(context.Context, *activity.PlayRequest) error
+	QueryActivityLogs(context.Context, *activity.ActivityLogQueryRequest) (*activity.ActivityLogPageResponse, error)
+	WriteActivityLogs(context.Context, *activity.ActivityLogQueryRequest, string, io.Writer) error
+	QueryActivitys(ctx context.Context) ([]*activity.ActivityResponse, error)
+	QueryIsLimit(context.Context, int, string) (bool, error)
+}
+
+func InitActivityRouter(app fiber.Router, service ActivityHTTPServer, conf *configs.Config) {
+	router := app.Group("v2")
+	router.Get("/activities", queryActivities(service))
+	router.Post("/activities/:code/plays", createPlay(service))
+	router.Get("/activities/:code/limit", queryLimit(service))
+	router.Get("/accounts/:id/activity-logs", queryActivityLogs(service))
+	router.Get("/accounts/:id/activity-logs/export", exportActivityLogs(service))
+}
+
+func queryActivities(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		response, err := service.QueryActivitys(ctx.Context())
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+func createPlay(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				Code      int    `params:"code" validate:"gte=0"`
+				AccountID string `json:"account_id" validate:"required,max=128"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.BodyParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		pr := &activity.PlayRequest{
+			ActivityCode: req.Code,
+			Account:      req.AccountID,
+			IP:           ctx.IP(),
+			DeviceID:     ctx.Get("X-Device-ID"),
+		}
+		if err := service.Play(ctx.Context(), pr); err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse("ok"))
+	}
+}
+
+func queryLimit(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		var (
+			req struct {
+				Code      int    `params:"code" validate:"gte=0"`
+				AccountID string `query:"account_id" validate:"required,max=128"`
+			}
+
+			res struct {
+				IsLimit bool `json:"is_limit"`
+			}
+		)
+
+		if err := ctx.ParamsParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := ctx.QueryParser(&req); err != nil {
+			return util.InvalidRequest(err)
+		}
+		if err := util.Validate(&req); err != nil {
+			return err
+		}
+
+		b, err := service.QueryIsLimit(ctx.Context(), req.Code, req.AccountID)
+		if err != nil {
+			return err
+		}
+		res.IsLimit = b
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(res))
+	}
+}
+
+func queryActivityLogs(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		alq, err := parseActivityLogQuery(ctx)
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+
+		response, err := service.QueryActivityLogs(ctx.Context(), alq)
+		if err != nil {
+			return err
+		}
+		return ctx.Status(http.StatusOK).JSON(util.MakeResponse(response))
+	}
+}
+
+// exportActivityLogs streams the history like v1 does, see there.
+func exportActivityLogs(service ActivityHTTPServer) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		alq, err := parseActivityLogQuery(ctx)
+		if err != nil {
+			return util.InvalidRequest(err)
+		}
+		format := ctx.Query("format", activity.LogFormatCSV)
+		switch format {
+		case activity.LogFormatCSV:
+			ctx.Set(fiber.HeaderContentType, "text/csv")
+		case activity.LogFormatNDJSON:
+			ctx.Set(fiber.HeaderContentType, "application/x-ndjson")
+		default:
+			return bizerr.ErrBadRequest.Errorf("unknown format %s", format)
+		}
+
+		ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="activity-log-%s.%s"`, alq.Account, format))
+		bg := tenant.Detach(ctx.Context())
+		ctx.Status(http.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			if err := service.WriteActivityLogs(bg, alq, format, w); err != nil {
+				zap.S().Errorf("exportActivityLogs: write %s err: %v", format, err)
+			}
+		})
+		return nil
+	}
+}
+
+func parseActivityLogQuery(ctx *fiber.Ctx) (*activity.ActivityLogQueryRequest, error) {
+	var (
+		req struct {
+			ID           string `params:"id" validate:"required,max=128"`
+			ActivityCode string `query:"activity_code"`
+			From         string `query:"from"`
+			To           string `query:"to"`
+			Page         int    `query:"page" validate:"gte=0"`
+			Limit        int    `query:"limit" validate:"gte=0,lte=1000"`
+			Cursor       string `query:"cursor"`
+		}
+		err error
+	)
+
+	if err = ctx.ParamsParser(&req); err != nil {
+		return nil, err
+	}
+	if err = ctx.QueryParser(&req); err != nil {
+		return nil, err
+	}
+	if err = util.Validate(&req); err != nil {
+		return nil, err
+	}
+	alq := &activity.ActivityLogQueryRequest{
+		Account: req.ID,
+		Page:    req.Page,
+		Limit:   req.Limit,
+		Cursor:  req.Cursor,
+	}
+	if alq.ActivityCode, err = util.ParseQueryInt("activity_code", req.ActivityCode); err != nil {
+		return nil, err
+	}
+	if alq.From, err = util.ParseQueryTime("from", req.From); err != nil {
+		return nil, err
+	}
+	if alq.To, err = util.ParseQueryTime("to", req.To); err != nil {
+		return nil, err
+	}
+	return alq, nil
+}
@@ -0,0 +1,6164 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.1.0 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	TokenScopes = "token.Scopes"
+)
+
+// Defines values for ExportAccountParamsFormat.
+const (
+	ExportAccountParamsFormatJson ExportAccountParamsFormat = "json"
+	ExportAccountParamsFormatZip  ExportAccountParamsFormat = "zip"
+)
+
+// Defines values for ExportActivityLogsParamsFormat.
+const (
+	ExportActivityLogsParamsFormatCsv    ExportActivityLogsParamsFormat = "csv"
+	ExportActivityLogsParamsFormatNdjson ExportActivityLogsParamsFormat = "ndjson"
+)
+
+// Defines values for V2ExportActivityLogsParamsFormat.
+const (
+	V2ExportActivityLogsParamsFormatCsv    V2ExportActivityLogsParamsFormat = "csv"
+	V2ExportActivityLogsParamsFormatNdjson V2ExportActivityLogsParamsFormat = "ndjson"
+)
+
+// Defines values for V2ExportAccountParamsFormat.
+const (
+	V2ExportAccountParamsFormatJson V2ExportAccountParamsFormat = "json"
+	V2ExportAccountParamsFormatZip  V2ExportAccountParamsFormat = "zip"
+)
+
+// Account defines model for Account.
+type Account struct {
+	AccountId     string  `json:"account_id"`
+	AvatarUrl     *string `json:"avatar_url,omitempty"`
+	ClaimCount    *int    `json:"claim_count,omitempty"`
+	Email         *string `json:"email,omitempty"`
+	EmailVerified *bool   `json:"email_verified,omitempty"`
+	Integral      int     `json:"integral"`
+	Name          *string `json:"name,omitempty"`
+	Provider      *string `json:"provider,omitempty"`
+	Received      int     `json:"received"`
+	SolanaAddr    *string `json:"solana_addr,omitempty"`
+	State         string  `json:"state"`
+}
+
+// AccountExport defines model for AccountExport.
+type AccountExport struct {
+	ActivityLogs *[]struct {
+		ActivityCode *int       `json:"activity_code,omitempty"`
+		ActivityName *string    `json:"activity_name,omitempty"`
+		CreateAt     *time.Time `json:"create_at,omitempty"`
+		Integral     *int       `json:"integral,omitempty"`
+	} `json:"activity_logs"`
+	Claims *[]struct {
+		ClaimCount *int       `json:"claim_count,omitempty"`
+		CreateAt   *time.Time `json:"create_at,omitempty"`
+		Points     *int       `json:"points,omitempty"`
+		Received   *int       `json:"received,omitempty"`
+		SolanaAddr *string    `json:"solana_addr,omitempty"`
+	} `json:"claims"`
+	ExportedAt *time.Time `json:"exported_at,omitempty"`
+	Identities *[]struct {
+		Address  *string `json:"address,omitempty"`
+		Email    *string `json:"email,omitempty"`
+		Provider *string `json:"provider,omitempty"`
+	} `json:"identities"`
+	Profile        *Account         `json:"profile,omitempty"`
+	ProfileChanges *[]ProfileChange `json:"profile_changes"`
+	StateLogs      *[]struct {
+		Actor     *string    `json:"actor,omitempty"`
+		CreateAt  *time.Time `json:"create_at,omitempty"`
+		ExpireAt  *time.Time `json:"expire_at"`
+		FromState *string    `json:"from_state,omitempty"`
+		Reason    *string    `json:"reason,omitempty"`
+		ToState   *string    `json:"to_state,omitempty"`
+	} `json:"state_logs"`
+}
+
+// Activity defines model for Activity.
+type Activity struct {
+	ActivityCode int    `json:"activity_code"`
+	ActivityName string `json:"activity_name"`
+	Integral     int    `json:"integral"`
+}
+
+// ActivityLog defines model for ActivityLog.
+type ActivityLog struct {
+	Account      string    `json:"account"`
+	ActivityCode int       `json:"activity_code"`
+	ActivityName *string   `json:"activity_name,omitempty"`
+	ClientId     *string   `json:"client_id,omitempty"`
+	CreateAt     time.Time `json:"create_at"`
+	Integral     int       `json:"integral"`
+}
+
+// ActivityLogPage defines model for ActivityLogPage.
+type ActivityLogPage struct {
+	Count      int            `json:"count"`
+	Data       *[]ActivityLog `json:"data"`
+	NextCursor string         `json:"next_cursor"`
+	Totals     *[]struct {
+		ActivityCode *int    `json:"activity_code,omitempty"`
+		ActivityName *string `json:"activity_name,omitempty"`
+		Count        *int    `json:"count,omitempty"`
+		Integral     *int    `json:"integral,omitempty"`
+	} `json:"totals,omitempty"`
+}
+
+// AppealRequest defines model for AppealRequest.
+type AppealRequest struct {
+	Content string `json:"content"`
+}
+
+// AuthRequest account_id is required unless email is given.
+type AuthRequest struct {
+	AccountId *string              `json:"account_id,omitempty"`
+	AvatarUrl *string              `json:"avatar_url,omitempty"`
+	Email     *openapi_types.Email `json:"email,omitempty"`
+	Name      *string              `json:"name,omitempty"`
+	Provider  string               `json:"provider"`
+}
+
+// Avatar defines model for Avatar.
+type Avatar struct {
+	Account    *Account           `json:"account,omitempty"`
+	AvatarUrl  *string            `json:"avatar_url,omitempty"`
+	Thumbnails *map[string]string `json:"thumbnails,omitempty"`
+}
+
+// Claim defines model for Claim.
+type Claim struct {
+	Signature string `json:"signature"`
+}
+
+// ClaimPointsRequest defines model for ClaimPointsRequest.
+type ClaimPointsRequest struct {
+	AccountId string `json:"account_id"`
+	IsOk      *bool  `json:"is_ok,omitempty"`
+	Points    int    `json:"points"`
+}
+
+// ClaimRequest defines model for ClaimRequest.
+type ClaimRequest struct {
+	// Confirm record the claim; without it only the signature is returned
+	Confirm *bool `json:"confirm,omitempty"`
+	Points  int   `json:"points"`
+}
+
+// Envelope defines model for Envelope.
+type Envelope struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	// Code stable name of the error, e.g. NOT_ENOUGH_POINTS
+	Code string `json:"code"`
+
+	// Errors the failing fields, on VALIDATION_FAILED
+	Errors    *[]FieldError `json:"errors,omitempty"`
+	Msg       string        `json:"msg"`
+	RequestId string        `json:"request_id"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field the field as sent, e.g. points
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// MagicLinkLoginRequest defines model for MagicLinkLoginRequest.
+type MagicLinkLoginRequest struct {
+	Token string `json:"token"`
+}
+
+// MagicLinkRequest defines model for MagicLinkRequest.
+type MagicLinkRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// PlayRequest defines model for PlayRequest.
+type PlayRequest struct {
+	Account      string `json:"account"`
+	ActivityCode int    `json:"activity_code"`
+}
+
+// PlayV2Request defines model for PlayV2Request.
+type PlayV2Request struct {
+	AccountId string `json:"account_id"`
+}
+
+// ProfileChange defines model for ProfileChange.
+type ProfileChange struct {
+	Actor    *string    `json:"actor,omitempty"`
+	CreateAt *time.Time `json:"create_at,omitempty"`
+	Field    *string    `json:"field,omitempty"`
+	NewValue *string    `json:"new_value,omitempty"`
+	OldValue *string    `json:"old_value,omitempty"`
+}
+
+// SavePointsAddrRequest defines model for SavePointsAddrRequest.
+type SavePointsAddrRequest struct {
+	Account string `json:"account"`
+
+	// Addr base58 solana public key
+	Addr string `json:"addr"`
+}
+
+// UpdateProfileRequest defines model for UpdateProfileRequest.
+type UpdateProfileRequest struct {
+	AvatarUrl *string `json:"avatar_url"`
+	Name      *string `json:"name"`
+}
+
+// VerifyEmailRequest defines model for VerifyEmailRequest.
+type VerifyEmailRequest struct {
+	Code string `json:"code"`
+}
+
+// WalletRequest defines model for WalletRequest.
+type WalletRequest struct {
+	// Addr base58 solana public key
+	Addr string `json:"addr"`
+}
+
+// AccountIDParam defines model for AccountIDParam.
+type AccountIDParam = string
+
+// AccountParam defines model for AccountParam.
+type AccountParam = string
+
+// ActivityCodeParam defines model for ActivityCodeParam.
+type ActivityCodeParam = int
+
+// ActivityCodePathParam defines model for ActivityCodePathParam.
+type ActivityCodePathParam = int
+
+// FromParam defines model for FromParam.
+type FromParam = string
+
+// ToParam defines model for ToParam.
+type ToParam = string
+
+// AccountResponse defines model for AccountResponse.
+type AccountResponse struct {
+	Code string   `json:"code"`
+	Data *Account `json:"data,omitempty"`
+	Msg  string   `json:"msg"`
+}
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse = Error
+
+// OKResponse defines model for OKResponse.
+type OKResponse struct {
+	Code string  `json:"code"`
+	Data *string `json:"data,omitempty"`
+	Msg  string  `json:"msg"`
+}
+
+// UploadAvatarMultipartBody defines parameters for UploadAvatar.
+type UploadAvatarMultipartBody struct {
+	File openapi_types.File `json:"file"`
+}
+
+// ExportAccountParams defines parameters for ExportAccount.
+type ExportAccountParams struct {
+	Format *ExportAccountParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportAccountParamsFormat defines parameters for ExportAccount.
+type ExportAccountParamsFormat string
+
+// PlayParams defines parameters for Play.
+type PlayParams struct {
+	XDeviceID *string `json:"X-Device-ID,omitempty"`
+}
+
+// QueryIsLimitParams defines parameters for QueryIsLimit.
+type QueryIsLimitParams struct {
+	ActivityCode int    `form:"activity_code" json:"activity_code"`
+	Account      string `form:"account" json:"account"`
+}
+
+// QueryActivityLogsParams defines parameters for QueryActivityLogs.
+type QueryActivityLogsParams struct {
+	ActivityCode *ActivityCodeParam `form:"activity_code,omitempty" json:"activity_code,omitempty"`
+
+	// From RFC 3339 time or YYYY-MM-DD.
+	From *FromParam `form:"from,omitempty" json:"from,omitempty"`
+
+	// To RFC 3339 time or YYYY-MM-DD, exclusive.
+	To     *ToParam `form:"to,omitempty" json:"to,omitempty"`
+	Limit  *int     `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor *string  `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Page   *int     `form:"page,omitempty" json:"page,omitempty"`
+}
+
+// ExportActivityLogsParams defines parameters for ExportActivityLogs.
+type ExportActivityLogsParams struct {
+	ActivityCode *ActivityCodeParam `form:"activity_code,omitempty" json:"activity_code,omitempty"`
+
+	// From RFC 3339 time or YYYY-MM-DD.
+	From *FromParam `form:"from,omitempty" json:"from,omitempty"`
+
+	// To RFC 3339 time or YYYY-MM-DD, exclusive.
+	To     *ToParam                        `form:"to,omitempty" json:"to,omitempty"`
+	Format *ExportActivityLogsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ExportActivityLogsParamsFormat defines parameters for ExportActivityLogs.
+type ExportActivityLogsParamsFormat string
+
+// V2ListActivityLogsParams defines parameters for V2ListActivityLogs.
+type V2ListActivityLogsParams struct {
+	ActivityCode *ActivityCodeParam `form:"activity_code,omitempty" json:"activity_code,omitempty"`
+
+	// From RFC 3339 time or YYYY-MM-DD.
+	From *FromParam `form:"from,omitempty" json:"from,omitempty"`
+
+	// To RFC 3339 time or YYYY-MM-DD, exclusive.
+	To     *ToParam `form:"to,omitempty" json:"to,omitempty"`
+	Limit  *int     `form:"limit,omitempty" json:"limit,omitempty"`
+	Cursor *string  `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// V2ExportActivityLogsParams defines parameters for V2ExportActivityLogs.
+type V2ExportActivityLogsParams struct {
+	ActivityCode *ActivityCodeParam `form:"activity_code,omitempty" json:"activity_code,omitempty"`
+
+	// From RFC 3339 time or YYYY-MM-DD.
+	From *FromParam `form:"from,omitempty" json:"from,omitempty"`
+
+	// To RFC 3339 time or YYYY-MM-DD, exclusive.
+	To     *ToParam                          `form:"to,omitempty" json:"to,omitempty"`
+	Format *V2ExportActivityLogsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// V2ExportActivityLogsParamsFormat defines parameters for V2ExportActivityLogs.
+type V2ExportActivityLogsParamsFormat string
+
+// V2UploadAvatarMultipartBody defines parameters for V2UploadAvatar.
+type V2UploadAvatarMultipartBody struct {
+	File openapi_types.File `json:"file"`
+}
+
+// V2ExportAccountParams defines parameters for V2ExportAccount.
+type V2ExportAccountParams struct {
+	Format *V2ExportAccountParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// V2ExportAccountParamsFormat defines parameters for V2ExportAccount.
+type V2ExportAccountParamsFormat string
+
+// V2GetActivityLimitParams defines parameters for V2GetActivityLimit.
+type V2GetActivityLimitParams struct {
+	AccountId string `form:"account_id" json:"account_id"`
+}
+
+// V2CreatePlayParams defines parameters for V2CreatePlay.
+type V2CreatePlayParams struct {
+	XDeviceID *string `json:"X-Device-ID,omitempty"`
+}
+
+// AuthJSONRequestBody defines body for Auth for application/json ContentType.
+type AuthJSONRequestBody = AuthRequest
+
+// ClaimPointsJSONRequestBody defines body for ClaimPoints for application/json ContentType.
+type ClaimPointsJSONRequestBody = ClaimPointsRequest
+
+// SendMagicLinkJSONRequestBody defines body for SendMagicLink for application/json ContentType.
+type SendMagicLinkJSONRequestBody = MagicLinkRequest
+
+// LoginMagicLinkJSONRequestBody defines body for LoginMagicLink for application/json ContentType.
+type LoginMagicLinkJSONRequestBody = MagicLinkLoginRequest
+
+// UpdateProfileJSONRequestBody defines body for UpdateProfile for application/json ContentType.
+type UpdateProfileJSONRequestBody = UpdateProfileRequest
+
+// AppealJSONRequestBody defines body for Appeal for application/json ContentType.
+type AppealJSONRequestBody = AppealRequest
+
+// UploadAvatarMultipartRequestBody defines body for UploadAvatar for multipart/form-data ContentType.
+type UploadAvatarMultipartRequestBody UploadAvatarMultipartBody
+
+// VerifyEmailJSONRequestBody defines body for VerifyEmail for application/json ContentType.
+type VerifyEmailJSONRequestBody = VerifyEmailRequest
+
+// SavePointsAddrJSONRequestBody defines body for SavePointsAddr for application/json ContentType.
+type SavePointsAddrJSONRequestBody = SavePointsAddrRequest
+
+// PlayJSONRequestBody defines body for Play for application/json ContentType.
+type PlayJSONRequestBody = PlayRequest
+
+// V2SignInJSONRequestBody defines body for V2SignIn for application/json ContentType.
+type V2SignInJSONRequestBody = AuthRequest
+
+// V2UpdateAccountJSONRequestBody defines body for V2UpdateAccount for application/json ContentType.
+type V2UpdateAccountJSONRequestBody = UpdateProfileRequest
+
+// V2CreateAppealJSONRequestBody defines body for V2CreateAppeal for application/json ContentType.
+type V2CreateAppealJSONRequestBody = AppealRequest
+
+// V2UploadAvatarMultipartRequestBody defines body for V2UploadAvatar for multipart/form-data ContentType.
+type V2UploadAvatarMultipartRequestBody V2UploadAvatarMultipartBody
+
+// V2CreateClaimJSONRequestBody defines body for V2CreateClaim for application/json ContentType.
+type V2CreateClaimJSONRequestBody = ClaimRequest
+
+// V2VerifyEmailJSONRequestBody defines body for V2VerifyEmail for application/json ContentType.
+type V2VerifyEmailJSONRequestBody = VerifyEmailRequest
+
+// V2BindWalletJSONRequestBody defines body for V2BindWallet for application/json ContentType.
+type V2BindWalletJSONRequestBody = WalletRequest
+
+// V2CreatePlayJSONRequestBody defines body for V2CreatePlay for application/json ContentType.
+type V2CreatePlayJSONRequestBody = PlayV2Request
+
+// V2SendMagicLinkJSONRequestBody defines body for V2SendMagicLink for application/json ContentType.
+type V2SendMagicLinkJSONRequestBody = MagicLinkRequest
+
+// V2CreateSessionJSONRequestBody defines body for V2CreateSession for application/json ContentType.
+type V2CreateSessionJSONRequestBody = MagicLinkLoginRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// AuthWithBody request with any body
+	AuthWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Auth(ctx context.Context, body AuthJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ClaimPointsWithBody request with any body
+	ClaimPointsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ClaimPoints(ctx context.Context, body ClaimPointsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SendMagicLinkWithBody request with any body
+	SendMagicLinkWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SendMagicLink(ctx context.Context, body SendMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LoginMagicLinkWithBody request with any body
+	LoginMagicLinkWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	LoginMagicLink(ctx context.Context, body LoginMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAccount request
+	DeleteAccount(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// QueryAccount request
+	QueryAccount(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateProfileWithBody request with any body
+	UpdateProfileWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateProfile(ctx context.Context, id AccountIDParam, body UpdateProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AppealWithBody request with any body
+	AppealWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Appeal(ctx context.Context, id AccountIDParam, body AppealJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UploadAvatarWithBody request with any body
+	UploadAvatarWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SendEmailVerification request
+	SendEmailVerification(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// VerifyEmailWithBody request with any body
+	VerifyEmailWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	VerifyEmail(ctx context.Context, id AccountIDParam, body VerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportAccount request
+	ExportAccount(ctx context.Context, id AccountIDParam, params *ExportAccountParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// QueryProfileChanges request
+	QueryProfileChanges(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SavePointsAddrWithBody request with any body
+	SavePointsAddrWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SavePointsAddr(ctx context.Context, id AccountIDParam, body SavePointsAddrJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// QueryActivitys request
+	QueryActivitys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PlayWithBody request with any body
+	PlayWithBody(ctx context.Context, params *PlayParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Play(ctx context.Context, params *PlayParams, body PlayJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// QueryIsLimit request
+	QueryIsLimit(ctx context.Context, params *QueryIsLimitParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// QueryActivityLogs request
+	QueryActivityLogs(ctx context.Context, account AccountParam, params *QueryActivityLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportActivityLogs request
+	ExportActivityLogs(ctx context.Context, account AccountParam, params *ExportActivityLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2SignInWithBody request with any body
+	V2SignInWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V2SignIn(ctx context.Context, body V2SignInJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2DeleteAccount request
+	V2DeleteAccount(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2GetAccount request
+	V2GetAccount(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2UpdateAccountWithBody request with any body
+	V2UpdateAccountWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V2UpdateAccount(ctx context.Context, id AccountIDParam, body V2UpdateAccountJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2ListActivityLogs request
+	V2ListActivityLogs(ctx context.Context, id AccountIDParam, params *V2ListActivityLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2ExportActivityLogs request
+	V2ExportActivityLogs(ctx context.Context, id AccountIDParam, params *V2ExportActivityLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2CreateAppealWithBody request with any body
+	V2CreateAppealWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V2CreateAppeal(ctx context.Context, id AccountIDParam, body V2CreateAppealJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2UploadAvatarWithBody request with any body
+	V2UploadAvatarWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2CreateClaimWithBody request with any body
+	V2CreateClaimWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V2CreateClaim(ctx context.Context, id AccountIDParam, body V2CreateClaimJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2VerifyEmailWithBody request with any body
+	V2VerifyEmailWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V2VerifyEmail(ctx context.Context, id AccountIDParam, body V2VerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2ExportAccount request
+	V2ExportAccount(ctx context.Context, id AccountIDParam, params *V2ExportAccountParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2ListProfileChanges request
+	V2ListProfileChanges(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2SendVerificationCode request
+	V2SendVerificationCode(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2BindWalletWithBody request with any body
+	V2BindWalletWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V2BindWallet(ctx context.Context, id AccountIDParam, body V2BindWalletJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2ListActivities request
+	V2ListActivities(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2GetActivityLimit request
+	V2GetActivityLimit(ctx context.Context, code ActivityCodePathParam, params *V2GetActivityLimitParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2CreatePlayWithBody request with any body
+	V2CreatePlayWithBody(ctx context.Context, code ActivityCodePathParam, params *V2CreatePlayParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V2CreatePlay(ctx context.Context, code ActivityCodePathParam, params *V2CreatePlayParams, body V2CreatePlayJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2SendMagicLinkWithBody request with any body
+	V2SendMagicLinkWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V2SendMagicLink(ctx context.Context, body V2SendMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// V2CreateSessionWithBody request with any body
+	V2CreateSessionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	V2CreateSession(ctx context.Context, body V2CreateSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) AuthWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAuthRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Auth(ctx context.Context, body AuthJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAuthRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ClaimPointsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewClaimPointsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ClaimPoints(ctx context.Context, body ClaimPointsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewClaimPointsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SendMagicLinkWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSendMagicLinkRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SendMagicLink(ctx context.Context, body SendMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSendMagicLinkRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LoginMagicLinkWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginMagicLinkRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LoginMagicLink(ctx context.Context, body LoginMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginMagicLinkRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteAccount(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAccountRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QueryAccount(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQueryAccountRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateProfileWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateProfileRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateProfile(ctx context.Context, id AccountIDParam, body UpdateProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateProfileRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AppealWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAppealRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Appeal(ctx context.Context, id AccountIDParam, body AppealJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAppealRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UploadAvatarWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUploadAvatarRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SendEmailVerification(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSendEmailVerificationRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) VerifyEmailWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewVerifyEmailRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) VerifyEmail(ctx context.Context, id AccountIDParam, body VerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewVerifyEmailRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportAccount(ctx context.Context, id AccountIDParam, params *ExportAccountParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportAccountRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QueryProfileChanges(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQueryProfileChangesRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SavePointsAddrWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSavePointsAddrRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SavePointsAddr(ctx context.Context, id AccountIDParam, body SavePointsAddrJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSavePointsAddrRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QueryActivitys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQueryActivitysRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PlayWithBody(ctx context.Context, params *PlayParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPlayRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Play(ctx context.Context, params *PlayParams, body PlayJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPlayRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QueryIsLimit(ctx context.Context, params *QueryIsLimitParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQueryIsLimitRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QueryActivityLogs(ctx context.Context, account AccountParam, params *QueryActivityLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQueryActivityLogsRequest(c.Server, account, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportActivityLogs(ctx context.Context, account AccountParam, params *ExportActivityLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportActivityLogsRequest(c.Server, account, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2SignInWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2SignInRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2SignIn(ctx context.Context, body V2SignInJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2SignInRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2DeleteAccount(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2DeleteAccountRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2GetAccount(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2GetAccountRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2UpdateAccountWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2UpdateAccountRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2UpdateAccount(ctx context.Context, id AccountIDParam, body V2UpdateAccountJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2UpdateAccountRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2ListActivityLogs(ctx context.Context, id AccountIDParam, params *V2ListActivityLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2ListActivityLogsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2ExportActivityLogs(ctx context.Context, id AccountIDParam, params *V2ExportActivityLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2ExportActivityLogsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2CreateAppealWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2CreateAppealRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2CreateAppeal(ctx context.Context, id AccountIDParam, body V2CreateAppealJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2CreateAppealRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2UploadAvatarWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2UploadAvatarRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2CreateClaimWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2CreateClaimRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2CreateClaim(ctx context.Context, id AccountIDParam, body V2CreateClaimJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2CreateClaimRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2VerifyEmailWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2VerifyEmailRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2VerifyEmail(ctx context.Context, id AccountIDParam, body V2VerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2VerifyEmailRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2ExportAccount(ctx context.Context, id AccountIDParam, params *V2ExportAccountParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2ExportAccountRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2ListProfileChanges(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2ListProfileChangesRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2SendVerificationCode(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2SendVerificationCodeRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2BindWalletWithBody(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2BindWalletRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2BindWallet(ctx context.Context, id AccountIDParam, body V2BindWalletJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2BindWalletRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2ListActivities(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2ListActivitiesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2GetActivityLimit(ctx context.Context, code ActivityCodePathParam, params *V2GetActivityLimitParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2GetActivityLimitRequest(c.Server, code, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2CreatePlayWithBody(ctx context.Context, code ActivityCodePathParam, params *V2CreatePlayParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2CreatePlayRequestWithBody(c.Server, code, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2CreatePlay(ctx context.Context, code ActivityCodePathParam, params *V2CreatePlayParams, body V2CreatePlayJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2CreatePlayRequest(c.Server, code, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2SendMagicLinkWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2SendMagicLinkRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2SendMagicLink(ctx context.Context, body V2SendMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2SendMagicLinkRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2CreateSessionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2CreateSessionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) V2CreateSession(ctx context.Context, body V2CreateSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewV2CreateSessionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewAuthRequest calls the generic Auth builder with application/json body
+func NewAuthRequest(server string, body AuthJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAuthRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAuthRequestWithBody generates requests for Auth with any type of body
+func NewAuthRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewClaimPointsRequest calls the generic ClaimPoints builder with application/json body
+func NewClaimPointsRequest(server string, body ClaimPointsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewClaimPointsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewClaimPointsRequestWithBody generates requests for ClaimPoints with any type of body
+func NewClaimPointsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/claim_points")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSendMagicLinkRequest calls the generic SendMagicLink builder with application/json body
+func NewSendMagicLinkRequest(server string, body SendMagicLinkJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSendMagicLinkRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSendMagicLinkRequestWithBody generates requests for SendMagicLink with any type of body
+func NewSendMagicLinkRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/magic_link")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLoginMagicLinkRequest calls the generic LoginMagicLink builder with application/json body
+func NewLoginMagicLinkRequest(server string, body LoginMagicLinkJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLoginMagicLinkRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewLoginMagicLinkRequestWithBody generates requests for LoginMagicLink with any type of body
+func NewLoginMagicLinkRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/magic_link/login")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteAccountRequest generates requests for DeleteAccount
+func NewDeleteAccountRequest(server string, id AccountIDParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewQueryAccountRequest generates requests for QueryAccount
+func NewQueryAccountRequest(server string, id AccountIDParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateProfileRequest calls the generic UpdateProfile builder with application/json body
+func NewUpdateProfileRequest(server string, id AccountIDParam, body UpdateProfileJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateProfileRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateProfileRequestWithBody generates requests for UpdateProfile with any type of body
+func NewUpdateProfileRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewAppealRequest calls the generic Appeal builder with application/json body
+func NewAppealRequest(server string, id AccountIDParam, body AppealJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAppealRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewAppealRequestWithBody generates requests for Appeal with any type of body
+func NewAppealRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/%s/appeal", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUploadAvatarRequestWithBody generates requests for UploadAvatar with any type of body
+func NewUploadAvatarRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/%s/avatar", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSendEmailVerificationRequest generates requests for SendEmailVerification
+func NewSendEmailVerificationRequest(server string, id AccountIDParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/%s/email/send_code", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewVerifyEmailRequest calls the generic VerifyEmail builder with application/json body
+func NewVerifyEmailRequest(server string, id AccountIDParam, body VerifyEmailJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewVerifyEmailRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewVerifyEmailRequestWithBody generates requests for VerifyEmail with any type of body
+func NewVerifyEmailRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/%s/email/verify", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewExportAccountRequest generates requests for ExportAccount
+func NewExportAccountRequest(server string, id AccountIDParam, params *ExportAccountParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/%s/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewQueryProfileChangesRequest generates requests for QueryProfileChanges
+func NewQueryProfileChangesRequest(server string, id AccountIDParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/%s/profile_logs", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSavePointsAddrRequest calls the generic SavePointsAddr builder with application/json body
+func NewSavePointsAddrRequest(server string, id AccountIDParam, body SavePointsAddrJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSavePointsAddrRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewSavePointsAddrRequestWithBody generates requests for SavePointsAddr with any type of body
+func NewSavePointsAddrRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/account/%s/save_points_addr", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewQueryActivitysRequest generates requests for QueryActivitys
+func NewQueryActivitysRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/activity")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPlayRequest calls the generic Play builder with application/json body
+func NewPlayRequest(server string, params *PlayParams, body PlayJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPlayRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPlayRequestWithBody generates requests for Play with any type of body
+func NewPlayRequestWithBody(server string, params *PlayParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/activity")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XDeviceID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Device-ID", runtime.ParamLocationHeader, *params.XDeviceID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Device-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewQueryIsLimitRequest generates requests for QueryIsLimit
+func NewQueryIsLimitRequest(server string, params *QueryIsLimitParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/activity/Limit")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "activity_code", runtime.ParamLocationQuery, params.ActivityCode); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "account", runtime.ParamLocationQuery, params.Account); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewQueryActivityLogsRequest generates requests for QueryActivityLogs
+func NewQueryActivityLogsRequest(server string, account AccountParam, params *QueryActivityLogsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account", runtime.ParamLocationPath, account)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/activity/log/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ActivityCode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "activity_code", runtime.ParamLocationQuery, *params.ActivityCode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportActivityLogsRequest generates requests for ExportActivityLogs
+func NewExportActivityLogsRequest(server string, account AccountParam, params *ExportActivityLogsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "account", runtime.ParamLocationPath, account)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/activity/log/%s/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ActivityCode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "activity_code", runtime.ParamLocationQuery, *params.ActivityCode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV2SignInRequest calls the generic V2SignIn builder with application/json body
+func NewV2SignInRequest(server string, body V2SignInJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV2SignInRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewV2SignInRequestWithBody generates requests for V2SignIn with any type of body
+func NewV2SignInRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewV2DeleteAccountRequest generates requests for V2DeleteAccount
+func NewV2DeleteAccountRequest(server string, id AccountIDParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV2GetAccountRequest generates requests for V2GetAccount
+func NewV2GetAccountRequest(server string, id AccountIDParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV2UpdateAccountRequest calls the generic V2UpdateAccount builder with application/json body
+func NewV2UpdateAccountRequest(server string, id AccountIDParam, body V2UpdateAccountJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV2UpdateAccountRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewV2UpdateAccountRequestWithBody generates requests for V2UpdateAccount with any type of body
+func NewV2UpdateAccountRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewV2ListActivityLogsRequest generates requests for V2ListActivityLogs
+func NewV2ListActivityLogsRequest(server string, id AccountIDParam, params *V2ListActivityLogsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s/activity-logs", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ActivityCode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "activity_code", runtime.ParamLocationQuery, *params.ActivityCode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV2ExportActivityLogsRequest generates requests for V2ExportActivityLogs
+func NewV2ExportActivityLogsRequest(server string, id AccountIDParam, params *V2ExportActivityLogsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s/activity-logs/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ActivityCode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "activity_code", runtime.ParamLocationQuery, *params.ActivityCode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV2CreateAppealRequest calls the generic V2CreateAppeal builder with application/json body
+func NewV2CreateAppealRequest(server string, id AccountIDParam, body V2CreateAppealJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV2CreateAppealRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewV2CreateAppealRequestWithBody generates requests for V2CreateAppeal with any type of body
+func NewV2CreateAppealRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s/appeals", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewV2UploadAvatarRequestWithBody generates requests for V2UploadAvatar with any type of body
+func NewV2UploadAvatarRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s/avatar", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewV2CreateClaimRequest calls the generic V2CreateClaim builder with application/json body
+func NewV2CreateClaimRequest(server string, id AccountIDParam, body V2CreateClaimJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV2CreateClaimRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewV2CreateClaimRequestWithBody generates requests for V2CreateClaim with any type of body
+func NewV2CreateClaimRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s/claims", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewV2VerifyEmailRequest calls the generic V2VerifyEmail builder with application/json body
+func NewV2VerifyEmailRequest(server string, id AccountIDParam, body V2VerifyEmailJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV2VerifyEmailRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewV2VerifyEmailRequestWithBody generates requests for V2VerifyEmail with any type of body
+func NewV2VerifyEmailRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s/email-verifications", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewV2ExportAccountRequest generates requests for V2ExportAccount
+func NewV2ExportAccountRequest(server string, id AccountIDParam, params *V2ExportAccountParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV2ListProfileChangesRequest generates requests for V2ListProfileChanges
+func NewV2ListProfileChangesRequest(server string, id AccountIDParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s/profile-changes", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV2SendVerificationCodeRequest generates requests for V2SendVerificationCode
+func NewV2SendVerificationCodeRequest(server string, id AccountIDParam) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s/verification-codes", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV2BindWalletRequest calls the generic V2BindWallet builder with application/json body
+func NewV2BindWalletRequest(server string, id AccountIDParam, body V2BindWalletJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV2BindWalletRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewV2BindWalletRequestWithBody generates requests for V2BindWallet with any type of body
+func NewV2BindWalletRequestWithBody(server string, id AccountIDParam, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/accounts/%s/wallets", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewV2ListActivitiesRequest generates requests for V2ListActivities
+func NewV2ListActivitiesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/activities")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV2GetActivityLimitRequest generates requests for V2GetActivityLimit
+func NewV2GetActivityLimitRequest(server string, code ActivityCodePathParam, params *V2GetActivityLimitParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "code", runtime.ParamLocationPath, code)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/activities/%s/limit", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "account_id", runtime.ParamLocationQuery, params.AccountId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewV2CreatePlayRequest calls the generic V2CreatePlay builder with application/json body
+func NewV2CreatePlayRequest(server string, code ActivityCodePathParam, params *V2CreatePlayParams, body V2CreatePlayJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV2CreatePlayRequestWithBody(server, code, params, "application/json", bodyReader)
+}
+
+// NewV2CreatePlayRequestWithBody generates requests for V2CreatePlay with any type of body
+func NewV2CreatePlayRequestWithBody(server string, code ActivityCodePathParam, params *V2CreatePlayParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "code", runtime.ParamLocationPath, code)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/activities/%s/plays", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.XDeviceID != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "X-Device-ID", runtime.ParamLocationHeader, *params.XDeviceID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-Device-ID", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewV2SendMagicLinkRequest calls the generic V2SendMagicLink builder with application/json body
+func NewV2SendMagicLinkRequest(server string, body V2SendMagicLinkJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV2SendMagicLinkRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewV2SendMagicLinkRequestWithBody generates requests for V2SendMagicLink with any type of body
+func NewV2SendMagicLinkRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/magic-links")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewV2CreateSessionRequest calls the generic V2CreateSession builder with application/json body
+func NewV2CreateSessionRequest(server string, body V2CreateSessionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewV2CreateSessionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewV2CreateSessionRequestWithBody generates requests for V2CreateSession with any type of body
+func NewV2CreateSessionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// AuthWithBodyWithResponse request with any body
+	AuthWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AuthResponse, error)
+
+	AuthWithResponse(ctx context.Context, body AuthJSONRequestBody, reqEditors ...RequestEditorFn) (*AuthResponse, error)
+
+	// ClaimPointsWithBodyWithResponse request with any body
+	ClaimPointsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ClaimPointsResponse, error)
+
+	ClaimPointsWithResponse(ctx context.Context, body ClaimPointsJSONRequestBody, reqEditors ...RequestEditorFn) (*ClaimPointsResponse, error)
+
+	// SendMagicLinkWithBodyWithResponse request with any body
+	SendMagicLinkWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SendMagicLinkResponse, error)
+
+	SendMagicLinkWithResponse(ctx context.Context, body SendMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*SendMagicLinkResponse, error)
+
+	// LoginMagicLinkWithBodyWithResponse request with any body
+	LoginMagicLinkWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginMagicLinkResponse, error)
+
+	LoginMagicLinkWithResponse(ctx context.Context, body LoginMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginMagicLinkResponse, error)
+
+	// DeleteAccountWithResponse request
+	DeleteAccountWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*DeleteAccountResponse, error)
+
+	// QueryAccountWithResponse request
+	QueryAccountWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*QueryAccountResponse, error)
+
+	// UpdateProfileWithBodyWithResponse request with any body
+	UpdateProfileWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateProfileResponse, error)
+
+	UpdateProfileWithResponse(ctx context.Context, id AccountIDParam, body UpdateProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateProfileResponse, error)
+
+	// AppealWithBodyWithResponse request with any body
+	AppealWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AppealResponse, error)
+
+	AppealWithResponse(ctx context.Context, id AccountIDParam, body AppealJSONRequestBody, reqEditors ...RequestEditorFn) (*AppealResponse, error)
+
+	// UploadAvatarWithBodyWithResponse request with any body
+	UploadAvatarWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadAvatarResponse, error)
+
+	// SendEmailVerificationWithResponse request
+	SendEmailVerificationWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*SendEmailVerificationResponse, error)
+
+	// VerifyEmailWithBodyWithResponse request with any body
+	VerifyEmailWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VerifyEmailResponse, error)
+
+	VerifyEmailWithResponse(ctx context.Context, id AccountIDParam, body VerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*VerifyEmailResponse, error)
+
+	// ExportAccountWithResponse request
+	ExportAccountWithResponse(ctx context.Context, id AccountIDParam, params *ExportAccountParams, reqEditors ...RequestEditorFn) (*ExportAccountResponse, error)
+
+	// QueryProfileChangesWithResponse request
+	QueryProfileChangesWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*QueryProfileChangesResponse, error)
+
+	// SavePointsAddrWithBodyWithResponse request with any body
+	SavePointsAddrWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SavePointsAddrResponse, error)
+
+	SavePointsAddrWithResponse(ctx context.Context, id AccountIDParam, body SavePointsAddrJSONRequestBody, reqEditors ...RequestEditorFn) (*SavePointsAddrResponse, error)
+
+	// QueryActivitysWithResponse request
+	QueryActivitysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*QueryActivitysResponse, error)
+
+	// PlayWithBodyWithResponse request with any body
+	PlayWithBodyWithResponse(ctx context.Context, params *PlayParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PlayResponse, error)
+
+	PlayWithResponse(ctx context.Context, params *PlayParams, body PlayJSONRequestBody, reqEditors ...RequestEditorFn) (*PlayResponse, error)
+
+	// QueryIsLimitWithResponse request
+	QueryIsLimitWithResponse(ctx context.Context, params *QueryIsLimitParams, reqEditors ...RequestEditorFn) (*QueryIsLimitResponse, error)
+
+	// QueryActivityLogsWithResponse request
+	QueryActivityLogsWithResponse(ctx context.Context, account AccountParam, params *QueryActivityLogsParams, reqEditors ...RequestEditorFn) (*QueryActivityLogsResponse, error)
+
+	// ExportActivityLogsWithResponse request
+	ExportActivityLogsWithResponse(ctx context.Context, account AccountParam, params *ExportActivityLogsParams, reqEditors ...RequestEditorFn) (*ExportActivityLogsResponse, error)
+
+	// V2SignInWithBodyWithResponse request with any body
+	V2SignInWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2SignInResponse, error)
+
+	V2SignInWithResponse(ctx context.Context, body V2SignInJSONRequestBody, reqEditors ...RequestEditorFn) (*V2SignInResponse, error)
+
+	// V2DeleteAccountWithResponse request
+	V2DeleteAccountWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*V2DeleteAccountResponse, error)
+
+	// V2GetAccountWithResponse request
+	V2GetAccountWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*V2GetAccountResponse, error)
+
+	// V2UpdateAccountWithBodyWithResponse request with any body
+	V2UpdateAccountWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2UpdateAccountResponse, error)
+
+	V2UpdateAccountWithResponse(ctx context.Context, id AccountIDParam, body V2UpdateAccountJSONRequestBody, reqEditors ...RequestEditorFn) (*V2UpdateAccountResponse, error)
+
+	// V2ListActivityLogsWithResponse request
+	V2ListActivityLogsWithResponse(ctx context.Context, id AccountIDParam, params *V2ListActivityLogsParams, reqEditors ...RequestEditorFn) (*V2ListActivityLogsResponse, error)
+
+	// V2ExportActivityLogsWithResponse request
+	V2ExportActivityLogsWithResponse(ctx context.Context, id AccountIDParam, params *V2ExportActivityLogsParams, reqEditors ...RequestEditorFn) (*V2ExportActivityLogsResponse, error)
+
+	// V2CreateAppealWithBodyWithResponse request with any body
+	V2CreateAppealWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2CreateAppealResponse, error)
+
+	V2CreateAppealWithResponse(ctx context.Context, id AccountIDParam, body V2CreateAppealJSONRequestBody, reqEditors ...RequestEditorFn) (*V2CreateAppealResponse, error)
+
+	// V2UploadAvatarWithBodyWithResponse request with any body
+	V2UploadAvatarWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2UploadAvatarResponse, error)
+
+	// V2CreateClaimWithBodyWithResponse request with any body
+	V2CreateClaimWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2CreateClaimResponse, error)
+
+	V2CreateClaimWithResponse(ctx context.Context, id AccountIDParam, body V2CreateClaimJSONRequestBody, reqEditors ...RequestEditorFn) (*V2CreateClaimResponse, error)
+
+	// V2VerifyEmailWithBodyWithResponse request with any body
+	V2VerifyEmailWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2VerifyEmailResponse, error)
+
+	V2VerifyEmailWithResponse(ctx context.Context, id AccountIDParam, body V2VerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*V2VerifyEmailResponse, error)
+
+	// V2ExportAccountWithResponse request
+	V2ExportAccountWithResponse(ctx context.Context, id AccountIDParam, params *V2ExportAccountParams, reqEditors ...RequestEditorFn) (*V2ExportAccountResponse, error)
+
+	// V2ListProfileChangesWithResponse request
+	V2ListProfileChangesWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*V2ListProfileChangesResponse, error)
+
+	// V2SendVerificationCodeWithResponse request
+	V2SendVerificationCodeWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*V2SendVerificationCodeResponse, error)
+
+	// V2BindWalletWithBodyWithResponse request with any body
+	V2BindWalletWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2BindWalletResponse, error)
+
+	V2BindWalletWithResponse(ctx context.Context, id AccountIDParam, body V2BindWalletJSONRequestBody, reqEditors ...RequestEditorFn) (*V2BindWalletResponse, error)
+
+	// V2ListActivitiesWithResponse request
+	V2ListActivitiesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*V2ListActivitiesResponse, error)
+
+	// V2GetActivityLimitWithResponse request
+	V2GetActivityLimitWithResponse(ctx context.Context, code ActivityCodePathParam, params *V2GetActivityLimitParams, reqEditors ...RequestEditorFn) (*V2GetActivityLimitResponse, error)
+
+	// V2CreatePlayWithBodyWithResponse request with any body
+	V2CreatePlayWithBodyWithResponse(ctx context.Context, code ActivityCodePathParam, params *V2CreatePlayParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2CreatePlayResponse, error)
+
+	V2CreatePlayWithResponse(ctx context.Context, code ActivityCodePathParam, params *V2CreatePlayParams, body V2CreatePlayJSONRequestBody, reqEditors ...RequestEditorFn) (*V2CreatePlayResponse, error)
+
+	// V2SendMagicLinkWithBodyWithResponse request with any body
+	V2SendMagicLinkWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2SendMagicLinkResponse, error)
+
+	V2SendMagicLinkWithResponse(ctx context.Context, body V2SendMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*V2SendMagicLinkResponse, error)
+
+	// V2CreateSessionWithBodyWithResponse request with any body
+	V2CreateSessionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2CreateSessionResponse, error)
+
+	V2CreateSessionWithResponse(ctx context.Context, body V2CreateSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*V2CreateSessionResponse, error)
+}
+
+type AuthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AuthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AuthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ClaimPointsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string  `json:"code"`
+		Data *string `json:"data,omitempty"`
+		Msg  string  `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ClaimPointsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ClaimPointsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SendMagicLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SendMagicLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SendMagicLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LoginMagicLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r LoginMagicLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LoginMagicLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type QueryAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r QueryAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r QueryAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateProfileResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateProfileResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateProfileResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AppealResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AppealResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AppealResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UploadAvatarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string  `json:"code"`
+		Data *Avatar `json:"data,omitempty"`
+		Msg  string  `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UploadAvatarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UploadAvatarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SendEmailVerificationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SendEmailVerificationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SendEmailVerificationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type VerifyEmailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r VerifyEmailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r VerifyEmailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string         `json:"code"`
+		Data *AccountExport `json:"data,omitempty"`
+		Msg  string         `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type QueryProfileChangesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string           `json:"code"`
+		Data *[]ProfileChange `json:"data"`
+		Msg  string           `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r QueryProfileChangesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r QueryProfileChangesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SavePointsAddrResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SavePointsAddrResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SavePointsAddrResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type QueryActivitysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string `json:"code"`
+		Data *struct {
+			Data *[]Activity `json:"data,omitempty"`
+		} `json:"data,omitempty"`
+		Msg string `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r QueryActivitysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r QueryActivitysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PlayResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PlayResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PlayResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type QueryIsLimitResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string `json:"code"`
+		Data *struct {
+			IsLimit bool `json:"is_limit"`
+		} `json:"data,omitempty"`
+		Msg string `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r QueryIsLimitResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r QueryIsLimitResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type QueryActivityLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string           `json:"code"`
+		Data *ActivityLogPage `json:"data,omitempty"`
+		Msg  string           `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r QueryActivityLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r QueryActivityLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportActivityLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportActivityLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportActivityLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2SignInResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2SignInResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2SignInResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2DeleteAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2DeleteAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2DeleteAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2GetAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2GetAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2GetAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2UpdateAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2UpdateAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2UpdateAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2ListActivityLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string           `json:"code"`
+		Data *ActivityLogPage `json:"data,omitempty"`
+		Msg  string           `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2ListActivityLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2ListActivityLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2ExportActivityLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2ExportActivityLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2ExportActivityLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2CreateAppealResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2CreateAppealResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2CreateAppealResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2UploadAvatarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string  `json:"code"`
+		Data *Avatar `json:"data,omitempty"`
+		Msg  string  `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2UploadAvatarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2UploadAvatarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2CreateClaimResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string `json:"code"`
+		Data *Claim `json:"data,omitempty"`
+		Msg  string `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2CreateClaimResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2CreateClaimResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2VerifyEmailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2VerifyEmailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2VerifyEmailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2ExportAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string         `json:"code"`
+		Data *AccountExport `json:"data,omitempty"`
+		Msg  string         `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2ExportAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2ExportAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2ListProfileChangesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string           `json:"code"`
+		Data *[]ProfileChange `json:"data"`
+		Msg  string           `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2ListProfileChangesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2ListProfileChangesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2SendVerificationCodeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2SendVerificationCodeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2SendVerificationCodeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2BindWalletResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2BindWalletResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2BindWalletResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2ListActivitiesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string      `json:"code"`
+		Data *[]Activity `json:"data,omitempty"`
+		Msg  string      `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2ListActivitiesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2ListActivitiesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2GetActivityLimitResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Code string `json:"code"`
+		Data *struct {
+			IsLimit bool `json:"is_limit"`
+		} `json:"data,omitempty"`
+		Msg string `json:"msg"`
+	}
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2GetActivityLimitResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2GetActivityLimitResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2CreatePlayResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2CreatePlayResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2CreatePlayResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2SendMagicLinkResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2SendMagicLinkResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2SendMagicLinkResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type V2CreateSessionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r V2CreateSessionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r V2CreateSessionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// AuthWithBodyWithResponse request with arbitrary body returning *AuthResponse
+func (c *ClientWithResponses) AuthWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AuthResponse, error) {
+	rsp, err := c.AuthWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuthResponse(rsp)
+}
+
+func (c *ClientWithResponses) AuthWithResponse(ctx context.Context, body AuthJSONRequestBody, reqEditors ...RequestEditorFn) (*AuthResponse, error) {
+	rsp, err := c.Auth(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuthResponse(rsp)
+}
+
+// ClaimPointsWithBodyWithResponse request with arbitrary body returning *ClaimPointsResponse
+func (c *ClientWithResponses) ClaimPointsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ClaimPointsResponse, error) {
+	rsp, err := c.ClaimPointsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClaimPointsResponse(rsp)
+}
+
+func (c *ClientWithResponses) ClaimPointsWithResponse(ctx context.Context, body ClaimPointsJSONRequestBody, reqEditors ...RequestEditorFn) (*ClaimPointsResponse, error) {
+	rsp, err := c.ClaimPoints(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClaimPointsResponse(rsp)
+}
+
+// SendMagicLinkWithBodyWithResponse request with arbitrary body returning *SendMagicLinkResponse
+func (c *ClientWithResponses) SendMagicLinkWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SendMagicLinkResponse, error) {
+	rsp, err := c.SendMagicLinkWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSendMagicLinkResponse(rsp)
+}
+
+func (c *ClientWithResponses) SendMagicLinkWithResponse(ctx context.Context, body SendMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*SendMagicLinkResponse, error) {
+	rsp, err := c.SendMagicLink(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSendMagicLinkResponse(rsp)
+}
+
+// LoginMagicLinkWithBodyWithResponse request with arbitrary body returning *LoginMagicLinkResponse
+func (c *ClientWithResponses) LoginMagicLinkWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginMagicLinkResponse, error) {
+	rsp, err := c.LoginMagicLinkWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginMagicLinkResponse(rsp)
+}
+
+func (c *ClientWithResponses) LoginMagicLinkWithResponse(ctx context.Context, body LoginMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginMagicLinkResponse, error) {
+	rsp, err := c.LoginMagicLink(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginMagicLinkResponse(rsp)
+}
+
+// DeleteAccountWithResponse request returning *DeleteAccountResponse
+func (c *ClientWithResponses) DeleteAccountWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*DeleteAccountResponse, error) {
+	rsp, err := c.DeleteAccount(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAccountResponse(rsp)
+}
+
+// QueryAccountWithResponse request returning *QueryAccountResponse
+func (c *ClientWithResponses) QueryAccountWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*QueryAccountResponse, error) {
+	rsp, err := c.QueryAccount(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryAccountResponse(rsp)
+}
+
+// UpdateProfileWithBodyWithResponse request with arbitrary body returning *UpdateProfileResponse
+func (c *ClientWithResponses) UpdateProfileWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateProfileResponse, error) {
+	rsp, err := c.UpdateProfileWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateProfileResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateProfileWithResponse(ctx context.Context, id AccountIDParam, body UpdateProfileJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateProfileResponse, error) {
+	rsp, err := c.UpdateProfile(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateProfileResponse(rsp)
+}
+
+// AppealWithBodyWithResponse request with arbitrary body returning *AppealResponse
+func (c *ClientWithResponses) AppealWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AppealResponse, error) {
+	rsp, err := c.AppealWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAppealResponse(rsp)
+}
+
+func (c *ClientWithResponses) AppealWithResponse(ctx context.Context, id AccountIDParam, body AppealJSONRequestBody, reqEditors ...RequestEditorFn) (*AppealResponse, error) {
+	rsp, err := c.Appeal(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAppealResponse(rsp)
+}
+
+// UploadAvatarWithBodyWithResponse request with arbitrary body returning *UploadAvatarResponse
+func (c *ClientWithResponses) UploadAvatarWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadAvatarResponse, error) {
+	rsp, err := c.UploadAvatarWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUploadAvatarResponse(rsp)
+}
+
+// SendEmailVerificationWithResponse request returning *SendEmailVerificationResponse
+func (c *ClientWithResponses) SendEmailVerificationWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*SendEmailVerificationResponse, error) {
+	rsp, err := c.SendEmailVerification(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSendEmailVerificationResponse(rsp)
+}
+
+// VerifyEmailWithBodyWithResponse request with arbitrary body returning *VerifyEmailResponse
+func (c *ClientWithResponses) VerifyEmailWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VerifyEmailResponse, error) {
+	rsp, err := c.VerifyEmailWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerifyEmailResponse(rsp)
+}
+
+func (c *ClientWithResponses) VerifyEmailWithResponse(ctx context.Context, id AccountIDParam, body VerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*VerifyEmailResponse, error) {
+	rsp, err := c.VerifyEmail(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVerifyEmailResponse(rsp)
+}
+
+// ExportAccountWithResponse request returning *ExportAccountResponse
+func (c *ClientWithResponses) ExportAccountWithResponse(ctx context.Context, id AccountIDParam, params *ExportAccountParams, reqEditors ...RequestEditorFn) (*ExportAccountResponse, error) {
+	rsp, err := c.ExportAccount(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportAccountResponse(rsp)
+}
+
+// QueryProfileChangesWithResponse request returning *QueryProfileChangesResponse
+func (c *ClientWithResponses) QueryProfileChangesWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*QueryProfileChangesResponse, error) {
+	rsp, err := c.QueryProfileChanges(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryProfileChangesResponse(rsp)
+}
+
+// SavePointsAddrWithBodyWithResponse request with arbitrary body returning *SavePointsAddrResponse
+func (c *ClientWithResponses) SavePointsAddrWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SavePointsAddrResponse, error) {
+	rsp, err := c.SavePointsAddrWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSavePointsAddrResponse(rsp)
+}
+
+func (c *ClientWithResponses) SavePointsAddrWithResponse(ctx context.Context, id AccountIDParam, body SavePointsAddrJSONRequestBody, reqEditors ...RequestEditorFn) (*SavePointsAddrResponse, error) {
+	rsp, err := c.SavePointsAddr(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSavePointsAddrResponse(rsp)
+}
+
+// QueryActivitysWithResponse request returning *QueryActivitysResponse
+func (c *ClientWithResponses) QueryActivitysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*QueryActivitysResponse, error) {
+	rsp, err := c.QueryActivitys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryActivitysResponse(rsp)
+}
+
+// PlayWithBodyWithResponse request with arbitrary body returning *PlayResponse
+func (c *ClientWithResponses) PlayWithBodyWithResponse(ctx context.Context, params *PlayParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PlayResponse, error) {
+	rsp, err := c.PlayWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlayResponse(rsp)
+}
+
+func (c *ClientWithResponses) PlayWithResponse(ctx context.Context, params *PlayParams, body PlayJSONRequestBody, reqEditors ...RequestEditorFn) (*PlayResponse, error) {
+	rsp, err := c.Play(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlayResponse(rsp)
+}
+
+// QueryIsLimitWithResponse request returning *QueryIsLimitResponse
+func (c *ClientWithResponses) QueryIsLimitWithResponse(ctx context.Context, params *QueryIsLimitParams, reqEditors ...RequestEditorFn) (*QueryIsLimitResponse, error) {
+	rsp, err := c.QueryIsLimit(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryIsLimitResponse(rsp)
+}
+
+// QueryActivityLogsWithResponse request returning *QueryActivityLogsResponse
+func (c *ClientWithResponses) QueryActivityLogsWithResponse(ctx context.Context, account AccountParam, params *QueryActivityLogsParams, reqEditors ...RequestEditorFn) (*QueryActivityLogsResponse, error) {
+	rsp, err := c.QueryActivityLogs(ctx, account, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQueryActivityLogsResponse(rsp)
+}
+
+// ExportActivityLogsWithResponse request returning *ExportActivityLogsResponse
+func (c *ClientWithResponses) ExportActivityLogsWithResponse(ctx context.Context, account AccountParam, params *ExportActivityLogsParams, reqEditors ...RequestEditorFn) (*ExportActivityLogsResponse, error) {
+	rsp, err := c.ExportActivityLogs(ctx, account, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportActivityLogsResponse(rsp)
+}
+
+// V2SignInWithBodyWithResponse request with arbitrary body returning *V2SignInResponse
+func (c *ClientWithResponses) V2SignInWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2SignInResponse, error) {
+	rsp, err := c.V2SignInWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2SignInResponse(rsp)
+}
+
+func (c *ClientWithResponses) V2SignInWithResponse(ctx context.Context, body V2SignInJSONRequestBody, reqEditors ...RequestEditorFn) (*V2SignInResponse, error) {
+	rsp, err := c.V2SignIn(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2SignInResponse(rsp)
+}
+
+// V2DeleteAccountWithResponse request returning *V2DeleteAccountResponse
+func (c *ClientWithResponses) V2DeleteAccountWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*V2DeleteAccountResponse, error) {
+	rsp, err := c.V2DeleteAccount(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2DeleteAccountResponse(rsp)
+}
+
+// V2GetAccountWithResponse request returning *V2GetAccountResponse
+func (c *ClientWithResponses) V2GetAccountWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*V2GetAccountResponse, error) {
+	rsp, err := c.V2GetAccount(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2GetAccountResponse(rsp)
+}
+
+// V2UpdateAccountWithBodyWithResponse request with arbitrary body returning *V2UpdateAccountResponse
+func (c *ClientWithResponses) V2UpdateAccountWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2UpdateAccountResponse, error) {
+	rsp, err := c.V2UpdateAccountWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2UpdateAccountResponse(rsp)
+}
+
+func (c *ClientWithResponses) V2UpdateAccountWithResponse(ctx context.Context, id AccountIDParam, body V2UpdateAccountJSONRequestBody, reqEditors ...RequestEditorFn) (*V2UpdateAccountResponse, error) {
+	rsp, err := c.V2UpdateAccount(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2UpdateAccountResponse(rsp)
+}
+
+// V2ListActivityLogsWithResponse request returning *V2ListActivityLogsResponse
+func (c *ClientWithResponses) V2ListActivityLogsWithResponse(ctx context.Context, id AccountIDParam, params *V2ListActivityLogsParams, reqEditors ...RequestEditorFn) (*V2ListActivityLogsResponse, error) {
+	rsp, err := c.V2ListActivityLogs(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2ListActivityLogsResponse(rsp)
+}
+
+// V2ExportActivityLogsWithResponse request returning *V2ExportActivityLogsResponse
+func (c *ClientWithResponses) V2ExportActivityLogsWithResponse(ctx context.Context, id AccountIDParam, params *V2ExportActivityLogsParams, reqEditors ...RequestEditorFn) (*V2ExportActivityLogsResponse, error) {
+	rsp, err := c.V2ExportActivityLogs(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2ExportActivityLogsResponse(rsp)
+}
+
+// V2CreateAppealWithBodyWithResponse request with arbitrary body returning *V2CreateAppealResponse
+func (c *ClientWithResponses) V2CreateAppealWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2CreateAppealResponse, error) {
+	rsp, err := c.V2CreateAppealWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2CreateAppealResponse(rsp)
+}
+
+func (c *ClientWithResponses) V2CreateAppealWithResponse(ctx context.Context, id AccountIDParam, body V2CreateAppealJSONRequestBody, reqEditors ...RequestEditorFn) (*V2CreateAppealResponse, error) {
+	rsp, err := c.V2CreateAppeal(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2CreateAppealResponse(rsp)
+}
+
+// V2UploadAvatarWithBodyWithResponse request with arbitrary body returning *V2UploadAvatarResponse
+func (c *ClientWithResponses) V2UploadAvatarWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2UploadAvatarResponse, error) {
+	rsp, err := c.V2UploadAvatarWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2UploadAvatarResponse(rsp)
+}
+
+// V2CreateClaimWithBodyWithResponse request with arbitrary body returning *V2CreateClaimResponse
+func (c *ClientWithResponses) V2CreateClaimWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2CreateClaimResponse, error) {
+	rsp, err := c.V2CreateClaimWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2CreateClaimResponse(rsp)
+}
+
+func (c *ClientWithResponses) V2CreateClaimWithResponse(ctx context.Context, id AccountIDParam, body V2CreateClaimJSONRequestBody, reqEditors ...RequestEditorFn) (*V2CreateClaimResponse, error) {
+	rsp, err := c.V2CreateClaim(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2CreateClaimResponse(rsp)
+}
+
+// V2VerifyEmailWithBodyWithResponse request with arbitrary body returning *V2VerifyEmailResponse
+func (c *ClientWithResponses) V2VerifyEmailWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2VerifyEmailResponse, error) {
+	rsp, err := c.V2VerifyEmailWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2VerifyEmailResponse(rsp)
+}
+
+func (c *ClientWithResponses) V2VerifyEmailWithResponse(ctx context.Context, id AccountIDParam, body V2VerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*V2VerifyEmailResponse, error) {
+	rsp, err := c.V2VerifyEmail(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2VerifyEmailResponse(rsp)
+}
+
+// V2ExportAccountWithResponse request returning *V2ExportAccountResponse
+func (c *ClientWithResponses) V2ExportAccountWithResponse(ctx context.Context, id AccountIDParam, params *V2ExportAccountParams, reqEditors ...RequestEditorFn) (*V2ExportAccountResponse, error) {
+	rsp, err := c.V2ExportAccount(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2ExportAccountResponse(rsp)
+}
+
+// V2ListProfileChangesWithResponse request returning *V2ListProfileChangesResponse
+func (c *ClientWithResponses) V2ListProfileChangesWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*V2ListProfileChangesResponse, error) {
+	rsp, err := c.V2ListProfileChanges(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2ListProfileChangesResponse(rsp)
+}
+
+// V2SendVerificationCodeWithResponse request returning *V2SendVerificationCodeResponse
+func (c *ClientWithResponses) V2SendVerificationCodeWithResponse(ctx context.Context, id AccountIDParam, reqEditors ...RequestEditorFn) (*V2SendVerificationCodeResponse, error) {
+	rsp, err := c.V2SendVerificationCode(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2SendVerificationCodeResponse(rsp)
+}
+
+// V2BindWalletWithBodyWithResponse request with arbitrary body returning *V2BindWalletResponse
+func (c *ClientWithResponses) V2BindWalletWithBodyWithResponse(ctx context.Context, id AccountIDParam, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2BindWalletResponse, error) {
+	rsp, err := c.V2BindWalletWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2BindWalletResponse(rsp)
+}
+
+func (c *ClientWithResponses) V2BindWalletWithResponse(ctx context.Context, id AccountIDParam, body V2BindWalletJSONRequestBody, reqEditors ...RequestEditorFn) (*V2BindWalletResponse, error) {
+	rsp, err := c.V2BindWallet(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2BindWalletResponse(rsp)
+}
+
+// V2ListActivitiesWithResponse request returning *V2ListActivitiesResponse
+func (c *ClientWithResponses) V2ListActivitiesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*V2ListActivitiesResponse, error) {
+	rsp, err := c.V2ListActivities(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2ListActivitiesResponse(rsp)
+}
+
+// V2GetActivityLimitWithResponse request returning *V2GetActivityLimitResponse
+func (c *ClientWithResponses) V2GetActivityLimitWithResponse(ctx context.Context, code ActivityCodePathParam, params *V2GetActivityLimitParams, reqEditors ...RequestEditorFn) (*V2GetActivityLimitResponse, error) {
+	rsp, err := c.V2GetActivityLimit(ctx, code, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2GetActivityLimitResponse(rsp)
+}
+
+// V2CreatePlayWithBodyWithResponse request with arbitrary body returning *V2CreatePlayResponse
+func (c *ClientWithResponses) V2CreatePlayWithBodyWithResponse(ctx context.Context, code ActivityCodePathParam, params *V2CreatePlayParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2CreatePlayResponse, error) {
+	rsp, err := c.V2CreatePlayWithBody(ctx, code, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2CreatePlayResponse(rsp)
+}
+
+func (c *ClientWithResponses) V2CreatePlayWithResponse(ctx context.Context, code ActivityCodePathParam, params *V2CreatePlayParams, body V2CreatePlayJSONRequestBody, reqEditors ...RequestEditorFn) (*V2CreatePlayResponse, error) {
+	rsp, err := c.V2CreatePlay(ctx, code, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2CreatePlayResponse(rsp)
+}
+
+// V2SendMagicLinkWithBodyWithResponse request with arbitrary body returning *V2SendMagicLinkResponse
+func (c *ClientWithResponses) V2SendMagicLinkWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2SendMagicLinkResponse, error) {
+	rsp, err := c.V2SendMagicLinkWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2SendMagicLinkResponse(rsp)
+}
+
+func (c *ClientWithResponses) V2SendMagicLinkWithResponse(ctx context.Context, body V2SendMagicLinkJSONRequestBody, reqEditors ...RequestEditorFn) (*V2SendMagicLinkResponse, error) {
+	rsp, err := c.V2SendMagicLink(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2SendMagicLinkResponse(rsp)
+}
+
+// V2CreateSessionWithBodyWithResponse request with arbitrary body returning *V2CreateSessionResponse
+func (c *ClientWithResponses) V2CreateSessionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*V2CreateSessionResponse, error) {
+	rsp, err := c.V2CreateSessionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2CreateSessionResponse(rsp)
+}
+
+func (c *ClientWithResponses) V2CreateSessionWithResponse(ctx context.Context, body V2CreateSessionJSONRequestBody, reqEditors ...RequestEditorFn) (*V2CreateSessionResponse, error) {
+	rsp, err := c.V2CreateSession(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseV2CreateSessionResponse(rsp)
+}
+
+// ParseAuthResponse parses an HTTP response from a AuthWithResponse call
+func ParseAuthResponse(rsp *http.Response) (*AuthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AuthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseClaimPointsResponse parses an HTTP response from a ClaimPointsWithResponse call
+func ParseClaimPointsResponse(rsp *http.Response) (*ClaimPointsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ClaimPointsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string  `json:"code"`
+			Data *string `json:"data,omitempty"`
+			Msg  string  `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSendMagicLinkResponse parses an HTTP response from a SendMagicLinkWithResponse call
+func ParseSendMagicLinkResponse(rsp *http.Response) (*SendMagicLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SendMagicLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLoginMagicLinkResponse parses an HTTP response from a LoginMagicLinkWithResponse call
+func ParseLoginMagicLinkResponse(rsp *http.Response) (*LoginMagicLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LoginMagicLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAccountResponse parses an HTTP response from a DeleteAccountWithResponse call
+func ParseDeleteAccountResponse(rsp *http.Response) (*DeleteAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseQueryAccountResponse parses an HTTP response from a QueryAccountWithResponse call
+func ParseQueryAccountResponse(rsp *http.Response) (*QueryAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QueryAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateProfileResponse parses an HTTP response from a UpdateProfileWithResponse call
+func ParseUpdateProfileResponse(rsp *http.Response) (*UpdateProfileResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateProfileResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAppealResponse parses an HTTP response from a AppealWithResponse call
+func ParseAppealResponse(rsp *http.Response) (*AppealResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AppealResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUploadAvatarResponse parses an HTTP response from a UploadAvatarWithResponse call
+func ParseUploadAvatarResponse(rsp *http.Response) (*UploadAvatarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UploadAvatarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string  `json:"code"`
+			Data *Avatar `json:"data,omitempty"`
+			Msg  string  `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSendEmailVerificationResponse parses an HTTP response from a SendEmailVerificationWithResponse call
+func ParseSendEmailVerificationResponse(rsp *http.Response) (*SendEmailVerificationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SendEmailVerificationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseVerifyEmailResponse parses an HTTP response from a VerifyEmailWithResponse call
+func ParseVerifyEmailResponse(rsp *http.Response) (*VerifyEmailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &VerifyEmailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportAccountResponse parses an HTTP response from a ExportAccountWithResponse call
+func ParseExportAccountResponse(rsp *http.Response) (*ExportAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string         `json:"code"`
+			Data *AccountExport `json:"data,omitempty"`
+			Msg  string         `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (application/zip) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseQueryProfileChangesResponse parses an HTTP response from a QueryProfileChangesWithResponse call
+func ParseQueryProfileChangesResponse(rsp *http.Response) (*QueryProfileChangesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QueryProfileChangesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string           `json:"code"`
+			Data *[]ProfileChange `json:"data"`
+			Msg  string           `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSavePointsAddrResponse parses an HTTP response from a SavePointsAddrWithResponse call
+func ParseSavePointsAddrResponse(rsp *http.Response) (*SavePointsAddrResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SavePointsAddrResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseQueryActivitysResponse parses an HTTP response from a QueryActivitysWithResponse call
+func ParseQueryActivitysResponse(rsp *http.Response) (*QueryActivitysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QueryActivitysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string `json:"code"`
+			Data *struct {
+				Data *[]Activity `json:"data,omitempty"`
+			} `json:"data,omitempty"`
+			Msg string `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePlayResponse parses an HTTP response from a PlayWithResponse call
+func ParsePlayResponse(rsp *http.Response) (*PlayResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PlayResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseQueryIsLimitResponse parses an HTTP response from a QueryIsLimitWithResponse call
+func ParseQueryIsLimitResponse(rsp *http.Response) (*QueryIsLimitResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QueryIsLimitResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string `json:"code"`
+			Data *struct {
+				IsLimit bool `json:"is_limit"`
+			} `json:"data,omitempty"`
+			Msg string `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseQueryActivityLogsResponse parses an HTTP response from a QueryActivityLogsWithResponse call
+func ParseQueryActivityLogsResponse(rsp *http.Response) (*QueryActivityLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QueryActivityLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string           `json:"code"`
+			Data *ActivityLogPage `json:"data,omitempty"`
+			Msg  string           `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportActivityLogsResponse parses an HTTP response from a ExportActivityLogsWithResponse call
+func ParseExportActivityLogsResponse(rsp *http.Response) (*ExportActivityLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportActivityLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2SignInResponse parses an HTTP response from a V2SignInWithResponse call
+func ParseV2SignInResponse(rsp *http.Response) (*V2SignInResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2SignInResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2DeleteAccountResponse parses an HTTP response from a V2DeleteAccountWithResponse call
+func ParseV2DeleteAccountResponse(rsp *http.Response) (*V2DeleteAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2DeleteAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2GetAccountResponse parses an HTTP response from a V2GetAccountWithResponse call
+func ParseV2GetAccountResponse(rsp *http.Response) (*V2GetAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2GetAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2UpdateAccountResponse parses an HTTP response from a V2UpdateAccountWithResponse call
+func ParseV2UpdateAccountResponse(rsp *http.Response) (*V2UpdateAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2UpdateAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2ListActivityLogsResponse parses an HTTP response from a V2ListActivityLogsWithResponse call
+func ParseV2ListActivityLogsResponse(rsp *http.Response) (*V2ListActivityLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2ListActivityLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string           `json:"code"`
+			Data *ActivityLogPage `json:"data,omitempty"`
+			Msg  string           `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2ExportActivityLogsResponse parses an HTTP response from a V2ExportActivityLogsWithResponse call
+func ParseV2ExportActivityLogsResponse(rsp *http.Response) (*V2ExportActivityLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2ExportActivityLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2CreateAppealResponse parses an HTTP response from a V2CreateAppealWithResponse call
+func ParseV2CreateAppealResponse(rsp *http.Response) (*V2CreateAppealResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2CreateAppealResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2UploadAvatarResponse parses an HTTP response from a V2UploadAvatarWithResponse call
+func ParseV2UploadAvatarResponse(rsp *http.Response) (*V2UploadAvatarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2UploadAvatarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string  `json:"code"`
+			Data *Avatar `json:"data,omitempty"`
+			Msg  string  `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2CreateClaimResponse parses an HTTP response from a V2CreateClaimWithResponse call
+func ParseV2CreateClaimResponse(rsp *http.Response) (*V2CreateClaimResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2CreateClaimResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string `json:"code"`
+			Data *Claim `json:"data,omitempty"`
+			Msg  string `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2VerifyEmailResponse parses an HTTP response from a V2VerifyEmailWithResponse call
+func ParseV2VerifyEmailResponse(rsp *http.Response) (*V2VerifyEmailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2VerifyEmailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2ExportAccountResponse parses an HTTP response from a V2ExportAccountWithResponse call
+func ParseV2ExportAccountResponse(rsp *http.Response) (*V2ExportAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2ExportAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string         `json:"code"`
+			Data *AccountExport `json:"data,omitempty"`
+			Msg  string         `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (application/zip) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseV2ListProfileChangesResponse parses an HTTP response from a V2ListProfileChangesWithResponse call
+func ParseV2ListProfileChangesResponse(rsp *http.Response) (*V2ListProfileChangesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2ListProfileChangesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string           `json:"code"`
+			Data *[]ProfileChange `json:"data"`
+			Msg  string           `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2SendVerificationCodeResponse parses an HTTP response from a V2SendVerificationCodeWithResponse call
+func ParseV2SendVerificationCodeResponse(rsp *http.Response) (*V2SendVerificationCodeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2SendVerificationCodeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2BindWalletResponse parses an HTTP response from a V2BindWalletWithResponse call
+func ParseV2BindWalletResponse(rsp *http.Response) (*V2BindWalletResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2BindWalletResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2ListActivitiesResponse parses an HTTP response from a V2ListActivitiesWithResponse call
+func ParseV2ListActivitiesResponse(rsp *http.Response) (*V2ListActivitiesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2ListActivitiesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string      `json:"code"`
+			Data *[]Activity `json:"data,omitempty"`
+			Msg  string      `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2GetActivityLimitResponse parses an HTTP response from a V2GetActivityLimitWithResponse call
+func ParseV2GetActivityLimitResponse(rsp *http.Response) (*V2GetActivityLimitResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2GetActivityLimitResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Code string `json:"code"`
+			Data *struct {
+				IsLimit bool `json:"is_limit"`
+			} `json:"data,omitempty"`
+			Msg string `json:"msg"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2CreatePlayResponse parses an HTTP response from a V2CreatePlayWithResponse call
+func ParseV2CreatePlayResponse(rsp *http.Response) (*V2CreatePlayResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2CreatePlayResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2SendMagicLinkResponse parses an HTTP response from a V2SendMagicLinkWithResponse call
+func ParseV2SendMagicLinkResponse(rsp *http.Response) (*V2SendMagicLinkResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2SendMagicLinkResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseV2CreateSessionResponse parses an HTTP response from a V2CreateSessionWithResponse call
+func ParseV2CreateSessionResponse(rsp *http.Response) (*V2CreateSessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &V2CreateSessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,63 @@
+// Package openapi holds the OpenAPI document of the public HTTP API. The
+// client package is generated from it; the contract tests in api/http/v1
+// keep it in step with the routes.
+package openapi
+
+//go:generate oapi-codegen -config client/config.yaml openapi.yaml
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// swaggerUI renders /openapi.json with the swagger-ui bundle from a CDN.
+const swaggerUI = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>starland-account API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>`
+
+// Load parses and validates the embedded document.
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, err
+	}
+	if err = doc.Validate(context.Background()); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Register serves the document at /openapi.json and Swagger UI at /docs.
+func Register(app fiber.Router, doc *openapi3.T) error {
+	b, err := doc.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	app.Get("/openapi.json", func(ctx *fiber.Ctx) error {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return ctx.Send(b)
+	})
+	app.Get("/docs", func(ctx *fiber.Ctx) error {
+		ctx.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return ctx.SendString(swaggerUI)
+	})
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"starland-account/configs"
+	"starland-account/internal/pkg/tenant"
+	"starland-account/internal/service/airdrop"
+)
+
+// runAirdrop awards points from a CSV of account_id,points,reason and writes
+// the per-row report. Passing -job resumes an existing job instead.
+//
+//	starland-account airdrop -file awards.csv -operator alice -report report.csv
+//
+// -tenant picks the tenant whose accounts are awarded, default otherwise. A
+// job the server is running is refused until it finishes there.
+func runAirdrop(cfg *configs.Config, args []string) error {
+	var (
+		file     string
+		jobID    string
+		operator string
+		report   string
+		tenantID string
+	)
+	fs := flag.NewFlagSet("airdrop", flag.ExitOnError)
+	fs.StringVar(&file, "file", "", "csv file of account_id,points,reason")
+	fs.StringVar(&jobID, "job", "", "resume an existing job")
+	fs.StringVar(&operator, "operator", os.Getenv("USER"), "operator recorded on the job")
+	fs.StringVar(&report, "report", "", "write the result report to this file")
+	fs.StringVar(&tenantID, "tenant", tenant.Default, "tenant the accounts belong to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if file == "" && jobID == "" {
+		fs.Usage()
+		return fmt.Errorf("either -file or -job is required")
+	}
+	if tenant.Find(cfg, tenantID) == nil {
+		return fmt.Errorf("unknown tenant %s", tenantID)
+	}
+
+	s, cleanup, err := initApp(cfg)
+	if err != nil {
+		return fmt.Errorf("dependency injection is err: %w", err)
+	}
+	defer cleanup()
+	ctx := tenant.NewContext(context.Background(), tenantID)
+
+	if jobID == "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return fmt.Errorf("open %s err: %w", file, err)
+		}
+		defer f.Close()
+
+		job, err := s.Airdrop.CreateJob(ctx, &airdrop.CreateAirdropJobRequest{
+			Operator: operator,
+			FileName: filepath.Base(file),
+		}, f)
+		if err != nil {
+			return err
+		}
+		jobID = job.JobID
+		fmt.Printf("job %s created: %d rows, %d rejected\n", job.JobID, job.Total, job.Failed)
+	}
+
+	err = s.Airdrop.RunJob(ctx, jobID, func(job *airdrop.AirdropJobResponse) {
+		fmt.Printf("job %s: %d/%d done, %d succeeded, %d failed\n",
+			job.JobID, job.Total-job.Pending, job.Total, job.Succeeded, job.Failed)
+	})
+	if err != nil {
+		return err
+	}
+
+	if report != "" {
+		f, err := os.Create(report)
+		if err != nil {
+			return fmt.Errorf("create %s err: %w", report, err)
+		}
+		defer f.Close()
+		if err = s.Airdrop.WriteReport(ctx, jobID, f); err != nil {
+			return err
+		}
+		fmt.Printf("report written to %s\n", report)
+	}
+	return nil
+}
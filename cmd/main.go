@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"log"
@@ -9,20 +10,38 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	grpcapi "starland-account/api/grpc"
 	api "starland-account/api/http"
 	config "starland-account/configs"
 	"starland-account/internal/pkg/logs"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
+const defaultShutdownTimeout = 30 * time.Second
+
 func main() {
 	// init config,log
 	config.InitConfig()
 	cfg := config.GetConfig()
 	logs.InitLogging(cfg)
 
+	if len(os.Args) > 1 && os.Args[1] == "airdrop" {
+		if err := runAirdrop(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("airdrop: %s\n", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("migrate: %s\n", err)
+		}
+		return
+	}
+
 	var host string
 	flag.StringVar(&host, "h", cfg.HTTP.Addr, "host")
 	flag.Parse()
@@ -31,11 +50,15 @@ func main() {
 		zap.S().Fatalf("net listen is err: %s", err.Error())
 	}
 
-	s, err := initApp(cfg)
+	s, cleanup, err := initApp(cfg)
 	if err != nil {
 		zap.S().Fatalf("dependency injection is err: %s", err.Error())
 	}
+	s.Workers.Start(context.Background())
 	app, err := api.NewHTTPServer(cfg, s)
+	if err != nil {
+		zap.S().Fatalf("http server is err: %s", err.Error())
+	}
 
 	go func() {
 		if err := app.Listener(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -43,15 +66,61 @@ func main() {
 		}
 	}()
 
+	var grpcServer *grpc.Server
+	if cfg.GRPC != nil && cfg.GRPC.Addr != "" {
+		grpcLn, err := net.Listen("tcp", cfg.GRPC.Addr)
+		if err != nil {
+			zap.S().Fatalf("grpc net listen is err: %s", err.Error())
+		}
+		if grpcServer, err = grpcapi.NewGRPCServer(cfg, s); err != nil {
+			zap.S().Fatalf("grpc server is err: %s", err.Error())
+		}
+		go func() {
+			if err := grpcServer.Serve(grpcLn); err != nil {
+				log.Fatalf("grpc serve: %s\n", err)
+			}
+		}()
+	}
+
 	//nolint:gomnd
 	quit := make(chan os.Signal, 10)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Print("shutting down service...")
 
-	if err = app.Shutdown(); err != nil {
-		log.Printf("shutting down service : %s", err.Error())
+	// stop taking requests first, then the workers, which may still use the
+	// database and Redis, then close those
+	timeout := defaultShutdownTimeout
+	if cfg.HTTP.ShutdownTimeout > 0 {
+		timeout = cfg.HTTP.ShutdownTimeout * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err = app.ShutdownWithContext(ctx); err != nil {
+		log.Printf("shutting down http server : %s", err.Error())
+	}
+	if grpcServer != nil {
+		stopGRPC(ctx, grpcServer)
+	}
+	if err = s.Workers.Stop(ctx); err != nil {
+		log.Printf("shutting down workers : %s", err.Error())
 	}
+	cleanup()
 
 	log.Print("bye")
 }
+
+// stopGRPC waits for the pending RPCs until ctx is done, then cancels them.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Print("shutting down grpc server : deadline exceeded")
+		server.Stop()
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"starland-account/configs"
+	"starland-account/internal/data"
+)
+
+// runMigrate applies, reverts or lists the schema migrations. Set
+// data.db.skip_migrate to run them with this command alone.
+//
+//	starland-account migrate up [-to version]
+//	starland-account migrate down [-to version]
+//	starland-account migrate status
+//
+// up applies every pending migration by default, down reverts the latest.
+func runMigrate(cfg *configs.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up|down|status [-to version]")
+	}
+	to := -1
+	fs := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+	fs.IntVar(&to, "to", -1, "version to migrate up or down to")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	db, err := data.OpenDB(cfg)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), data.MigrationLockWait)
+	defer cancel()
+	statuses, err := data.MigrationStatuses(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	var done []data.Migration
+	switch args[0] {
+	case "up":
+		if to < 0 {
+			to = 0
+		}
+		done, err = data.MigrateUp(ctx, db, to)
+	case "down":
+		if to < 0 {
+			to = previousVersion(statuses)
+		}
+		done, err = data.MigrateDown(ctx, db, to)
+	case "status":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, applied)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %s", args[0])
+	}
+	for _, m := range done {
+		fmt.Printf("%s %d %s\n", args[0], m.Version, m.Name)
+	}
+	if err == nil && len(done) == 0 {
+		fmt.Println("nothing to migrate")
+	}
+	return err
+}
+
+// previousVersion is the version below the latest applied migration.
+func previousVersion(statuses []*data.MigrationStatus) int {
+	prev, latest := 0, 0
+	for _, s := range statuses {
+		if s.AppliedAt != nil && s.Version > latest {
+			prev, latest = latest, s.Version
+		}
+	}
+	return prev
+}
@@ -9,18 +9,34 @@ import (
 	"starland-account/configs"
 	"starland-account/internal/biz"
 	"starland-account/internal/data"
+	"starland-account/internal/pkg/mailer"
+	"starland-account/internal/pkg/storage"
 	"starland-account/internal/service"
 	account_service "starland-account/internal/service/account"
 	activity_service "starland-account/internal/service/activity"
+	airdrop_service "starland-account/internal/service/airdrop"
+	analytics_service "starland-account/internal/service/analytics"
+	award_service "starland-account/internal/service/award"
+	event_service "starland-account/internal/service/event"
+	leader_service "starland-account/internal/service/leader"
+	webhook_service "starland-account/internal/service/webhook"
 
 	"github.com/google/wire"
 )
 
 // initApp
-func initApp(cfg *configs.Config) (*service.Service, error) {
+func initApp(cfg *configs.Config) (*service.Service, func(), error) {
 	panic(wire.Build(data.ProviderSet,
+		storage.NewStorage,
+		mailer.NewMailer,
 		biz.ProviderSet,
 		account_service.ProviderSet,
 		activity_service.ProviderSet,
+		airdrop_service.ProviderSet,
+		analytics_service.ProviderSet,
+		event_service.ProviderSet,
+		webhook_service.ProviderSet,
+		award_service.ProviderSet,
+		leader_service.ProviderSet,
 		service.ProviderSet))
 }
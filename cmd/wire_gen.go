@@ -10,23 +10,71 @@ import (
 	"starland-account/configs"
 	"starland-account/internal/biz"
 	"starland-account/internal/data"
+	"starland-account/internal/pkg/mailer"
+	"starland-account/internal/pkg/storage"
 	"starland-account/internal/service"
 	"starland-account/internal/service/account"
 	"starland-account/internal/service/activity"
+	"starland-account/internal/service/airdrop"
+	"starland-account/internal/service/analytics"
+	"starland-account/internal/service/award"
+	"starland-account/internal/service/event"
+	"starland-account/internal/service/leader"
+	"starland-account/internal/service/webhook"
 )
 
 // Injectors from wire.go:
 
 // initApp
-func initApp(cfg *configs.Config) (*service.Service, error) {
-	dataData := data.NewData(cfg)
+func initApp(cfg *configs.Config) (*service.Service, func(), error) {
+	dataData, cleanup, err := data.NewData(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
 	accountRepo := data.NewAccountRepo(cfg, dataData)
-	accountUsecase := biz.NewAccountUsecase(accountRepo)
-	accountService := account.NewAccountService(cfg, accountUsecase)
+	accountStateRepo := data.NewAccountStateRepo(cfg, dataData)
+	profileRepo := data.NewProfileRepo(cfg, dataData)
+	verifyRepo := data.NewVerifyRepo(cfg, dataData)
+	accountUsecase := biz.NewAccountUsecase(accountRepo, accountStateRepo, profileRepo, verifyRepo)
 	activityRepo := data.NewActivityRepo(cfg, dataData)
 	activityLogRepo := data.NewActivityLogRepo(cfg, dataData)
 	activityUsecase := biz.NewActivityUsecase(activityRepo, activityLogRepo)
-	activityService := activity.NewActivityService(cfg, activityUsecase, accountUsecase)
-	serviceService := service.NewService(accountService, activityService)
-	return serviceService, nil
+	storageStorage, err := storage.NewStorage(cfg)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	mailerMailer, err := mailer.NewMailer(cfg)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	accountService := account.NewAccountService(cfg, accountUsecase, activityUsecase, storageStorage, mailerMailer)
+	riskRepo := data.NewRiskRepo(cfg, dataData)
+	riskUsecase := biz.NewRiskUsecase(cfg, riskRepo)
+	activityService := activity.NewActivityService(cfg, activityUsecase, accountUsecase, riskUsecase)
+	airdropRepo := data.NewAirdropRepo(cfg, dataData)
+	airdropUsecase := biz.NewAirdropUsecase(airdropRepo, accountRepo)
+	leaseRepo := data.NewLeaseRepo(cfg, dataData)
+	leaderUsecase := biz.NewLeaderUsecase(leaseRepo)
+	airdropService := airdrop.NewAirdropService(cfg, airdropUsecase, leaderUsecase)
+	analyticsRepo := data.NewAnalyticsRepo(cfg, dataData)
+	analyticsUsecase := biz.NewAnalyticsUsecase(analyticsRepo)
+	analyticsService := analytics.NewAnalyticsService(cfg, analyticsUsecase)
+	outboxRepo := data.NewOutboxRepo(cfg, dataData)
+	outboxUsecase := biz.NewOutboxUsecase(outboxRepo)
+	eventService := event.NewEventService(cfg, outboxUsecase)
+	webhookRepo := data.NewWebhookRepo(cfg, dataData)
+	webhookUsecase := biz.NewWebhookUsecase(webhookRepo)
+	webhookService := webhook.NewWebhookService(cfg, webhookUsecase)
+	awardRepo := data.NewAwardRepo(cfg, dataData)
+	awardUsecase := biz.NewAwardUsecase(awardRepo)
+	awardService := award.NewAwardService(cfg, accountUsecase, awardUsecase)
+	leaderService := leader.NewLeaderService(cfg, leaderUsecase)
+	rateLimitRepo := data.NewRateLimitRepo(cfg, dataData)
+	rateLimitUsecase := biz.NewRateLimitUsecase(rateLimitRepo)
+	serviceService := service.NewService(cfg, accountService, activityService, airdropService, analyticsService, eventService, webhookService, awardService, leaderService, rateLimitUsecase)
+	return serviceService, func() {
+		cleanup()
+	}, nil
 }
@@ -3,6 +3,8 @@ package configs
 import (
 	"fmt"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
@@ -10,8 +12,16 @@ import (
 	"github.com/spf13/viper"
 )
 
-var cfg *Config
+var (
+	cfg atomic.Pointer[Config]
 
+	listenersMu sync.Mutex
+	listeners   []func(*Config)
+)
+
+// InitConfig loads the config and watches the file. A change is loaded into a
+// new Config that replaces the current one, so a *Config already handed out
+// stays as it was; what applies changes live subscribes with OnChange.
 func InitConfig() {
 	confPath := "./conf"
 	value := os.Getenv("CONF_PATH")
@@ -27,10 +37,12 @@ func InitConfig() {
 	viper.WatchConfig()
 	viper.OnConfigChange(func(e fsnotify.Event) {
 		fmt.Printf("InitLog: config has change: %v\n", e.Name)
-		if err := viper.Unmarshal(&cfg); err != nil {
+		next := new(Config)
+		if err := viper.Unmarshal(next); err != nil {
 			fmt.Printf("InitLog: unmarshal config failed: %v\n", err)
 			return
 		}
+		Replace(next)
 	})
 
 	if err := cf.ReadInConfig(); err != nil {
@@ -40,35 +52,77 @@ func InitConfig() {
 		fmt.Println("InitLog: using cf file:", cf.ConfigFileUsed())
 	}
 
-	if err := cf.Unmarshal(&cfg); err != nil {
+	loaded := new(Config)
+	if err := cf.Unmarshal(loaded); err != nil {
 		fmt.Printf("InitLog:  unmarshaling cf file: %v\n", err)
 		os.Exit(1)
-	} else if GetConfig().Debug {
+	}
+	cfg.Store(loaded)
+	if loaded.Debug {
 		// un
-		_, err := pp.Println(cfg)
+		_, err := pp.Println(loaded)
 		if err != nil {
 			fmt.Printf("InitLog: pp err %s\n", err.Error())
 		}
 	}
 }
 
+// GetConfig returns the current config, which is never changed in place.
 func GetConfig() *Config {
-	if cfg == nil {
-		InitConfig()
+	if c := cfg.Load(); c != nil {
+		return c
 	}
-	return cfg
+	InitConfig()
+	return cfg.Load()
+}
+
+// Replace makes next the current config and hands it to the OnChange
+// listeners, as a change of the file does.
+func Replace(next *Config) {
+	cfg.Store(next)
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+	for _, fn := range listeners {
+		fn(next)
+	}
+}
+
+// OnChange calls fn with every config loaded after a change of the file.
+func OnChange(fn func(*Config)) {
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+	listeners = append(listeners, fn)
 }
 
 type Config struct {
-	Debug          bool         `mapstructure:"debug"`
-	PrivatePath    string       `mapstructure:"private_path"`
-	Env            string       `mapstructure:"env"`
-	HTTP           *HTTPConfig  `mapstructure:"http"`
-	Token          string       `mapstructure:"token"`
-	HTTPS          *HTTPSConfig `mapstructure:"https"`
-	Log            *LogConfig   `mapstructure:"log"`
-	Data           *DataConfig  `mapstructure:"data"`
-	FeiShuAlertURL string       `mapstructure:"feiShuAlertUrl"`
+	Debug          bool             `mapstructure:"debug"`
+	PrivatePath    string           `mapstructure:"private_path"`
+	Env            string           `mapstructure:"env"`
+	HTTP           *HTTPConfig      `mapstructure:"http"`
+	GRPC           *GRPCConfig      `mapstructure:"grpc"`
+	Token          string           `mapstructure:"token"`
+	AdminToken     string           `mapstructure:"admin_token"`
+	Admins         []AdminConfig    `mapstructure:"admins"`
+	HTTPS          *HTTPSConfig     `mapstructure:"https"`
+	Log            *LogConfig       `mapstructure:"log"`
+	Data           *DataConfig      `mapstructure:"data"`
+	FeiShuAlertURL string           `mapstructure:"feiShuAlertUrl"`
+	Risk           *RiskConfig      `mapstructure:"risk"`
+	RateLimit      *RateLimitConfig `mapstructure:"rate_limit"`
+	Account        *AccountConfig   `mapstructure:"account"`
+	Storage        *StorageConfig   `mapstructure:"storage"`
+	Email          *EmailConfig     `mapstructure:"email"`
+	Analytics      *AnalyticsConfig `mapstructure:"analytics"`
+	Event          *EventConfig     `mapstructure:"event"`
+	Webhook        *WebhookConfig   `mapstructure:"webhook"`
+	Award          *AwardConfig     `mapstructure:"award"`
+	Leader         *LeaderConfig    `mapstructure:"leader"`
+	Tenants        []TenantConfig   `mapstructure:"tenants"`
+}
+
+// GRPCConfig enables the gRPC API on Addr; it is off when Addr is empty.
+type GRPCConfig struct {
+	Addr string `mapstructure:"addr"`
 }
 
 type HTTPConfig struct {
@@ -76,6 +130,11 @@ type HTTPConfig struct {
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
 	LogFormat    string        `mapstructure:"log_format"`
+	// V1Sunset is the date (2006-01-02) v1 routes are retired, announced in
+	// their Sunset header.
+	V1Sunset string `mapstructure:"v1_sunset"`
+	// ShutdownTimeout is how many seconds shutting down may take, 30 when unset.
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 }
 
 type HTTPSConfig struct {
@@ -96,8 +155,13 @@ type DataConfig struct {
 	Redis RedisConfig `mapstructure:"redis"`
 }
 
+// DBConfig picks the database: Driver is mysql (the default), postgres or
+// sqlite, and Source the driver's DSN. SkipMigrate leaves the migrations to
+// the migrate command; startup then fails while one is pending.
 type DBConfig struct {
-	Source string `mapstructure:"source"`
+	Driver      string `mapstructure:"driver"`
+	Source      string `mapstructure:"source"`
+	SkipMigrate bool   `mapstructure:"skip_migrate"`
 }
 
 type RedisConfig struct {
@@ -106,6 +170,223 @@ type RedisConfig struct {
 	Expiration time.Duration `mapstructure:"expiration"`
 }
 
+// RiskConfig is swapped whole into the risk engine when the config file
+// changes, so edits take effect without a restart.
+type RiskConfig struct {
+	Enable      bool               `mapstructure:"enable"`
+	ReviewScore int                `mapstructure:"review_score"`
+	Actions     []RiskActionConfig `mapstructure:"actions"`
+	Rules       []RiskRuleConfig   `mapstructure:"rules"`
+	// ThrottleCooldown is how many seconds a throttled account waits between
+	// plays, 60 when unset.
+	ThrottleCooldown time.Duration `mapstructure:"throttle_cooldown"`
+}
+
+type RiskActionConfig struct {
+	Score  int    `mapstructure:"score"`
+	Action string `mapstructure:"action"`
+}
+
+type RiskRuleConfig struct {
+	Name      string        `mapstructure:"name"`
+	Signal    string        `mapstructure:"signal"`
+	Window    time.Duration `mapstructure:"window"`
+	Threshold int64         `mapstructure:"threshold"`
+	Score     int           `mapstructure:"score"`
+}
+
+type AccountConfig struct {
+	// DeletedRetentionDays is how long erased accounts are kept soft deleted
+	// before they are purged.
+	DeletedRetentionDays int           `mapstructure:"deleted_retention_days"`
+	Profile              ProfileConfig `mapstructure:"profile"`
+}
+
+type ProfileConfig struct {
+	NameMinLen int  `mapstructure:"name_min_len"`
+	NameMaxLen int  `mapstructure:"name_max_len"`
+	UniqueName bool `mapstructure:"unique_name"`
+	// BannedWords are matched case-insensitively anywhere in the name.
+	BannedWords []string `mapstructure:"banned_words"`
+	// AvatarHosts lists the hosts (and their subdomains) avatar URLs may point
+	// to; empty allows any https URL.
+	AvatarHosts []string `mapstructure:"avatar_hosts"`
+	// NameChangeLimit caps name changes per NameChangeWindow seconds.
+	NameChangeLimit  int           `mapstructure:"name_change_limit"`
+	NameChangeWindow time.Duration `mapstructure:"name_change_window"`
+	// AvatarMaxSize is the upload limit in bytes.
+	AvatarMaxSize int64 `mapstructure:"avatar_max_size"`
+	// AvatarSizes are the square thumbnail edges generated on upload; the
+	// first one becomes the account's avatar_url.
+	AvatarSizes []int `mapstructure:"avatar_sizes"`
+}
+
+type StorageConfig struct {
+	Driver string             `mapstructure:"driver"`
+	Local  LocalStorageConfig `mapstructure:"local"`
+	S3     S3StorageConfig    `mapstructure:"s3"`
+}
+
+type LocalStorageConfig struct {
+	Dir     string `mapstructure:"dir"`
+	BaseURL string `mapstructure:"base_url"`
+	// Serve exposes Dir under /image on the HTTP server.
+	Serve bool `mapstructure:"serve"`
+}
+
+type S3StorageConfig struct {
+	Endpoint  string `mapstructure:"endpoint"`
+	Region    string `mapstructure:"region"`
+	Bucket    string `mapstructure:"bucket"`
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string `mapstructure:"secret_key"`
+	PathStyle bool   `mapstructure:"path_style"`
+	BaseURL   string `mapstructure:"base_url"`
+}
+
+type EmailConfig struct {
+	// Mailer is smtp or log; log writes mail to LogFile, or the log when
+	// LogFile is empty, instead of sending it.
+	Mailer  string     `mapstructure:"mailer"`
+	From    string     `mapstructure:"from"`
+	LogFile string     `mapstructure:"log_file"`
+	SMTP    SMTPConfig `mapstructure:"smtp"`
+	// CodeTTL and ResendInterval are in seconds; MaxAttempts caps wrong codes
+	// before the code is dropped.
+	CodeTTL        time.Duration `mapstructure:"code_ttl"`
+	ResendInterval time.Duration `mapstructure:"resend_interval"`
+	MaxAttempts    int           `mapstructure:"max_attempts"`
+	// MagicLinkURL gets the token appended as the token query parameter.
+	MagicLinkURL string `mapstructure:"magic_link_url"`
+	// RequireVerifiedForClaim blocks claims from accounts with an unverified
+	// email; accounts without an email are not affected.
+	RequireVerifiedForClaim bool `mapstructure:"require_verified_for_claim"`
+}
+
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	// TLS dials with implicit TLS; otherwise STARTTLS is used when offered.
+	TLS bool `mapstructure:"tls"`
+}
+
+type AnalyticsConfig struct {
+	// Interval is how often, in seconds, today's and yesterday's stats are
+	// rebuilt.
+	Interval time.Duration `mapstructure:"interval"`
+	// Timezone sets the day boundaries, e.g. Asia/Shanghai; defaults to UTC.
+	Timezone string `mapstructure:"timezone"`
+	// BackfillDays is how far back an empty summary table is filled on start.
+	BackfillDays int `mapstructure:"backfill_days"`
+}
+
+// LeaderConfig elects one replica, through a Redis lease, to run the jobs
+// that must not run on every replica. Without it every replica runs them.
+type LeaderConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Key is the Redis key of the lease, starland-account:leader when unset.
+	Key string `mapstructure:"key"`
+	// TTL is how many seconds the lease outlives a leader that stopped
+	// renewing it, 15 when unset. It is renewed every third of it.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+type EventConfig struct {
+	// Stream is the Redis stream events are appended to, trimmed to about
+	// MaxLen entries.
+	Stream string `mapstructure:"stream"`
+	MaxLen int64  `mapstructure:"max_len"`
+	// BatchSize events are relayed every Interval seconds.
+	BatchSize int           `mapstructure:"batch_size"`
+	Interval  time.Duration `mapstructure:"interval"`
+	// RetentionDays is how long published events stay in the outbox table.
+	RetentionDays int `mapstructure:"retention_days"`
+}
+
+type WebhookConfig struct {
+	// Timeout is the per request timeout in seconds.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxAttempts is how many times a delivery is tried before it is given
+	// up; retries back off exponentially up to MaxBackoff seconds.
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	MaxBackoff  time.Duration `mapstructure:"max_backoff"`
+	// DisableAfter consecutive failed attempts disable the subscription.
+	DisableAfter int `mapstructure:"disable_after"`
+	BatchSize    int `mapstructure:"batch_size"`
+}
+
+// AwardConfig configures the server to server award API.
+type AwardConfig struct {
+	// MaxSkew is how far in seconds a request timestamp may be from now.
+	MaxSkew  time.Duration       `mapstructure:"max_skew"`
+	MaxBatch int                 `mapstructure:"max_batch"`
+	Clients  []AwardClientConfig `mapstructure:"clients"`
+}
+
+// AdminConfig is an admin's own token; the admin's changes are recorded
+// under Name. The shared admin_token authenticates as "admin".
+type AdminConfig struct {
+	Name  string `mapstructure:"name"`
+	Token string `mapstructure:"token"`
+}
+
+// AwardClientConfig is a partner service allowed to award points. Requests
+// carry APIKey and are signed with Secret.
+type AwardClientConfig struct {
+	ID     string `mapstructure:"id"`
+	APIKey string `mapstructure:"api_key"`
+	Secret string `mapstructure:"secret"`
+	// DailyQuota caps the points awarded per UTC day, 0 for no cap.
+	DailyQuota int `mapstructure:"daily_quota"`
+	// MaxPoints caps a single award, 0 for no cap.
+	MaxPoints    int    `mapstructure:"max_points"`
+	ActivityCode int    `mapstructure:"activity_code"`
+	ActivityName string `mapstructure:"activity_name"`
+	// Tenant is the tenant the client awards in, default when empty.
+	// ClientAuth runs the client's requests in it; they carry no X-Token.
+	Tenant string `mapstructure:"tenant"`
+}
+
+// TenantConfig is an app sharing the service. Its requests carry Token as
+// X-Token and only see the tenant's accounts, activities and logs. The
+// default tenant, which owns the data from before tenants existed, falls back
+// to the top level token, private_path and env.
+type TenantConfig struct {
+	ID    string `mapstructure:"id"`
+	Token string `mapstructure:"token"`
+	// PrivatePath is the PEM key claims are signed with.
+	PrivatePath string `mapstructure:"private_path"`
+	// Env picks the Solana cluster like the top level env: pro, test or dev.
+	Env string `mapstructure:"env"`
+	// ProgramID owns the on-chain point records; records owned by another
+	// program are ignored by the chain check. Empty skips the check.
+	ProgramID string `mapstructure:"program_id"`
+	// MaxClaimPoints caps a single claim and DailyPoints the points an account
+	// earns from activities per UTC day, 0 for no cap.
+	MaxClaimPoints int `mapstructure:"max_claim_points"`
+	DailyPoints    int `mapstructure:"daily_points"`
+}
+
+type RateLimitConfig struct {
+	Enable  bool                    `mapstructure:"enable"`
+	Default RateLimitPolicyConfig   `mapstructure:"default"`
+	Routes  []RateLimitPolicyConfig `mapstructure:"routes"`
+}
+
+// RateLimitPolicyConfig limits requests matching Method and Path (fiber style,
+// e.g. /v1/account/:id) to Limit per Window seconds, counted per Key: ip,
+// account (of the authenticated tenant) or token (the tenant it
+// authenticates). Requests without a valid token are counted by ip only.
+type RateLimitPolicyConfig struct {
+	Method string        `mapstructure:"method"`
+	Path   string        `mapstructure:"path"`
+	Key    string        `mapstructure:"key"`
+	Limit  int           `mapstructure:"limit"`
+	Window time.Duration `mapstructure:"window"`
+}
+
 type AgentConfig struct {
 	MaxChatHistoryContextLength int32   `mapstructure:"maxChatHistoryContextLength"`
 	Temperature                 float32 `mapstructure:"temperature"`
@@ -2,10 +2,10 @@ package biz
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"starland-account/internal/pkg/bizerr"
-
-	"go.uber.org/zap"
+	"time"
 )
 
 type AccountRequest struct {
@@ -20,46 +20,109 @@ type AccountRequest struct {
 }
 
 type AccountResponse struct {
-	AccountID  string
-	Integral   int
-	Received   int
-	Email      string
-	Name       string
-	Provider   string
-	AvatarURL  string
-	SolanaAddr string
-	ClaimCount int
+	AccountID     string
+	Integral      int
+	Received      int
+	Email         string
+	Name          string
+	Provider      string
+	AvatarURL     string
+	SolanaAddr    string
+	ClaimCount    int
+	State         int
+	StateReason   string
+	StateExpireAt *time.Time
+	EmailVerified bool
+	CreateAt      time.Time
 }
 
 type AccountRepo interface {
 	SaveAccount(context.Context, *AccountRequest) error
 	QueryAccount(context.Context, string, string, string) (*AccountResponse, error)
 	UpdateAccountIntegral(context.Context, string, int) error
-	UpdateClaimPoints(context.Context, string, int, int) error
+	// UpdateClaimPoints adds the points to what the account has received, and
+	// logs the claim, unless that would exceed its integral; false then.
+	UpdateClaimPoints(context.Context, string, int) (bool, error)
 	QueryAccounts(context.Context) ([]*AccountResponse, error)
+	// SearchAccounts returns up to Limit accounts matching the filters, in
+	// Sort order with account_id as tie breaker, starting after the cursor.
+	SearchAccounts(context.Context, *AccountSearchRequest) ([]*AccountResponse, error)
 	UpdateAddr(context.Context, string, string) error
+	QueryAccountIDs(context.Context, []string) ([]string, error)
+	QueryClaimLogs(context.Context, string) ([]*ClaimLogResponse, error)
+	// EraseAccount anonymizes the profile and its change history, marks the
+	// account deleted and soft deletes it; points and logs are kept for ledger
+	// integrity.
+	EraseAccount(context.Context, *AccountStateRequest) error
+	// PurgeAccounts hard deletes accounts soft deleted before the given time,
+	// together with their profile history. Under a lease it fails with
+	// ErrLeaseStale once a newer term wrote.
+	PurgeAccounts(context.Context, time.Time) (int64, error)
+	// AccountErased reports whether the id belongs to an erased account that
+	// is not purged yet.
+	AccountErased(context.Context, string) (bool, error)
+}
+
+type ClaimLogResponse struct {
+	AccountID  string
+	Points     int
+	Received   int
+	SolanaAddr string
+	ClaimCount int
+	CreateAt   time.Time
 }
 
 type AccountUsecase struct {
-	repo AccountRepo
+	repo    AccountRepo
+	state   AccountStateRepo
+	profile ProfileRepo
+	verify  VerifyRepo
 }
 
-func NewAccountUsecase(repo AccountRepo) *AccountUsecase {
-	return &AccountUsecase{repo: repo}
+func NewAccountUsecase(repo AccountRepo, state AccountStateRepo, profile ProfileRepo, verify VerifyRepo) *AccountUsecase {
+	return &AccountUsecase{repo: repo, state: state, profile: profile, verify: verify}
 }
 
+// SaveAccount refuses the id of an erased account until it is purged, so
+// erasing an account can't shake off its state.
 func (uc *AccountUsecase) SaveAccount(ctx context.Context, req *AccountRequest) error {
-
+	erased, err := uc.repo.AccountErased(ctx, req.AccountID)
+	if err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("SaveAccount: query erased(%s) err: %w", req.AccountID, err))
+	}
+	if erased {
+		return bizerr.ErrAccountDeleted
+	}
 	if err := uc.repo.SaveAccount(ctx, req); err != nil {
 		return bizerr.ErrInternalError.Wrap(fmt.Errorf("SaveAccount: save(%+v) to db err: %w", *req, err))
 	}
 	return nil
 }
 
-func (uc *AccountUsecase) UpdateClaimPoints(ctx context.Context, accountID string, integral, received int) error {
-	zap.S().Info("req:", integral, received)
-	if err := uc.repo.UpdateClaimPoints(ctx, accountID, integral, received); err != nil {
-		return bizerr.ErrInternalError.Wrap(fmt.Errorf("UpdateAccountIntegral: claimPoints err: %w", err))
+// CheckClaimPoints refuses claims of no points or of more than the account's
+// unclaimed balance.
+func (uc *AccountUsecase) CheckClaimPoints(ac *AccountResponse, points int) error {
+	if points <= 0 {
+		return bizerr.ErrBadRequest.Errorf("points must be positive")
+	}
+	if ac.Integral < ac.Received+points {
+		return bizerr.ErrNotEnoughPoints
+	}
+	return nil
+}
+
+// ClaimPoints adds points to what ac has received.
+func (uc *AccountUsecase) ClaimPoints(ctx context.Context, ac *AccountResponse, points int) error {
+	if err := uc.CheckClaimPoints(ac, points); err != nil {
+		return err
+	}
+	ok, err := uc.repo.UpdateClaimPoints(ctx, ac.AccountID, points)
+	if err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("ClaimPoints: claimPoints err: %w", err))
+	}
+	if !ok {
+		// spent by a concurrent claim since ac was read
+		return bizerr.ErrNotEnoughPoints
 	}
 	return nil
 }
@@ -101,3 +164,44 @@ func (uc *AccountUsecase) UpdateAddr(ctx context.Context, account, addr string)
 	}
 	return nil
 }
+
+func (uc *AccountUsecase) QueryClaimLogs(ctx context.Context, accountID string) ([]*ClaimLogResponse, error) {
+	res, err := uc.repo.QueryClaimLogs(ctx, accountID)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryClaimLogs: query(%s) err: %w", accountID, err))
+	}
+	return res, nil
+}
+
+// EraseAccount drops the account's pending verification codes and magic
+// links, then erases it. The codes go first so a failure leaves the account to
+// be erased again.
+func (uc *AccountUsecase) EraseAccount(ctx context.Context, accountID, actor string) error {
+	if _, err := uc.QueryAccount(ctx, accountID, "", ""); err != nil {
+		return err
+	}
+	if err := uc.verify.DeleteAccountVerifyCodes(ctx, accountID); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("EraseAccount: delete verify codes(%s) err: %w", accountID, err))
+	}
+	req := &AccountStateRequest{
+		AccountID: accountID,
+		State:     AccountStateDeleted,
+		Reason:    "erasure requested",
+		Actor:     actor,
+	}
+	if err := uc.repo.EraseAccount(ctx, req); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("EraseAccount: erase(%s) err: %w", accountID, err))
+	}
+	return nil
+}
+
+func (uc *AccountUsecase) PurgeAccounts(ctx context.Context, before time.Time) (int64, error) {
+	n, err := uc.repo.PurgeAccounts(ctx, before)
+	if errors.Is(err, ErrLeaseStale) {
+		return n, bizerr.ErrNotLeader.Wrap(fmt.Errorf("PurgeAccounts: purge before %s err: %w", before, err))
+	}
+	if err != nil {
+		return n, bizerr.ErrInternalError.Wrap(fmt.Errorf("PurgeAccounts: purge before %s err: %w", before, err))
+	}
+	return n, nil
+}
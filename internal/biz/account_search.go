@@ -0,0 +1,108 @@
+package biz
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+)
+
+const (
+	AccountSortCreatedAt = "created_at"
+	AccountSortIntegral  = "integral"
+	AccountSortReceived  = "received"
+	AccountSortAccountID = "account_id"
+
+	defaultAccountSearchLimit = 50
+	maxAccountSearchLimit     = 500
+)
+
+var accountSorts = map[string]bool{
+	AccountSortCreatedAt: true,
+	AccountSortIntegral:  true,
+	AccountSortReceived:  true,
+	AccountSortAccountID: true,
+}
+
+// AccountSearchRequest filters are combined with AND; zero values and nil
+// pointers are ignored.
+type AccountSearchRequest struct {
+	AccountIDPrefix string
+	Email           string
+	NamePrefix      string
+	Provider        string
+	SolanaAddr      string
+	State           *int
+	CreatedFrom     *time.Time
+	CreatedTo       *time.Time
+	MinIntegral     *int
+	MaxIntegral     *int
+	Sort            string
+	Desc            bool
+	Limit           int
+	After           *AccountCursor
+}
+
+// AccountCursor is the sort key of the last account on a page; the next page
+// starts right after it.
+type AccountCursor struct {
+	CreatedAt time.Time `json:"c,omitempty"`
+	Integral  int       `json:"i,omitempty"`
+	Received  int       `json:"r,omitempty"`
+	AccountID string    `json:"a"`
+}
+
+type AccountPage struct {
+	Accounts   []*AccountResponse
+	NextCursor string
+}
+
+func EncodeAccountCursor(c *AccountCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeAccountCursor(s string) (*AccountCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, bizerr.ErrBadRequest.Errorf("invalid cursor")
+	}
+	var c AccountCursor
+	if err = json.Unmarshal(b, &c); err != nil || c.AccountID == "" {
+		return nil, bizerr.ErrBadRequest.Errorf("invalid cursor")
+	}
+	return &c, nil
+}
+
+func (uc *AccountUsecase) SearchAccounts(ctx context.Context, req *AccountSearchRequest) (*AccountPage, error) {
+	if req.Sort == "" {
+		req.Sort = AccountSortCreatedAt
+	}
+	if !accountSorts[req.Sort] {
+		return nil, bizerr.ErrBadRequest.Errorf("unknown sort %s", req.Sort)
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultAccountSearchLimit
+	}
+	if req.Limit > maxAccountSearchLimit {
+		req.Limit = maxAccountSearchLimit
+	}
+
+	accounts, err := uc.repo.SearchAccounts(ctx, req)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("SearchAccounts: search(%+v) err: %w", *req, err))
+	}
+	page := &AccountPage{Accounts: accounts}
+	if len(accounts) == req.Limit {
+		last := accounts[len(accounts)-1]
+		page.NextCursor = EncodeAccountCursor(&AccountCursor{
+			CreatedAt: last.CreateAt,
+			Integral:  last.Integral,
+			Received:  last.Received,
+			AccountID: last.AccountID,
+		})
+	}
+	return page, nil
+}
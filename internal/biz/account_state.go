@@ -0,0 +1,231 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	AccountStateBanned    = -1
+	AccountStateActive    = 0
+	AccountStateSuspended = 1
+	AccountStateDeleted   = 2
+)
+
+const (
+	AppealPending  = 0
+	AppealApproved = 1
+	AppealRejected = 2
+)
+
+const SystemActor = "system"
+
+var AccountStates = map[int]string{
+	AccountStateBanned:    "banned",
+	AccountStateActive:    "active",
+	AccountStateSuspended: "suspended",
+	AccountStateDeleted:   "deleted",
+}
+
+type AccountStateRequest struct {
+	AccountID string
+	State     int
+	Reason    string
+	Actor     string
+	ExpireAt  *time.Time
+	// From, when set, limits the change to an account in one of these
+	// states, which don't include State.
+	From []int
+}
+
+type AccountStateLogResponse struct {
+	AccountID string
+	FromState int
+	ToState   int
+	Reason    string
+	Actor     string
+	ExpireAt  *time.Time
+	CreateAt  time.Time
+}
+
+type AppealRequest struct {
+	AppealID  string
+	AccountID string
+	Content   string
+}
+
+// ResolveAppealRequest closes an appeal; Reactivate, when set, is the state
+// change an approval makes.
+type ResolveAppealRequest struct {
+	AppealID   string
+	State      int
+	Actor      string
+	Reply      string
+	Reactivate *AccountStateRequest
+}
+
+type AppealResponse struct {
+	AppealID  string
+	AccountID string
+	Content   string
+	State     int
+	Actor     string
+	Reply     string
+	CreateAt  time.Time
+	UpdateAt  time.Time
+}
+
+type AccountStateRepo interface {
+	// UpdateAccountState changes the account state and records the transition
+	// in the same transaction. It returns false, changing nothing, when the
+	// account is in none of the request's From states. Under a lease it fails
+	// with ErrLeaseStale once a newer term wrote.
+	UpdateAccountState(context.Context, *AccountStateRequest) (bool, error)
+	QueryAccountStateLogs(context.Context, string) ([]*AccountStateLogResponse, error)
+	AddAppeal(context.Context, *AppealRequest) error
+	QueryAppeal(context.Context, string) (*AppealResponse, error)
+	QueryAppeals(context.Context, int) ([]*AppealResponse, error)
+	QueryPendingAppeal(context.Context, string) (*AppealResponse, error)
+	// ResolveAppeal closes the appeal and makes the request's state change in
+	// the same transaction. It returns false, changing nothing, when the
+	// appeal is no longer pending or the account is in none of the state
+	// change's From states.
+	ResolveAppeal(context.Context, *ResolveAppealRequest) (bool, error)
+}
+
+func (uc *AccountUsecase) ChangeAccountState(ctx context.Context, req *AccountStateRequest) error {
+	if _, ok := AccountStates[req.State]; !ok {
+		return bizerr.ErrBadRequest.Errorf("unknown state %d", req.State)
+	}
+	if _, err := uc.QueryAccount(ctx, req.AccountID, "", ""); err != nil {
+		return err
+	}
+	ok, err := uc.state.UpdateAccountState(ctx, req)
+	if errors.Is(err, ErrLeaseStale) {
+		return bizerr.ErrNotLeader.Wrap(fmt.Errorf("ChangeAccountState: save(%+v) err: %w", *req, err))
+	}
+	if err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("ChangeAccountState: save(%+v) to db err: %w", *req, err))
+	}
+	if !ok {
+		return bizerr.ErrAccountStateConflict
+	}
+	return nil
+}
+
+// CheckAccountState returns an error unless the account may earn, claim or bind
+// a wallet. Suspensions past their expiry are lifted on the way.
+func (uc *AccountUsecase) CheckAccountState(ctx context.Context, accountID string) error {
+	res, err := uc.QueryAccount(ctx, accountID, "", "")
+	if err != nil {
+		return err
+	}
+
+	switch res.State {
+	case AccountStateActive:
+		return nil
+	case AccountStateSuspended:
+		if res.StateExpireAt != nil && !res.StateExpireAt.After(time.Now()) {
+			_, err = uc.state.UpdateAccountState(ctx, &AccountStateRequest{
+				AccountID: accountID,
+				State:     AccountStateActive,
+				Reason:    "suspension expired",
+				Actor:     SystemActor,
+				From:      []int{AccountStateSuspended},
+			})
+			if err != nil {
+				zap.S().Errorf("CheckAccountState: lift expired suspension of %s err: %v", accountID, err)
+			}
+			return nil
+		}
+		return bizerr.ErrAccountSuspended
+	case AccountStateBanned:
+		return bizerr.ErrAccountBanned
+	case AccountStateDeleted:
+		return bizerr.ErrAccountDeleted
+	}
+	return nil
+}
+
+func (uc *AccountUsecase) QueryAccountStateLogs(ctx context.Context, accountID string) ([]*AccountStateLogResponse, error) {
+	res, err := uc.state.QueryAccountStateLogs(ctx, accountID)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryAccountStateLogs: query(%s) err: %w", accountID, err))
+	}
+	return res, nil
+}
+
+func (uc *AccountUsecase) AddAppeal(ctx context.Context, req *AppealRequest) error {
+	account, err := uc.QueryAccount(ctx, req.AccountID, "", "")
+	if err != nil {
+		return err
+	}
+	if account.State != AccountStateSuspended && account.State != AccountStateBanned {
+		return bizerr.ErrAppealNotAllowed
+	}
+	pending, err := uc.state.QueryPendingAppeal(ctx, req.AccountID)
+	if err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("AddAppeal: query pending appeal(%s) err: %w", req.AccountID, err))
+	}
+	if pending != nil {
+		return bizerr.ErrAppealPending
+	}
+	if err = uc.state.AddAppeal(ctx, req); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("AddAppeal: save(%+v) to db err: %w", *req, err))
+	}
+	return nil
+}
+
+func (uc *AccountUsecase) QueryAppeals(ctx context.Context, state int) ([]*AppealResponse, error) {
+	res, err := uc.state.QueryAppeals(ctx, state)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryAppeals: query(%d) err: %w", state, err))
+	}
+	return res, nil
+}
+
+// ResolveAppeal closes a pending appeal; approving it reactivates the account.
+func (uc *AccountUsecase) ResolveAppeal(ctx context.Context, appealID string, approve bool, actor, reply string) error {
+	appeal, err := uc.state.QueryAppeal(ctx, appealID)
+	if err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("ResolveAppeal: query(%s) err: %w", appealID, err))
+	}
+	if appeal == nil {
+		return bizerr.ErrAppealNotExist
+	}
+	if appeal.State != AppealPending {
+		return bizerr.ErrAppealResolved
+	}
+
+	req := &ResolveAppealRequest{AppealID: appealID, State: AppealRejected, Actor: actor, Reply: reply}
+	if approve {
+		req.State = AppealApproved
+		req.Reactivate = &AccountStateRequest{
+			AccountID: appeal.AccountID,
+			State:     AccountStateActive,
+			Reason:    fmt.Sprintf("appeal %s approved: %s", appealID, reply),
+			Actor:     actor,
+			From:      []int{AccountStateSuspended, AccountStateBanned},
+		}
+	}
+	ok, err := uc.state.ResolveAppeal(ctx, req)
+	if err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("ResolveAppeal: save(%s) err: %w", appealID, err))
+	}
+	if ok {
+		return nil
+	}
+	// resolved concurrently, or the account left the states an approval lifts
+	if appeal, err = uc.state.QueryAppeal(ctx, appealID); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("ResolveAppeal: query(%s) err: %w", appealID, err))
+	}
+	if appeal == nil || appeal.State != AppealPending {
+		return bizerr.ErrAppealResolved
+	}
+	return bizerr.ErrAccountStateConflict
+}
@@ -14,13 +14,18 @@ type ActivityLogRequest struct {
 	ActivityCode int
 	ActivityName string
 	Integral     int
+	// ClientID is the partner client that issued the award, empty for the
+	// account's own activity.
+	ClientID string
 }
 
 type ActivityLogResponse struct {
+	ID           uint
 	AccountID    string
 	ActivityCode int
 	ActivityName string
 	Integral     int
+	ClientID     string
 	CreateAt     time.Time
 }
 
@@ -39,7 +44,15 @@ type ActivityRepo interface {
 
 type ActivityLogRepo interface {
 	AddActivityLog(context.Context, *ActivityLogRequest) error
-	QueryActivityLog(context.Context, string, int, int) ([]*ActivityLogResponse, int64, error)
+	// EarnPoints adds the points to the account and logs them atomically.
+	EarnPoints(context.Context, *ActivityLogRequest) error
+	// QueryActivityLog returns up to Limit logs newest first, starting after
+	// the cursor.
+	QueryActivityLog(context.Context, *ActivityLogQuery) ([]*ActivityLogResponse, error)
+	// SumActivityLogs totals the logs matching the query per activity,
+	// ignoring the cursor.
+	SumActivityLogs(context.Context, *ActivityLogQuery) ([]*ActivityLogTotal, error)
+	QueryAllActivityLogs(context.Context, string) ([]*ActivityLogResponse, error)
 }
 
 type ActivityUsecase struct {
@@ -74,12 +87,11 @@ func (uc *ActivityUsecase) AddActivityLog(ctx context.Context, req *ActivityLogR
 	return nil
 }
 
-func (uc *ActivityUsecase) QueryActivityLog(ctx context.Context, addr string, page, limit int) ([]*ActivityLogResponse, int64, error) {
-	res, count, err := uc.activityLog.QueryActivityLog(ctx, addr, page, limit)
-	if err != nil {
-		return nil, count, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryActivity: query activity err: %w", err))
+func (uc *ActivityUsecase) EarnPoints(ctx context.Context, req *ActivityLogRequest) error {
+	if err := uc.activityLog.EarnPoints(ctx, req); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("EarnPoints: earn(%+v) err: %w", *req, err))
 	}
-	return res, count, nil
+	return nil
 }
 
 func (uc *ActivityUsecase) ConsumeActivityLimit(ctx context.Context, key string, n int, t time.Duration) error {
@@ -97,3 +109,11 @@ func (uc *ActivityUsecase) QueryActivityExpend(ctx context.Context, key string)
 	}
 	return res, nil
 }
+
+func (uc *ActivityUsecase) QueryAllActivityLogs(ctx context.Context, account string) ([]*ActivityLogResponse, error) {
+	res, err := uc.activityLog.QueryAllActivityLogs(ctx, account)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryAllActivityLogs: query(%s) err: %w", account, err))
+	}
+	return res, nil
+}
@@ -0,0 +1,94 @@
+package biz
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+)
+
+const (
+	defaultActivityLogLimit = 20
+	maxActivityLogLimit     = 1000
+)
+
+// ActivityLogQuery filters an account's logs; nil filters are ignored and
+// To is exclusive. Page is only honoured without a cursor, for clients that
+// still page with offsets.
+type ActivityLogQuery struct {
+	AccountID    string
+	ActivityCode *int
+	From         *time.Time
+	To           *time.Time
+	Limit        int
+	Page         int
+	After        *ActivityLogCursor
+}
+
+// ActivityLogCursor is the (created_at, id) of the last log on a page.
+type ActivityLogCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uint      `json:"i"`
+}
+
+type ActivityLogTotal struct {
+	ActivityCode int
+	ActivityName string
+	Count        int64
+	Integral     int64
+}
+
+type ActivityLogPage struct {
+	Logs       []*ActivityLogResponse
+	NextCursor string
+}
+
+func EncodeActivityLogCursor(c *ActivityLogCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeActivityLogCursor(s string) (*ActivityLogCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, bizerr.ErrBadRequest.Errorf("invalid cursor")
+	}
+	var c ActivityLogCursor
+	if err = json.Unmarshal(b, &c); err != nil || c.ID == 0 {
+		return nil, bizerr.ErrBadRequest.Errorf("invalid cursor")
+	}
+	return &c, nil
+}
+
+func (uc *ActivityUsecase) QueryActivityLog(ctx context.Context, query *ActivityLogQuery) (*ActivityLogPage, error) {
+	if query.Limit <= 0 {
+		query.Limit = defaultActivityLogLimit
+	}
+	if query.Limit > maxActivityLogLimit {
+		query.Limit = maxActivityLogLimit
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+
+	logs, err := uc.activityLog.QueryActivityLog(ctx, query)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryActivityLog: query(%s) err: %w", query.AccountID, err))
+	}
+	page := &ActivityLogPage{Logs: logs}
+	if len(logs) == query.Limit {
+		last := logs[len(logs)-1]
+		page.NextCursor = EncodeActivityLogCursor(&ActivityLogCursor{CreatedAt: last.CreateAt, ID: last.ID})
+	}
+	return page, nil
+}
+
+func (uc *ActivityUsecase) SumActivityLogs(ctx context.Context, query *ActivityLogQuery) ([]*ActivityLogTotal, error) {
+	res, err := uc.activityLog.SumActivityLogs(ctx, query)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("SumActivityLogs: sum(%s) err: %w", query.AccountID, err))
+	}
+	return res, nil
+}
@@ -0,0 +1,177 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+)
+
+const (
+	AirdropActivityCode = -1
+	AirdropActivityName = "airdrop"
+)
+
+const (
+	AirdropJobPending  = 0
+	AirdropJobRunning  = 1
+	AirdropJobFinished = 2
+)
+
+const (
+	AirdropItemPending   = 0
+	AirdropItemSucceeded = 1
+	AirdropItemFailed    = 2
+)
+
+type AirdropItemRequest struct {
+	Line      int
+	AccountID string
+	Points    int
+	Reason    string
+	State     int
+	Err       string
+}
+
+type AirdropJobRequest struct {
+	JobID    string
+	Operator string
+	FileName string
+	Items    []*AirdropItemRequest
+}
+
+type AirdropJobResponse struct {
+	JobID     string
+	Operator  string
+	FileName  string
+	State     int
+	Total     int
+	Pending   int
+	Succeeded int
+	Failed    int
+	CreateAt  time.Time
+	UpdateAt  time.Time
+}
+
+type AirdropItemResponse struct {
+	Line      int
+	AccountID string
+	Points    int
+	Reason    string
+	State     int
+	Err       string
+}
+
+type AirdropRepo interface {
+	CreateAirdropJob(context.Context, *AirdropJobRequest) error
+	QueryAirdropJob(context.Context, string) (*AirdropJobResponse, error)
+	QueryUnfinishedAirdropJobs(context.Context) ([]string, error)
+	UpdateAirdropJobState(context.Context, string, int) error
+	QueryPendingAirdropItems(context.Context, string, int) ([]*AirdropItemResponse, error)
+	QueryAirdropItems(context.Context, string) ([]*AirdropItemResponse, error)
+	// ApplyAirdropItem awards the item's points at most once; items that are
+	// no longer pending are left untouched. An item for an account that may
+	// not earn, banned, suspended or gone, fails with the reason.
+	ApplyAirdropItem(context.Context, string, *AirdropItemResponse) error
+}
+
+type AirdropUsecase struct {
+	repo    AirdropRepo
+	account AccountRepo
+}
+
+func NewAirdropUsecase(repo AirdropRepo, account AccountRepo) *AirdropUsecase {
+	return &AirdropUsecase{repo: repo, account: account}
+}
+
+// CreateAirdropJob validates every row against existing accounts and stores the
+// job; rows that fail validation are kept as failed items for the report.
+func (uc *AirdropUsecase) CreateAirdropJob(ctx context.Context, req *AirdropJobRequest) error {
+	ids := make([]string, 0, len(req.Items))
+	for i := range req.Items {
+		if req.Items[i].State == AirdropItemPending && req.Items[i].AccountID != "" {
+			ids = append(ids, req.Items[i].AccountID)
+		}
+	}
+	exists, err := uc.account.QueryAccountIDs(ctx, ids)
+	if err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("CreateAirdropJob: query accounts err: %w", err))
+	}
+	existMap := make(map[string]bool, len(exists))
+	for i := range exists {
+		existMap[exists[i]] = true
+	}
+
+	seen := make(map[string]int, len(req.Items))
+	for _, item := range req.Items {
+		if item.State != AirdropItemPending {
+			continue
+		}
+		switch {
+		case item.AccountID == "":
+			item.State, item.Err = AirdropItemFailed, "account_id is empty"
+		case item.Points <= 0:
+			item.State, item.Err = AirdropItemFailed, "points must be positive"
+		case !existMap[item.AccountID]:
+			item.State, item.Err = AirdropItemFailed, bizerr.ErrAccountNotExist.Msg()
+		case seen[item.AccountID] != 0:
+			item.State, item.Err = AirdropItemFailed, fmt.Sprintf("duplicate of line %d", seen[item.AccountID])
+		default:
+			seen[item.AccountID] = item.Line
+		}
+	}
+
+	if err = uc.repo.CreateAirdropJob(ctx, req); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("CreateAirdropJob: save job(%s) to db err: %w", req.JobID, err))
+	}
+	return nil
+}
+
+func (uc *AirdropUsecase) QueryAirdropJob(ctx context.Context, jobID string) (*AirdropJobResponse, error) {
+	res, err := uc.repo.QueryAirdropJob(ctx, jobID)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryAirdropJob: query job(%s) err: %w", jobID, err))
+	}
+	if res == nil {
+		return nil, bizerr.ErrAirdropJobNotExist
+	}
+	return res, nil
+}
+
+func (uc *AirdropUsecase) QueryUnfinishedAirdropJobs(ctx context.Context) ([]string, error) {
+	res, err := uc.repo.QueryUnfinishedAirdropJobs(ctx)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryUnfinishedAirdropJobs: query jobs err: %w", err))
+	}
+	return res, nil
+}
+
+func (uc *AirdropUsecase) UpdateAirdropJobState(ctx context.Context, jobID string, state int) error {
+	if err := uc.repo.UpdateAirdropJobState(ctx, jobID, state); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("UpdateAirdropJobState: update job(%s) err: %w", jobID, err))
+	}
+	return nil
+}
+
+func (uc *AirdropUsecase) QueryPendingAirdropItems(ctx context.Context, jobID string, limit int) ([]*AirdropItemResponse, error) {
+	res, err := uc.repo.QueryPendingAirdropItems(ctx, jobID, limit)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryPendingAirdropItems: query job(%s) err: %w", jobID, err))
+	}
+	return res, nil
+}
+
+func (uc *AirdropUsecase) QueryAirdropItems(ctx context.Context, jobID string) ([]*AirdropItemResponse, error) {
+	res, err := uc.repo.QueryAirdropItems(ctx, jobID)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryAirdropItems: query job(%s) err: %w", jobID, err))
+	}
+	return res, nil
+}
+
+func (uc *AirdropUsecase) ApplyAirdropItem(ctx context.Context, jobID string, item *AirdropItemResponse) error {
+	if err := uc.repo.ApplyAirdropItem(ctx, jobID, item); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("ApplyAirdropItem: apply job(%s) line(%d) err: %w", jobID, item.Line, err))
+	}
+	return nil
+}
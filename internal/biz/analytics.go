@@ -0,0 +1,99 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+)
+
+// StatDayLayout is the format of the day key in the summary tables.
+const StatDayLayout = "2006-01-02"
+
+type DailyActivityStat struct {
+	Day          string
+	ActivityCode int
+	ActivityName string
+	Plays        int64
+	UniqueUsers  int64
+	PointsIssued int64
+}
+
+type DailyAccountStat struct {
+	Day           string
+	AccountID     string
+	Plays         int64
+	PointsIssued  int64
+	Claims        int64
+	PointsClaimed int64
+}
+
+type DailyTotal struct {
+	Day           string
+	Plays         int64
+	UniqueUsers   int64
+	PointsIssued  int64
+	PointsClaimed int64
+}
+
+type AnalyticsRepo interface {
+	// RollupDay rebuilds the day's activity and account stats from the logs
+	// created in [from, to), replacing any previous rollup of the day.
+	RollupDay(context.Context, string, time.Time, time.Time) error
+	// LatestRollupDay returns the last day rolled up, or "" if none.
+	LatestRollupDay(context.Context) (string, error)
+	QueryActivityStats(context.Context, string, string, *int) ([]*DailyActivityStat, error)
+	QueryAccountStats(context.Context, string, string, string) ([]*DailyAccountStat, error)
+	QueryDailyTotals(context.Context, string, string) ([]*DailyTotal, error)
+}
+
+type AnalyticsUsecase struct {
+	repo AnalyticsRepo
+}
+
+func NewAnalyticsUsecase(repo AnalyticsRepo) *AnalyticsUsecase {
+	return &AnalyticsUsecase{repo: repo}
+}
+
+// RollupDay rebuilds the stats of the day containing t, with day boundaries
+// in t's location.
+func (uc *AnalyticsUsecase) RollupDay(ctx context.Context, t time.Time) error {
+	from := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	day := from.Format(StatDayLayout)
+	if err := uc.repo.RollupDay(ctx, day, from, from.AddDate(0, 0, 1)); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("RollupDay: rollup(%s) err: %w", day, err))
+	}
+	return nil
+}
+
+func (uc *AnalyticsUsecase) LatestRollupDay(ctx context.Context) (string, error) {
+	day, err := uc.repo.LatestRollupDay(ctx)
+	if err != nil {
+		return "", bizerr.ErrInternalError.Wrap(fmt.Errorf("LatestRollupDay: query err: %w", err))
+	}
+	return day, nil
+}
+
+func (uc *AnalyticsUsecase) QueryActivityStats(ctx context.Context, from, to string, activityCode *int) ([]*DailyActivityStat, error) {
+	res, err := uc.repo.QueryActivityStats(ctx, from, to, activityCode)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryActivityStats: query(%s~%s) err: %w", from, to, err))
+	}
+	return res, nil
+}
+
+func (uc *AnalyticsUsecase) QueryAccountStats(ctx context.Context, accountID, from, to string) ([]*DailyAccountStat, error) {
+	res, err := uc.repo.QueryAccountStats(ctx, accountID, from, to)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryAccountStats: query(%s %s~%s) err: %w", accountID, from, to, err))
+	}
+	return res, nil
+}
+
+func (uc *AnalyticsUsecase) QueryDailyTotals(ctx context.Context, from, to string) ([]*DailyTotal, error) {
+	res, err := uc.repo.QueryDailyTotals(ctx, from, to)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryDailyTotals: query(%s~%s) err: %w", from, to, err))
+	}
+	return res, nil
+}
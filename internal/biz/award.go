@@ -0,0 +1,118 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	AwardStatusAwarded   = "awarded"
+	AwardStatusDuplicate = "duplicate"
+	AwardStatusRejected  = "rejected"
+
+	// AwardQuotaDayLayout is the UTC day quotas are counted per.
+	AwardQuotaDayLayout = "20060102"
+)
+
+// AwardRequest credits points on behalf of a partner client. ExternalRef is
+// the client's id for the award; a client can only award a ref once.
+type AwardRequest struct {
+	ClientID     string
+	ExternalRef  string
+	AccountID    string
+	ActivityCode int
+	ActivityName string
+	Points       int
+}
+
+type Award struct {
+	ClientID      string
+	ExternalRef   string
+	AccountID     string
+	ActivityCode  int
+	Points        int
+	ActivityLogID uint
+	CreateAt      time.Time
+}
+
+// AwardResult is the outcome of one award of a batch.
+type AwardResult struct {
+	Status string
+	Award  *Award
+	Err    error
+}
+
+type AwardRepo interface {
+	QueryAward(context.Context, string, string) (*Award, error)
+	// AddAward records the award, credits the points and logs them in one
+	// transaction. When the client already used the ref it changes nothing
+	// and returns the existing award with false.
+	AddAward(context.Context, *AwardRequest) (*Award, bool, error)
+	// ReserveAwardQuota adds points to the client's usage of the day unless
+	// that would exceed limit, and reports whether it did.
+	ReserveAwardQuota(context.Context, string, string, int, int) (bool, error)
+	ReleaseAwardQuota(context.Context, string, string, int) error
+}
+
+type AwardUsecase struct {
+	repo AwardRepo
+}
+
+func NewAwardUsecase(repo AwardRepo) *AwardUsecase {
+	return &AwardUsecase{repo: repo}
+}
+
+// Award applies one award. Retrying a ref returns the original award as a
+// duplicate without counting it against the quota again; reusing a ref for a
+// different account or amount is rejected. dailyQuota <= 0 disables the quota.
+func (uc *AwardUsecase) Award(ctx context.Context, req *AwardRequest, dailyQuota int) *AwardResult {
+	existing, err := uc.repo.QueryAward(ctx, req.ClientID, req.ExternalRef)
+	if err != nil {
+		return &AwardResult{Status: AwardStatusRejected, Err: bizerr.ErrInternalError.Wrap(fmt.Errorf("Award: query(%s/%s) err: %w", req.ClientID, req.ExternalRef, err))}
+	}
+	if existing != nil {
+		return duplicateAward(req, existing)
+	}
+
+	day := time.Now().UTC().Format(AwardQuotaDayLayout)
+	if dailyQuota > 0 {
+		ok, err := uc.repo.ReserveAwardQuota(ctx, req.ClientID, day, req.Points, dailyQuota)
+		if err != nil {
+			return &AwardResult{Status: AwardStatusRejected, Err: bizerr.ErrInternalError.Wrap(fmt.Errorf("Award: reserve quota(%s) err: %w", req.ClientID, err))}
+		}
+		if !ok {
+			return &AwardResult{Status: AwardStatusRejected, Err: bizerr.ErrAwardQuotaExceeded}
+		}
+	}
+
+	award, created, err := uc.repo.AddAward(ctx, req)
+	if (err != nil || !created) && dailyQuota > 0 {
+		if rerr := uc.repo.ReleaseAwardQuota(ctx, req.ClientID, day, req.Points); rerr != nil {
+			zap.S().Errorf("Award: release quota(%s) err: %v", req.ClientID, rerr)
+		}
+	}
+	if err != nil {
+		return &AwardResult{Status: AwardStatusRejected, Err: bizerr.ErrInternalError.Wrap(fmt.Errorf("Award: add(%s/%s) err: %w", req.ClientID, req.ExternalRef, err))}
+	}
+	if !created {
+		return duplicateAward(req, award)
+	}
+	return &AwardResult{Status: AwardStatusAwarded, Award: award}
+}
+
+// duplicateAward compares a retry with the award holding its ref. The ref is
+// unique per client across tenants, so a ref the client used while it
+// belonged to another tenant conflicts without an award to return.
+func duplicateAward(req *AwardRequest, existing *Award) *AwardResult {
+	if existing == nil {
+		return &AwardResult{Status: AwardStatusRejected, Err: bizerr.ErrAwardRefConflict}
+	}
+	if existing.AccountID != req.AccountID || existing.Points != req.Points {
+		return &AwardResult{Status: AwardStatusRejected, Award: existing, Err: bizerr.ErrAwardRefConflict}
+	}
+	return &AwardResult{Status: AwardStatusDuplicate, Award: existing}
+}
@@ -2,4 +2,4 @@ package biz
 
 import "github.com/google/wire"
 
-var ProviderSet = wire.NewSet(NewAccountUsecase, NewActivityUsecase)
+var ProviderSet = wire.NewSet(NewAccountUsecase, NewActivityUsecase, NewAirdropUsecase, NewRiskUsecase, NewRateLimitUsecase, NewAnalyticsUsecase, NewOutboxUsecase, NewWebhookUsecase, NewAwardUsecase, NewLeaderUsecase)
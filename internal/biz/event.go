@@ -0,0 +1,121 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+)
+
+// Event types published to the event stream. Payload schemas are documented
+// in docs/events.md; bump EventVersion on breaking changes.
+const (
+	EventAccountCreated = "account.created"
+	EventPointsEarned   = "points.earned"
+	EventPointsClaimed  = "points.claimed"
+	EventAccountBanned  = "account.banned"
+
+	EventVersion = 1
+)
+
+// Event is the envelope stored in the outbox and published as is. ID is
+// stable across redeliveries so consumers can drop duplicates.
+type Event struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Version     int             `json:"version"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	TenantID    string          `json:"tenant_id"`
+	AggregateID string          `json:"aggregate_id"`
+	Data        json.RawMessage `json:"data"`
+}
+
+type AccountCreatedEvent struct {
+	AccountID string    `json:"account_id"`
+	Provider  string    `json:"provider"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PointsEarnedEvent struct {
+	AccountID    string    `json:"account_id"`
+	ActivityCode int       `json:"activity_code"`
+	ActivityName string    `json:"activity_name"`
+	Points       int       `json:"points"`
+	ClientID     string    `json:"client_id,omitempty"`
+	EarnedAt     time.Time `json:"earned_at"`
+}
+
+type PointsClaimedEvent struct {
+	AccountID  string    `json:"account_id"`
+	Points     int       `json:"points"`
+	Received   int       `json:"received"`
+	SolanaAddr string    `json:"solana_addr"`
+	ClaimCount int       `json:"claim_count"`
+	ClaimedAt  time.Time `json:"claimed_at"`
+}
+
+type AccountBannedEvent struct {
+	AccountID string    `json:"account_id"`
+	Reason    string    `json:"reason"`
+	Actor     string    `json:"actor"`
+	BannedAt  time.Time `json:"banned_at"`
+}
+
+// OutboxEvent is an event waiting in the outbox to be published.
+type OutboxEvent struct {
+	ID       uint
+	Event    *Event
+	Attempts int
+}
+
+type OutboxRepo interface {
+	// ListPendingEvents returns unpublished events due for an attempt, oldest
+	// first.
+	ListPendingEvents(context.Context, time.Time, int) ([]*OutboxEvent, error)
+	PublishEvent(context.Context, *Event) error
+	MarkEventPublished(context.Context, uint) error
+	MarkEventFailed(context.Context, uint, time.Time, string) error
+	PurgePublishedEvents(context.Context, time.Time) (int64, error)
+}
+
+type OutboxUsecase struct {
+	repo OutboxRepo
+}
+
+func NewOutboxUsecase(repo OutboxRepo) *OutboxUsecase {
+	return &OutboxUsecase{repo: repo}
+}
+
+// RelayEvents publishes up to limit due events. An event is marked published
+// only after the publish succeeds, so a crash in between redelivers it: the
+// delivery is at least once. Failures are retried with backoff.
+func (uc *OutboxUsecase) RelayEvents(ctx context.Context, limit int, backoff func(int) time.Duration) (int, error) {
+	events, err := uc.repo.ListPendingEvents(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, bizerr.ErrInternalError.Wrap(fmt.Errorf("RelayEvents: list pending err: %w", err))
+	}
+	published := 0
+	for _, e := range events {
+		if err = uc.repo.PublishEvent(ctx, e.Event); err != nil {
+			next := time.Now().Add(backoff(e.Attempts + 1))
+			if merr := uc.repo.MarkEventFailed(ctx, e.ID, next, err.Error()); merr != nil {
+				return published, bizerr.ErrInternalError.Wrap(fmt.Errorf("RelayEvents: mark failed(%s) err: %w", e.Event.ID, merr))
+			}
+			continue
+		}
+		if err = uc.repo.MarkEventPublished(ctx, e.ID); err != nil {
+			return published, bizerr.ErrInternalError.Wrap(fmt.Errorf("RelayEvents: mark published(%s) err: %w", e.Event.ID, err))
+		}
+		published++
+	}
+	return published, nil
+}
+
+func (uc *OutboxUsecase) PurgePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	n, err := uc.repo.PurgePublishedEvents(ctx, before)
+	if err != nil {
+		return n, bizerr.ErrInternalError.Wrap(fmt.Errorf("PurgePublishedEvents: purge before %s err: %w", before, err))
+	}
+	return n, nil
+}
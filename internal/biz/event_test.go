@@ -0,0 +1,184 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"starland-account/internal/pkg/bizerr"
+	"testing"
+	"time"
+)
+
+// fakeOutboxRepo keeps the outbox in memory. Listing and purging fail while
+// err is set, publishing the events in fail fails and marking fails while
+// markErr is set.
+type fakeOutboxRepo struct {
+	pending   []*OutboxEvent
+	published []uint
+	failed    map[uint]time.Time
+	errs      map[uint]string
+	fail      map[string]bool
+	err       error
+	markErr   error
+	limit     int
+	before    time.Time
+}
+
+func newFakeOutboxRepo(ids ...string) *fakeOutboxRepo {
+	r := &fakeOutboxRepo{failed: make(map[uint]time.Time), errs: make(map[uint]string), fail: make(map[string]bool)}
+	for i, id := range ids {
+		r.pending = append(r.pending, &OutboxEvent{ID: uint(i + 1), Event: &Event{ID: id, Type: EventPointsEarned}, Attempts: i})
+	}
+	return r
+}
+
+func (r *fakeOutboxRepo) ListPendingEvents(_ context.Context, _ time.Time, limit int) ([]*OutboxEvent, error) {
+	r.limit = limit
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(r.pending) > limit {
+		return r.pending[:limit], nil
+	}
+	return r.pending, nil
+}
+
+func (r *fakeOutboxRepo) PublishEvent(_ context.Context, e *Event) error {
+	if r.fail[e.ID] {
+		return errors.New("broker down")
+	}
+	return nil
+}
+
+func (r *fakeOutboxRepo) MarkEventPublished(_ context.Context, id uint) error {
+	if r.markErr != nil {
+		return r.markErr
+	}
+	r.published = append(r.published, id)
+	return nil
+}
+
+func (r *fakeOutboxRepo) MarkEventFailed(_ context.Context, id uint, next time.Time, msg string) error {
+	if r.markErr != nil {
+		return r.markErr
+	}
+	r.failed[id], r.errs[id] = next, msg
+	return nil
+}
+
+func (r *fakeOutboxRepo) PurgePublishedEvents(_ context.Context, before time.Time) (int64, error) {
+	r.before = before
+	return int64(len(r.published)), r.err
+}
+
+// reason is the bizerr reason of err, empty for other errors.
+func reason(err error) string {
+	var be *bizerr.BizError
+	if errors.As(err, &be) {
+		return be.Reason()
+	}
+	return ""
+}
+
+func TestRelayEvents(t *testing.T) {
+	repo := newFakeOutboxRepo("e1", "e2", "e3", "e4")
+	repo.fail["e2"] = true
+	var attempts []int
+	backoff := func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		return time.Minute
+	}
+
+	start := time.Now()
+	n, err := NewOutboxUsecase(repo).RelayEvents(context.Background(), 3, backoff)
+	if err != nil || n != 2 {
+		t.Fatalf("RelayEvents = %d, %v, want 2", n, err)
+	}
+	if repo.limit != 3 {
+		t.Errorf("listed %d, want the limit of 3", repo.limit)
+	}
+	if len(repo.published) != 2 || repo.published[0] != 1 || repo.published[1] != 3 {
+		t.Errorf("published = %v, want [1 3]", repo.published)
+	}
+	// e2 had one attempt before this one
+	if len(attempts) != 1 || attempts[0] != 2 {
+		t.Errorf("backoff attempts = %v, want [2]", attempts)
+	}
+	next, ok := repo.failed[2]
+	if !ok || next.Before(start.Add(time.Minute)) || next.After(time.Now().Add(time.Minute)) {
+		t.Errorf("e2 retried at %v, want a minute from now", next)
+	}
+	if repo.errs[2] != "broker down" {
+		t.Errorf("e2 err = %q, want the publish error", repo.errs[2])
+	}
+}
+
+func TestRelayEventsErrors(t *testing.T) {
+	backoff := func(int) time.Duration { return time.Second }
+	cases := []struct {
+		name    string
+		repo    func() *fakeOutboxRepo
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "list fails",
+			repo: func() *fakeOutboxRepo {
+				r := newFakeOutboxRepo("e1")
+				r.err = errors.New("db down")
+				return r
+			},
+			wantErr: true,
+		},
+		{
+			name: "marking published fails, leaving the event to be redelivered",
+			repo: func() *fakeOutboxRepo {
+				r := newFakeOutboxRepo("e1", "e2")
+				r.markErr = errors.New("db down")
+				return r
+			},
+			wantErr: true,
+		},
+		{
+			name: "marking failed fails",
+			repo: func() *fakeOutboxRepo {
+				r := newFakeOutboxRepo("e1")
+				r.fail["e1"], r.markErr = true, errors.New("db down")
+				return r
+			},
+			wantErr: true,
+		},
+		{
+			name: "nothing due",
+			repo: func() *fakeOutboxRepo { return newFakeOutboxRepo() },
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			n, err := NewOutboxUsecase(c.repo()).RelayEvents(context.Background(), 10, backoff)
+			if n != c.want || (err != nil) != c.wantErr {
+				t.Fatalf("RelayEvents = %d, %v, want %d with error %v", n, err, c.want, c.wantErr)
+			}
+			if err != nil && reason(err) != bizerr.ErrInternalError.Reason() {
+				t.Errorf("err = %v, want an internal error", err)
+			}
+		})
+	}
+}
+
+func TestPurgePublishedEvents(t *testing.T) {
+	repo := newFakeOutboxRepo()
+	repo.published = []uint{1, 2}
+	before := time.Now().AddDate(0, 0, -7)
+	uc := NewOutboxUsecase(repo)
+	if n, err := uc.PurgePublishedEvents(context.Background(), before); err != nil || n != 2 {
+		t.Fatalf("PurgePublishedEvents = %d, %v, want 2", n, err)
+	}
+	if !repo.before.Equal(before) {
+		t.Errorf("purged before %v, want %v", repo.before, before)
+	}
+
+	repo.err = errors.New("db down")
+	if _, err := uc.PurgePublishedEvents(context.Background(), before); reason(err) != bizerr.ErrInternalError.Reason() {
+		t.Errorf("PurgePublishedEvents = %v, want an internal error", err)
+	}
+}
@@ -0,0 +1,88 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+)
+
+// Lease is a term of leadership. Token grows with every term, so a write
+// fenced with an older token can be told apart from the current leader's.
+type Lease struct {
+	Key   string
+	Owner string
+	Token int64
+	TTL   time.Duration // left on the lease when read
+}
+
+// ErrLeaseStale is returned by repos refusing a write done under a lease once
+// a newer term wrote under its key.
+var ErrLeaseStale = errors.New("lease is stale")
+
+type LeaseRepo interface {
+	// AcquireLease takes the lease for owner with a new token unless it is
+	// held, nil then.
+	AcquireLease(ctx context.Context, key, owner string, ttl time.Duration) (*Lease, error)
+	// RenewLease extends the lease while it is still held with its token,
+	// false once it was lost.
+	RenewLease(ctx context.Context, lease *Lease, ttl time.Duration) (bool, error)
+	// ReleaseLease gives the lease up if it is still held with its token.
+	ReleaseLease(ctx context.Context, lease *Lease) error
+	// QueryLease returns the lease held, nil when there is none.
+	QueryLease(ctx context.Context, key string) (*Lease, error)
+}
+
+type leaseContextKey struct{}
+
+// NewLeaseContext returns ctx carrying the lease its work is done under.
+func NewLeaseContext(ctx context.Context, lease *Lease) context.Context {
+	return context.WithValue(ctx, leaseContextKey{}, lease)
+}
+
+// LeaseFromContext returns the lease ctx's work is done under, nil outside
+// of one.
+func LeaseFromContext(ctx context.Context) *Lease {
+	lease, _ := ctx.Value(leaseContextKey{}).(*Lease)
+	return lease
+}
+
+type LeaderUsecase struct {
+	repo LeaseRepo
+}
+
+func NewLeaderUsecase(repo LeaseRepo) *LeaderUsecase {
+	return &LeaderUsecase{repo: repo}
+}
+
+func (uc *LeaderUsecase) AcquireLease(ctx context.Context, key, owner string, ttl time.Duration) (*Lease, error) {
+	lease, err := uc.repo.AcquireLease(ctx, key, owner, ttl)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("AcquireLease: key(%s) err: %w", key, err))
+	}
+	return lease, nil
+}
+
+func (uc *LeaderUsecase) RenewLease(ctx context.Context, lease *Lease, ttl time.Duration) (bool, error) {
+	ok, err := uc.repo.RenewLease(ctx, lease, ttl)
+	if err != nil {
+		return false, bizerr.ErrInternalError.Wrap(fmt.Errorf("RenewLease: key(%s) err: %w", lease.Key, err))
+	}
+	return ok, nil
+}
+
+func (uc *LeaderUsecase) ReleaseLease(ctx context.Context, lease *Lease) error {
+	if err := uc.repo.ReleaseLease(ctx, lease); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("ReleaseLease: key(%s) err: %w", lease.Key, err))
+	}
+	return nil
+}
+
+func (uc *LeaderUsecase) QueryLease(ctx context.Context, key string) (*Lease, error) {
+	lease, err := uc.repo.QueryLease(ctx, key)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryLease: key(%s) err: %w", key, err))
+	}
+	return lease, nil
+}
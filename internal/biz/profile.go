@@ -0,0 +1,94 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+)
+
+const (
+	ProfileFieldName      = "name"
+	ProfileFieldAvatarURL = "avatar_url"
+)
+
+// ProfileRequest carries the fields to change; nil fields are left as is.
+type ProfileRequest struct {
+	AccountID string
+	Name      *string
+	AvatarURL *string
+	Actor     string
+}
+
+type ProfilePolicy struct {
+	UniqueName       bool
+	NameChangeLimit  int
+	NameChangeWindow time.Duration
+}
+
+type ProfileChangeResponse struct {
+	Field    string
+	OldValue string
+	NewValue string
+	Actor    string
+	CreateAt time.Time
+}
+
+type ProfileRepo interface {
+	// UpdateProfile saves the changed fields and records one history entry per
+	// field in the same transaction.
+	UpdateProfile(context.Context, *ProfileRequest) error
+	CountProfileChanges(context.Context, string, string, time.Time) (int64, error)
+	ExistAccountName(context.Context, string, string) (bool, error)
+	QueryProfileChanges(context.Context, string) ([]*ProfileChangeResponse, error)
+}
+
+func (uc *AccountUsecase) UpdateProfile(ctx context.Context, req *ProfileRequest, policy *ProfilePolicy) (*AccountResponse, error) {
+	account, err := uc.QueryAccount(ctx, req.AccountID, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil && *req.Name == account.Name {
+		req.Name = nil
+	}
+	if req.AvatarURL != nil && *req.AvatarURL == account.AvatarURL {
+		req.AvatarURL = nil
+	}
+	if req.Name == nil && req.AvatarURL == nil {
+		return account, nil
+	}
+
+	if req.Name != nil {
+		if policy.NameChangeLimit > 0 {
+			n, err := uc.profile.CountProfileChanges(ctx, req.AccountID, ProfileFieldName, time.Now().Add(-policy.NameChangeWindow))
+			if err != nil {
+				return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("UpdateProfile: count name changes(%s) err: %w", req.AccountID, err))
+			}
+			if int(n) >= policy.NameChangeLimit {
+				return nil, bizerr.ErrNameChangeLimited
+			}
+		}
+		if policy.UniqueName {
+			exist, err := uc.profile.ExistAccountName(ctx, *req.Name, req.AccountID)
+			if err != nil {
+				return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("UpdateProfile: check name(%s) err: %w", *req.Name, err))
+			}
+			if exist {
+				return nil, bizerr.ErrNameTaken
+			}
+		}
+	}
+
+	if err = uc.profile.UpdateProfile(ctx, req); err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("UpdateProfile: save(%s) to db err: %w", req.AccountID, err))
+	}
+	return uc.QueryAccount(ctx, req.AccountID, "", "")
+}
+
+func (uc *AccountUsecase) QueryProfileChanges(ctx context.Context, accountID string) ([]*ProfileChangeResponse, error) {
+	res, err := uc.profile.QueryProfileChanges(ctx, accountID)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryProfileChanges: query(%s) err: %w", accountID, err))
+	}
+	return res, nil
+}
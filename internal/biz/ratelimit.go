@@ -0,0 +1,49 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+)
+
+type RateLimitResult struct {
+	Limit     int
+	Remaining int
+	Reset     time.Duration
+	Allowed   bool
+}
+
+type RateLimitRepo interface {
+	// Take counts one hit against the key in the current window and returns
+	// the hits so far together with the time left in the window.
+	Take(context.Context, string, time.Duration) (int64, time.Duration, error)
+}
+
+type RateLimitUsecase struct {
+	repo RateLimitRepo
+}
+
+func NewRateLimitUsecase(repo RateLimitRepo) *RateLimitUsecase {
+	return &RateLimitUsecase{repo: repo}
+}
+
+func (uc *RateLimitUsecase) Take(ctx context.Context, key string, limit int, window time.Duration) (*RateLimitResult, error) {
+	n, ttl, err := uc.repo.Take(ctx, key, window)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("Take: key(%s) err: %w", key, err))
+	}
+	if ttl < 0 {
+		ttl = window
+	}
+	res := &RateLimitResult{
+		Limit:     limit,
+		Remaining: limit - int(n),
+		Reset:     ttl,
+		Allowed:   int(n) <= limit,
+	}
+	if res.Remaining < 0 {
+		res.Remaining = 0
+	}
+	return res, nil
+}
@@ -0,0 +1,285 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"starland-account/configs"
+	"starland-account/internal/pkg/bizerr"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	RiskActionAllow       = "allow"
+	RiskActionThrottle    = "throttle"
+	RiskActionShadowAward = "shadow_award"
+	RiskActionBlock       = "block"
+)
+
+const (
+	RiskSignalIPRate         = "ip_rate"
+	RiskSignalDeviceRate     = "device_rate"
+	RiskSignalAccountRate    = "account_rate"
+	RiskSignalIPAccounts     = "ip_accounts"
+	RiskSignalDeviceAccounts = "device_accounts"
+	RiskSignalAccountAge     = "account_age"
+	RiskSignalSharedWallet   = "shared_wallet"
+)
+
+const (
+	RiskReviewPending   = 0
+	RiskReviewCleared   = 1
+	RiskReviewConfirmed = 2
+)
+
+const (
+	riskKey         = "starland-account:risk:%s:%s"
+	riskThrottleKey = "starland-account:risk:throttle:%s"
+
+	defaultThrottleCooldown = 60 * time.Second
+)
+
+type RiskRequest struct {
+	AccountID    string
+	ActivityCode int
+	IP           string
+	DeviceID     string
+	SolanaAddr   string
+	AccountAge   time.Duration
+}
+
+type RiskResult struct {
+	Score  int
+	Action string
+	Hits   []string
+}
+
+type RiskReviewRequest struct {
+	AccountID    string
+	ActivityCode int
+	IP           string
+	DeviceID     string
+	Score        int
+	Action       string
+	Hits         string
+}
+
+// ResolveRiskReviewRequest closes a review; Ban, when set, is the ban a
+// confirmation makes.
+type ResolveRiskReviewRequest struct {
+	ID    uint
+	State int
+	Actor string
+	Ban   *AccountStateRequest
+}
+
+type RiskReviewResponse struct {
+	ID           uint
+	AccountID    string
+	ActivityCode int
+	IP           string
+	DeviceID     string
+	Score        int
+	Action       string
+	Hits         string
+	State        int
+	Actor        string
+	CreateAt     time.Time
+	UpdateAt     time.Time
+}
+
+type RiskRepo interface {
+	// IncrWindow increments a counter that resets after the window and returns
+	// its new value.
+	IncrWindow(context.Context, string, time.Duration) (int64, error)
+	// AddWindowSet adds a member to a set that expires after the window and
+	// returns the set size.
+	AddWindowSet(context.Context, string, string, time.Duration) (int64, error)
+	CountAccountsBySolanaAddr(context.Context, string) (int64, error)
+	AddRiskReview(context.Context, *RiskReviewRequest) error
+	QueryRiskReview(context.Context, uint) (*RiskReviewResponse, error)
+	QueryRiskReviews(context.Context, int) ([]*RiskReviewResponse, error)
+	// ResolveRiskReview closes the review if it is still pending, false
+	// otherwise, and bans the account in the same transaction. An account
+	// already banned or erased is left as it is.
+	ResolveRiskReview(context.Context, *ResolveRiskReviewRequest) (bool, error)
+}
+
+type RiskUsecase struct {
+	// rules is swapped whole when the config file changes, so an evaluation
+	// sees one version of the rules throughout
+	rules atomic.Pointer[configs.RiskConfig]
+	repo  RiskRepo
+}
+
+func NewRiskUsecase(cfg *configs.Config, repo RiskRepo) *RiskUsecase {
+	uc := &RiskUsecase{repo: repo}
+	uc.rules.Store(cfg.Risk)
+	configs.OnChange(func(c *configs.Config) { uc.rules.Store(c.Risk) })
+	return uc
+}
+
+// Evaluate scores a play against the configured rules. Signal errors are
+// logged and skipped so an unavailable store never blocks plays.
+func (uc *RiskUsecase) Evaluate(ctx context.Context, req *RiskRequest) *RiskResult {
+	res := &RiskResult{Action: RiskActionAllow}
+	rc := uc.rules.Load()
+	if rc == nil || !rc.Enable {
+		return res
+	}
+
+	for _, rule := range rc.Rules {
+		hit, err := uc.matchRule(ctx, &rule, req)
+		if err != nil {
+			zap.S().Errorf("Evaluate: rule(%s) err: %v", rule.Name, err)
+			continue
+		}
+		if hit {
+			res.Score += rule.Score
+			res.Hits = append(res.Hits, rule.Name)
+		}
+	}
+
+	actions := append([]configs.RiskActionConfig(nil), rc.Actions...)
+	sort.Slice(actions, func(i, j int) bool { return actions[i].Score > actions[j].Score })
+	for _, a := range actions {
+		if res.Score >= a.Score {
+			res.Action = a.Action
+			break
+		}
+	}
+
+	if rc.ReviewScore > 0 && res.Score >= rc.ReviewScore {
+		err := uc.repo.AddRiskReview(ctx, &RiskReviewRequest{
+			AccountID:    req.AccountID,
+			ActivityCode: req.ActivityCode,
+			IP:           req.IP,
+			DeviceID:     req.DeviceID,
+			Score:        res.Score,
+			Action:       res.Action,
+			Hits:         strings.Join(res.Hits, ","),
+		})
+		if err != nil {
+			zap.S().Errorf("Evaluate: add review(%s) err: %v", req.AccountID, err)
+		}
+	}
+	return res
+}
+
+// Throttle slows a throttled account down to one play per cooldown. It
+// returns false while the account is cooling down; like the signals, an
+// unavailable store lets the play through.
+func (uc *RiskUsecase) Throttle(ctx context.Context, accountID string) bool {
+	cooldown := defaultThrottleCooldown
+	if rc := uc.rules.Load(); rc != nil && rc.ThrottleCooldown > 0 {
+		cooldown = rc.ThrottleCooldown * time.Second
+	}
+	n, err := uc.repo.IncrWindow(ctx, fmt.Sprintf(riskThrottleKey, accountID), cooldown)
+	if err != nil {
+		zap.S().Errorf("Throttle: account(%s) err: %v", accountID, err)
+		return true
+	}
+	return n <= 1
+}
+
+func (uc *RiskUsecase) matchRule(ctx context.Context, rule *configs.RiskRuleConfig, req *RiskRequest) (bool, error) {
+	window := rule.Window * time.Second
+	var (
+		n   int64
+		err error
+	)
+	switch rule.Signal {
+	case RiskSignalIPRate:
+		if req.IP == "" {
+			return false, nil
+		}
+		n, err = uc.repo.IncrWindow(ctx, fmt.Sprintf(riskKey, rule.Name, req.IP), window)
+	case RiskSignalDeviceRate:
+		if req.DeviceID == "" {
+			return false, nil
+		}
+		n, err = uc.repo.IncrWindow(ctx, fmt.Sprintf(riskKey, rule.Name, req.DeviceID), window)
+	case RiskSignalAccountRate:
+		n, err = uc.repo.IncrWindow(ctx, fmt.Sprintf(riskKey, rule.Name, req.AccountID), window)
+	case RiskSignalIPAccounts:
+		if req.IP == "" {
+			return false, nil
+		}
+		n, err = uc.repo.AddWindowSet(ctx, fmt.Sprintf(riskKey, rule.Name, req.IP), req.AccountID, window)
+	case RiskSignalDeviceAccounts:
+		if req.DeviceID == "" {
+			return false, nil
+		}
+		n, err = uc.repo.AddWindowSet(ctx, fmt.Sprintf(riskKey, rule.Name, req.DeviceID), req.AccountID, window)
+	case RiskSignalAccountAge:
+		return req.AccountAge < window, nil
+	case RiskSignalSharedWallet:
+		if req.SolanaAddr == "" {
+			return false, nil
+		}
+		n, err = uc.repo.CountAccountsBySolanaAddr(ctx, req.SolanaAddr)
+		// the account itself is always one of them
+		n--
+	default:
+		return false, fmt.Errorf("unknown signal %q", rule.Signal)
+	}
+	if err != nil {
+		return false, err
+	}
+	return n > rule.Threshold, nil
+}
+
+func (uc *RiskUsecase) QueryRiskReview(ctx context.Context, id uint) (*RiskReviewResponse, error) {
+	res, err := uc.repo.QueryRiskReview(ctx, id)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryRiskReview: query(%d) err: %w", id, err))
+	}
+	if res == nil {
+		return nil, bizerr.ErrRiskReviewNotExist
+	}
+	return res, nil
+}
+
+func (uc *RiskUsecase) QueryRiskReviews(ctx context.Context, state int) ([]*RiskReviewResponse, error) {
+	res, err := uc.repo.QueryRiskReviews(ctx, state)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryRiskReviews: query(%d) err: %w", state, err))
+	}
+	return res, nil
+}
+
+// ResolveRiskReview clears a pending review, or confirms it and bans the
+// account.
+func (uc *RiskUsecase) ResolveRiskReview(ctx context.Context, id uint, ban bool, actor string) error {
+	review, err := uc.QueryRiskReview(ctx, id)
+	if err != nil {
+		return err
+	}
+	if review.State != RiskReviewPending {
+		return bizerr.ErrRiskReviewResolved
+	}
+
+	req := &ResolveRiskReviewRequest{ID: id, State: RiskReviewCleared, Actor: actor}
+	if ban {
+		req.State = RiskReviewConfirmed
+		req.Ban = &AccountStateRequest{
+			AccountID: review.AccountID,
+			State:     AccountStateBanned,
+			Reason:    fmt.Sprintf("risk review %d: %s", review.ID, review.Hits),
+			Actor:     actor,
+			From:      []int{AccountStateActive, AccountStateSuspended},
+		}
+	}
+	ok, err := uc.repo.ResolveRiskReview(ctx, req)
+	if err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("ResolveRiskReview: save(%d) err: %w", id, err))
+	}
+	if !ok {
+		return bizerr.ErrRiskReviewResolved
+	}
+	return nil
+}
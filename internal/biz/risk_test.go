@@ -0,0 +1,189 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"starland-account/configs"
+	"testing"
+	"time"
+)
+
+// fakeRiskRepo counts the windows in memory; signals fail while err is set.
+type fakeRiskRepo struct {
+	counts  map[string]int64
+	sets    map[string]map[string]bool
+	wallets int64
+	reviews []*RiskReviewRequest
+	err     error
+}
+
+func newFakeRiskRepo() *fakeRiskRepo {
+	return &fakeRiskRepo{counts: make(map[string]int64), sets: make(map[string]map[string]bool)}
+}
+
+func (r *fakeRiskRepo) IncrWindow(_ context.Context, key string, _ time.Duration) (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	r.counts[key]++
+	return r.counts[key], nil
+}
+
+func (r *fakeRiskRepo) AddWindowSet(_ context.Context, key, member string, _ time.Duration) (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.sets[key] == nil {
+		r.sets[key] = make(map[string]bool)
+	}
+	r.sets[key][member] = true
+	return int64(len(r.sets[key])), nil
+}
+
+func (r *fakeRiskRepo) CountAccountsBySolanaAddr(context.Context, string) (int64, error) {
+	return r.wallets, r.err
+}
+
+func (r *fakeRiskRepo) AddRiskReview(_ context.Context, req *RiskReviewRequest) error {
+	r.reviews = append(r.reviews, req)
+	return nil
+}
+
+func (r *fakeRiskRepo) QueryRiskReview(context.Context, uint) (*RiskReviewResponse, error) {
+	return nil, nil
+}
+
+func (r *fakeRiskRepo) QueryRiskReviews(context.Context, int) ([]*RiskReviewResponse, error) {
+	return nil, nil
+}
+
+func (r *fakeRiskRepo) ResolveRiskReview(context.Context, *ResolveRiskReviewRequest) (bool, error) {
+	return false, nil
+}
+
+func testRiskConfig() *configs.RiskConfig {
+	return &configs.RiskConfig{
+		Enable:      true,
+		ReviewScore: 60,
+		// unsorted on purpose, the highest threshold reached wins
+		Actions: []configs.RiskActionConfig{
+			{Score: 40, Action: RiskActionThrottle},
+			{Score: 100, Action: RiskActionBlock},
+			{Score: 70, Action: RiskActionShadowAward},
+		},
+		Rules: []configs.RiskRuleConfig{
+			{Name: "account_burst", Signal: RiskSignalAccountRate, Window: 60, Threshold: 1, Score: 30},
+			{Name: "new_account", Signal: RiskSignalAccountAge, Window: 3600, Score: 40},
+			{Name: "shared_wallet", Signal: RiskSignalSharedWallet, Threshold: 0, Score: 40},
+		},
+	}
+}
+
+func TestRiskEvaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		plays      int
+		age        time.Duration
+		wallet     string
+		wallets    int64
+		repoErr    error
+		wantScore  int
+		wantAction string
+		wantHits   int
+		wantReview bool
+	}{
+		{name: "clean play", plays: 1, age: 24 * time.Hour, wantAction: RiskActionAllow},
+		{name: "below every action", plays: 2, age: 24 * time.Hour, wantScore: 30, wantAction: RiskActionAllow, wantHits: 1},
+		{name: "throttle", plays: 1, age: time.Minute, wantScore: 40, wantAction: RiskActionThrottle, wantHits: 1},
+		{
+			name: "scores add up to shadow award", plays: 2, age: time.Minute,
+			wantScore: 70, wantAction: RiskActionShadowAward, wantHits: 2, wantReview: true,
+		},
+		{
+			name: "every rule blocks", plays: 2, age: time.Minute, wallet: "w1", wallets: 2,
+			wantScore: 110, wantAction: RiskActionBlock, wantHits: 3, wantReview: true,
+		},
+		{name: "own wallet only", plays: 1, age: 24 * time.Hour, wallet: "w1", wallets: 1, wantAction: RiskActionAllow},
+		{
+			name: "store down skips the signals", plays: 2, age: time.Minute, repoErr: errors.New("redis down"),
+			wantScore: 40, wantAction: RiskActionThrottle, wantHits: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeRiskRepo()
+			repo.wallets = tt.wallets
+			uc := NewRiskUsecase(&configs.Config{Risk: testRiskConfig()}, repo)
+			req := &RiskRequest{AccountID: "a1", ActivityCode: 1, SolanaAddr: tt.wallet, AccountAge: tt.age}
+
+			var res *RiskResult
+			for i := 0; i < tt.plays; i++ {
+				if i == tt.plays-1 {
+					repo.err, repo.reviews = tt.repoErr, nil
+				}
+				res = uc.Evaluate(context.Background(), req)
+			}
+			if res.Score != tt.wantScore || res.Action != tt.wantAction || len(res.Hits) != tt.wantHits {
+				t.Errorf("Evaluate = %d %s %v, want %d %s with %d hits",
+					res.Score, res.Action, res.Hits, tt.wantScore, tt.wantAction, tt.wantHits)
+			}
+			if got := len(repo.reviews) > 0; got != tt.wantReview {
+				t.Errorf("review enqueued = %v, want %v", got, tt.wantReview)
+			}
+			if tt.wantReview && (repo.reviews[0].Score != tt.wantScore || repo.reviews[0].Action != tt.wantAction) {
+				t.Errorf("review = %+v, want score %d action %s", repo.reviews[0], tt.wantScore, tt.wantAction)
+			}
+		})
+	}
+}
+
+func TestRiskEvaluateDisabled(t *testing.T) {
+	rc := testRiskConfig()
+	rc.Enable = false
+	uc := NewRiskUsecase(&configs.Config{Risk: rc}, newFakeRiskRepo())
+	if res := uc.Evaluate(context.Background(), &RiskRequest{AccountID: "a1"}); res.Action != RiskActionAllow || res.Score != 0 {
+		t.Errorf("Evaluate with risk disabled = %+v, want allow", res)
+	}
+}
+
+func TestRiskConfigReload(t *testing.T) {
+	repo := newFakeRiskRepo()
+	uc := NewRiskUsecase(&configs.Config{Risk: testRiskConfig()}, repo)
+	req := &RiskRequest{AccountID: "a1", AccountAge: time.Minute}
+	if res := uc.Evaluate(context.Background(), req); res.Action != RiskActionThrottle {
+		t.Fatalf("Evaluate = %s, want throttle", res.Action)
+	}
+
+	// the new rules score new accounts high enough to block
+	rc := testRiskConfig()
+	rc.Rules[1].Score = 100
+	configs.Replace(&configs.Config{Risk: rc})
+	if res := uc.Evaluate(context.Background(), req); res.Action != RiskActionBlock {
+		t.Errorf("Evaluate after the reload = %s, want block", res.Action)
+	}
+
+	configs.Replace(&configs.Config{})
+	if res := uc.Evaluate(context.Background(), req); res.Action != RiskActionAllow {
+		t.Errorf("Evaluate with the risk config removed = %s, want allow", res.Action)
+	}
+}
+
+func TestRiskThrottle(t *testing.T) {
+	repo := newFakeRiskRepo()
+	uc := NewRiskUsecase(&configs.Config{Risk: testRiskConfig()}, repo)
+	ctx := context.Background()
+
+	if !uc.Throttle(ctx, "a1") {
+		t.Fatal("first throttled play refused, want one per cooldown")
+	}
+	if uc.Throttle(ctx, "a1") {
+		t.Error("second play within the cooldown allowed")
+	}
+	if !uc.Throttle(ctx, "a2") {
+		t.Error("another account is cooling down too")
+	}
+	repo.err = errors.New("redis down")
+	if !uc.Throttle(ctx, "a1") {
+		t.Error("Throttle refused with the store down, want the play let through")
+	}
+}
@@ -0,0 +1,108 @@
+package biz
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"time"
+)
+
+const (
+	VerifyPurposeEmail     = "email"
+	VerifyPurposeMagicLink = "magic_link"
+
+	// EmailProvider is the provider of accounts created by magic-link login.
+	EmailProvider = "email"
+)
+
+// VerifyCode is a pending verification. Subject is the account id for email
+// verification and the token hash for magic links.
+type VerifyCode struct {
+	Purpose   string
+	Subject   string
+	Code      string
+	AccountID string
+	Email     string
+	Attempts  int64
+}
+
+type VerifyPolicy struct {
+	CodeTTL        time.Duration
+	ResendInterval time.Duration
+	MaxAttempts    int
+}
+
+type VerifyRepo interface {
+	SaveVerifyCode(context.Context, *VerifyCode, time.Duration) error
+	// QueryVerifyCode returns nil when the code doesn't exist or has expired.
+	QueryVerifyCode(context.Context, string, string) (*VerifyCode, error)
+	IncrVerifyAttempts(context.Context, string, string) (int64, error)
+	DeleteVerifyCode(context.Context, string, string) error
+	// DeleteAccountVerifyCodes drops the account's pending codes of every
+	// purpose.
+	DeleteAccountVerifyCodes(context.Context, string) error
+	// AcquireResend reports whether a code may be sent to the address, and
+	// blocks further sends for the interval when it may.
+	AcquireResend(context.Context, string, time.Duration) (bool, error)
+	MarkEmailVerified(context.Context, string, string) error
+}
+
+// CreateVerifyCode stores the code for later checking, rejecting resends to
+// the same address within the policy's interval.
+func (uc *AccountUsecase) CreateVerifyCode(ctx context.Context, code *VerifyCode, policy *VerifyPolicy) error {
+	if code.Email == "" {
+		return bizerr.ErrEmailMissing
+	}
+	if policy.ResendInterval > 0 {
+		ok, err := uc.verify.AcquireResend(ctx, code.Email, policy.ResendInterval)
+		if err != nil {
+			return bizerr.ErrInternalError.Wrap(fmt.Errorf("CreateVerifyCode: acquire resend(%s) err: %w", code.Email, err))
+		}
+		if !ok {
+			return bizerr.ErrVerifyResendLimited
+		}
+	}
+	if err := uc.verify.SaveVerifyCode(ctx, code, policy.CodeTTL); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("CreateVerifyCode: save(%s:%s) err: %w", code.Purpose, code.AccountID, err))
+	}
+	return nil
+}
+
+// CheckVerifyCode consumes the code when it matches. Wrong codes count
+// against the policy's attempt limit, after which the code is dropped.
+func (uc *AccountUsecase) CheckVerifyCode(ctx context.Context, purpose, subject, code string, policy *VerifyPolicy) (*VerifyCode, error) {
+	vc, err := uc.verify.QueryVerifyCode(ctx, purpose, subject)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("CheckVerifyCode: query(%s) err: %w", purpose, err))
+	}
+	if vc == nil {
+		return nil, bizerr.ErrVerificationCodeFailed
+	}
+	if policy.MaxAttempts > 0 && vc.Attempts >= int64(policy.MaxAttempts) {
+		_ = uc.verify.DeleteVerifyCode(ctx, purpose, subject)
+		return nil, bizerr.ErrVerifyAttemptsExceeded
+	}
+	if subtle.ConstantTimeCompare([]byte(vc.Code), []byte(code)) != 1 {
+		n, err := uc.verify.IncrVerifyAttempts(ctx, purpose, subject)
+		if err != nil {
+			return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("CheckVerifyCode: incr attempts(%s) err: %w", purpose, err))
+		}
+		if policy.MaxAttempts > 0 && n >= int64(policy.MaxAttempts) {
+			_ = uc.verify.DeleteVerifyCode(ctx, purpose, subject)
+			return nil, bizerr.ErrVerifyAttemptsExceeded
+		}
+		return nil, bizerr.ErrVerificationCodeFailed
+	}
+	if err = uc.verify.DeleteVerifyCode(ctx, purpose, subject); err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("CheckVerifyCode: delete(%s) err: %w", purpose, err))
+	}
+	return vc, nil
+}
+
+func (uc *AccountUsecase) MarkEmailVerified(ctx context.Context, accountID, email string) error {
+	if err := uc.verify.MarkEmailVerified(ctx, accountID, email); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("MarkEmailVerified: save(%s) err: %w", accountID, err))
+	}
+	return nil
+}
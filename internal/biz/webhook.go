@@ -0,0 +1,322 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"strings"
+	"time"
+)
+
+const (
+	WebhookDeliveryPending   = 0
+	WebhookDeliverySucceeded = 1
+	// WebhookDeliveryDead deliveries ran out of attempts; they are only sent
+	// again when replayed.
+	WebhookDeliveryDead = 2
+
+	// WebhookAllEvents subscribes to every event type.
+	WebhookAllEvents = "*"
+)
+
+type WebhookSubscription struct {
+	SubscriptionID      string
+	URL                 string
+	Secret              string
+	Events              []string
+	Description         string
+	Enabled             bool
+	ConsecutiveFailures int
+	DisabledReason      string
+	CreateAt            time.Time
+}
+
+// Matches reports whether the subscription wants events of the type.
+func (s *WebhookSubscription) Matches(eventType string) bool {
+	for _, e := range s.Events {
+		if e == WebhookAllEvents || e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDelivery struct {
+	ID             uint
+	DeliveryID     string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        string
+	State          int
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatus     int
+	LastErr        string
+	DeliveredAt    *time.Time
+	CreateAt       time.Time
+}
+
+type WebhookAttempt struct {
+	DeliveryID string
+	Attempt    int
+	StatusCode int
+	Err        string
+	Duration   time.Duration
+	CreateAt   time.Time
+}
+
+// WebhookResult is the outcome of one delivery attempt; a 2xx status with no
+// Err is a success.
+type WebhookResult struct {
+	StatusCode int
+	Err        string
+	Duration   time.Duration
+}
+
+func (r *WebhookResult) OK() bool {
+	return r.Err == "" && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+type WebhookPolicy struct {
+	MaxAttempts  int
+	MaxBackoff   time.Duration
+	DisableAfter int
+}
+
+// StreamEvent is an event read from the event stream by a consumer group.
+type StreamEvent struct {
+	MessageID string
+	Event     *Event
+}
+
+type WebhookRepo interface {
+	SaveSubscription(context.Context, *WebhookSubscription) error
+	QuerySubscription(context.Context, string) (*WebhookSubscription, error)
+	QuerySubscriptions(context.Context, bool) ([]*WebhookSubscription, error)
+	SetSubscriptionEnabled(context.Context, string, bool, string) error
+	DeleteSubscription(context.Context, string) error
+	// AddDeliveries skips deliveries that already exist for the same
+	// subscription and event, so redelivered events are enqueued once.
+	AddDeliveries(context.Context, []*WebhookDelivery) error
+	ListDueDeliveries(context.Context, time.Time, int) ([]*WebhookDelivery, error)
+	// ClaimDelivery moves a due delivery's next attempt to the lease time and
+	// reports whether this caller won it, so replicas don't send it twice.
+	ClaimDelivery(context.Context, uint, time.Time, time.Time) (bool, error)
+	// SaveDeliveryResult stores the attempt, the delivery's new state and
+	// the subscription's failure streak together.
+	SaveDeliveryResult(context.Context, *WebhookDelivery, *WebhookAttempt, bool, int) error
+	QueryDelivery(context.Context, string) (*WebhookDelivery, error)
+	QueryDeliveries(context.Context, string, int) ([]*WebhookDelivery, error)
+	QueryAttempts(context.Context, string) ([]*WebhookAttempt, error)
+	ResetDelivery(context.Context, string) error
+	ReadStreamEvents(context.Context, string, string, string, int, time.Duration) ([]*StreamEvent, error)
+	// ClaimStreamEvents takes over the group's events left unacknowledged by
+	// other consumers for at least the idle time, such as the ones of an
+	// instance that went away.
+	ClaimStreamEvents(context.Context, string, string, time.Duration, int) ([]*StreamEvent, error)
+	AckStreamEvents(context.Context, string, ...string) error
+}
+
+type WebhookUsecase struct {
+	repo WebhookRepo
+}
+
+func NewWebhookUsecase(repo WebhookRepo) *WebhookUsecase {
+	return &WebhookUsecase{repo: repo}
+}
+
+func (uc *WebhookUsecase) CreateSubscription(ctx context.Context, sub *WebhookSubscription) error {
+	sub.Enabled = true
+	if err := uc.repo.SaveSubscription(ctx, sub); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("CreateSubscription: save(%s) err: %w", sub.URL, err))
+	}
+	return nil
+}
+
+func (uc *WebhookUsecase) QuerySubscription(ctx context.Context, id string) (*WebhookSubscription, error) {
+	sub, err := uc.repo.QuerySubscription(ctx, id)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QuerySubscription: query(%s) err: %w", id, err))
+	}
+	if sub == nil {
+		return nil, bizerr.ErrWebhookNotExist
+	}
+	return sub, nil
+}
+
+func (uc *WebhookUsecase) QuerySubscriptions(ctx context.Context) ([]*WebhookSubscription, error) {
+	res, err := uc.repo.QuerySubscriptions(ctx, false)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QuerySubscriptions: query err: %w", err))
+	}
+	return res, nil
+}
+
+// SetSubscriptionEnabled re-enabling a subscription also clears its failure
+// streak.
+func (uc *WebhookUsecase) SetSubscriptionEnabled(ctx context.Context, id string, enabled bool, reason string) error {
+	if _, err := uc.QuerySubscription(ctx, id); err != nil {
+		return err
+	}
+	if err := uc.repo.SetSubscriptionEnabled(ctx, id, enabled, reason); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("SetSubscriptionEnabled: save(%s) err: %w", id, err))
+	}
+	return nil
+}
+
+func (uc *WebhookUsecase) DeleteSubscription(ctx context.Context, id string) error {
+	if _, err := uc.QuerySubscription(ctx, id); err != nil {
+		return err
+	}
+	if err := uc.repo.DeleteSubscription(ctx, id); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("DeleteSubscription: delete(%s) err: %w", id, err))
+	}
+	return nil
+}
+
+// EnqueueDeliveries creates a pending delivery of the event for every enabled
+// subscription that wants it. newID generates delivery ids.
+func (uc *WebhookUsecase) EnqueueDeliveries(ctx context.Context, e *Event, payload string, newID func() string) (int, error) {
+	subs, err := uc.repo.QuerySubscriptions(ctx, true)
+	if err != nil {
+		return 0, bizerr.ErrInternalError.Wrap(fmt.Errorf("EnqueueDeliveries: query subscriptions err: %w", err))
+	}
+	now := time.Now()
+	deliveries := make([]*WebhookDelivery, 0, len(subs))
+	for _, sub := range subs {
+		if !sub.Matches(e.Type) {
+			continue
+		}
+		deliveries = append(deliveries, &WebhookDelivery{
+			DeliveryID:     newID(),
+			SubscriptionID: sub.SubscriptionID,
+			EventID:        e.ID,
+			EventType:      e.Type,
+			Payload:        payload,
+			State:          WebhookDeliveryPending,
+			NextAttemptAt:  now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+	if err = uc.repo.AddDeliveries(ctx, deliveries); err != nil {
+		return 0, bizerr.ErrInternalError.Wrap(fmt.Errorf("EnqueueDeliveries: save(%s) err: %w", e.ID, err))
+	}
+	return len(deliveries), nil
+}
+
+func (uc *WebhookUsecase) ListDueDeliveries(ctx context.Context, limit int) ([]*WebhookDelivery, error) {
+	res, err := uc.repo.ListDueDeliveries(ctx, time.Now(), limit)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("ListDueDeliveries: query err: %w", err))
+	}
+	return res, nil
+}
+
+func (uc *WebhookUsecase) ClaimDelivery(ctx context.Context, d *WebhookDelivery, lease time.Duration) (bool, error) {
+	ok, err := uc.repo.ClaimDelivery(ctx, d.ID, d.NextAttemptAt, time.Now().Add(lease))
+	if err != nil {
+		return false, bizerr.ErrInternalError.Wrap(fmt.Errorf("ClaimDelivery: claim(%s) err: %w", d.DeliveryID, err))
+	}
+	return ok, nil
+}
+
+// RecordResult applies an attempt's outcome: success completes the delivery,
+// failure schedules a retry with exponential backoff, or gives up after
+// MaxAttempts. The subscription is disabled once DisableAfter attempts in a
+// row have failed.
+func (uc *WebhookUsecase) RecordResult(ctx context.Context, d *WebhookDelivery, res *WebhookResult, policy *WebhookPolicy) error {
+	now := time.Now()
+	d.Attempts++
+	d.LastStatus, d.LastErr = res.StatusCode, res.Err
+	ok := res.OK()
+	switch {
+	case ok:
+		d.State = WebhookDeliverySucceeded
+		d.DeliveredAt = &now
+	case d.Attempts >= policy.MaxAttempts:
+		d.State = WebhookDeliveryDead
+	default:
+		d.NextAttemptAt = now.Add(webhookBackoff(d.Attempts, policy.MaxBackoff))
+	}
+	attempt := &WebhookAttempt{
+		DeliveryID: d.DeliveryID,
+		Attempt:    d.Attempts,
+		StatusCode: res.StatusCode,
+		Err:        res.Err,
+		Duration:   res.Duration,
+	}
+	if err := uc.repo.SaveDeliveryResult(ctx, d, attempt, ok, policy.DisableAfter); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("RecordResult: save(%s) err: %w", d.DeliveryID, err))
+	}
+	return nil
+}
+
+func (uc *WebhookUsecase) QueryDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*WebhookDelivery, error) {
+	res, err := uc.repo.QueryDeliveries(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryDeliveries: query(%s) err: %w", subscriptionID, err))
+	}
+	return res, nil
+}
+
+func (uc *WebhookUsecase) QueryAttempts(ctx context.Context, deliveryID string) ([]*WebhookAttempt, error) {
+	res, err := uc.repo.QueryAttempts(ctx, deliveryID)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("QueryAttempts: query(%s) err: %w", deliveryID, err))
+	}
+	return res, nil
+}
+
+// ReplayDelivery sends a delivery again from its first attempt, whatever its
+// state. Past attempts are kept.
+func (uc *WebhookUsecase) ReplayDelivery(ctx context.Context, deliveryID string) error {
+	d, err := uc.repo.QueryDelivery(ctx, deliveryID)
+	if err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("ReplayDelivery: query(%s) err: %w", deliveryID, err))
+	}
+	if d == nil {
+		return bizerr.ErrWebhookDeliveryNotExist
+	}
+	if err = uc.repo.ResetDelivery(ctx, deliveryID); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("ReplayDelivery: reset(%s) err: %w", deliveryID, err))
+	}
+	return nil
+}
+
+func (uc *WebhookUsecase) ReadStreamEvents(ctx context.Context, group, consumer, start string, count int, block time.Duration) ([]*StreamEvent, error) {
+	res, err := uc.repo.ReadStreamEvents(ctx, group, consumer, start, count, block)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("ReadStreamEvents: read(%s) err: %w", group, err))
+	}
+	return res, nil
+}
+
+func (uc *WebhookUsecase) ClaimStreamEvents(ctx context.Context, group, consumer string, minIdle time.Duration, count int) ([]*StreamEvent, error) {
+	res, err := uc.repo.ClaimStreamEvents(ctx, group, consumer, minIdle, count)
+	if err != nil {
+		return nil, bizerr.ErrInternalError.Wrap(fmt.Errorf("ClaimStreamEvents: claim(%s) err: %w", group, err))
+	}
+	return res, nil
+}
+
+func (uc *WebhookUsecase) AckStreamEvents(ctx context.Context, group string, ids ...string) error {
+	if err := uc.repo.AckStreamEvents(ctx, group, ids...); err != nil {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("AckStreamEvents: ack(%s) err: %w", strings.Join(ids, ","), err))
+	}
+	return nil
+}
+
+// webhookBackoff doubles from 30 seconds per attempt up to max.
+func webhookBackoff(attempt int, max time.Duration) time.Duration {
+	d := 30 * time.Second
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
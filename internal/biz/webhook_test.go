@@ -0,0 +1,79 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeWebhookRepo records the results saved; the other methods are not used.
+type fakeWebhookRepo struct {
+	WebhookRepo
+	attempts []*WebhookAttempt
+	oks      []bool
+}
+
+func (r *fakeWebhookRepo) SaveDeliveryResult(_ context.Context, _ *WebhookDelivery, a *WebhookAttempt, ok bool, _ int) error {
+	r.attempts = append(r.attempts, a)
+	r.oks = append(r.oks, ok)
+	return nil
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	cases := []struct {
+		attempt int
+		max     time.Duration
+		want    time.Duration
+	}{
+		{1, time.Hour, 30 * time.Second},
+		{2, time.Hour, time.Minute},
+		{4, time.Hour, 4 * time.Minute},
+		{8, time.Hour, time.Hour},
+		{100, time.Hour, time.Hour},
+		{1, 10 * time.Second, 10 * time.Second},
+	}
+	for _, c := range cases {
+		if got := webhookBackoff(c.attempt, c.max); got != c.want {
+			t.Errorf("webhookBackoff(%d, %v) = %v, want %v", c.attempt, c.max, got, c.want)
+		}
+	}
+}
+
+func TestRecordResult(t *testing.T) {
+	policy := &WebhookPolicy{MaxAttempts: 3, MaxBackoff: time.Hour, DisableAfter: 10}
+	repo := &fakeWebhookRepo{}
+	uc := NewWebhookUsecase(repo)
+	d := &WebhookDelivery{DeliveryID: "d1", State: WebhookDeliveryPending}
+	failed := &WebhookResult{StatusCode: 500, Err: "status 500"}
+
+	start := time.Now()
+	if err := uc.RecordResult(context.Background(), d, failed, policy); err != nil {
+		t.Fatalf("RecordResult: %v", err)
+	}
+	if d.State != WebhookDeliveryPending || d.Attempts != 1 || d.NextAttemptAt.Before(start.Add(30*time.Second)) {
+		t.Errorf("after a failure = %+v, want a retry in 30s", d)
+	}
+	if err := uc.RecordResult(context.Background(), d, failed, policy); err != nil {
+		t.Fatalf("RecordResult: %v", err)
+	}
+	if d.NextAttemptAt.Before(start.Add(time.Minute)) {
+		t.Errorf("second retry at %v, want a minute out", d.NextAttemptAt)
+	}
+	if err := uc.RecordResult(context.Background(), d, failed, policy); err != nil {
+		t.Fatalf("RecordResult: %v", err)
+	}
+	if d.State != WebhookDeliveryDead || d.LastStatus != 500 || d.LastErr != "status 500" {
+		t.Errorf("after MaxAttempts = %+v, want dead", d)
+	}
+
+	d = &WebhookDelivery{DeliveryID: "d2", State: WebhookDeliveryPending}
+	if err := uc.RecordResult(context.Background(), d, &WebhookResult{StatusCode: 204}, policy); err != nil {
+		t.Fatalf("RecordResult: %v", err)
+	}
+	if d.State != WebhookDeliverySucceeded || d.DeliveredAt == nil {
+		t.Errorf("after a success = %+v, want succeeded", d)
+	}
+	if len(repo.attempts) != 4 || repo.attempts[2].Attempt != 3 || !repo.oks[3] || repo.oks[0] {
+		t.Errorf("saved attempts %v, oks %v", repo.attempts, repo.oks)
+	}
+}
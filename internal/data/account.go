@@ -5,24 +5,50 @@ import (
 	"errors"
 	"starland-account/configs"
 	"starland-account/internal/biz"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// Account spells out gorm.Model so created_at can be indexed for the admin
+// search.
 type Account struct {
+	ID            uint      `gorm:"primarykey"`
+	CreatedAt     time.Time `gorm:"index"`
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	TenantID      string         `gorm:"size:64;default:'default'"` // unique with AccountID by migration 2
+	AccountID     string         `json:"account_id" gorm:"primary_key;index;size:255"`
+	Integral      int            `gorm:"index"`
+	Received      int            `gorm:"index"`
+	Email         string         `gorm:"index:idx_member"`
+	Name          string         `gorm:"index;size:255"`
+	Provider      string         `gorm:"index:idx_member"`
+	AvatarURL     string
+	State         int `gorm:"index"`
+	StateReason   string
+	StateActor    string
+	StateExpireAt *time.Time
+	SolanaAddr    string `gorm:"index;size:64"`
+	ClaimCount    int
+	// EmailVerifiedAt is set once the owner proves control of Email.
+	EmailVerifiedAt *time.Time
+}
+
+// ClaimLog records every confirmed points claim.
+type ClaimLog struct {
 	gorm.Model
-	AccountID  string `json:"account_id" gorm:"primary_key;size:255"`
-	Integral   int
+	TenantID   string `gorm:"index;size:64;default:'default'"`
+	AccountID  string `gorm:"index;size:255"`
+	Points     int
 	Received   int
-	Email      string `gorm:"index:idx_member"`
-	Name       string
-	Provider   string `gorm:"index:idx_member"`
-	AvatarURL  string
-	State      int
 	SolanaAddr string
 	ClaimCount int
 }
 
+const deletedAccountName = "deleted user"
+
 type accountRepo struct {
 	cfg  *configs.Config
 	data *Data
@@ -36,10 +62,17 @@ func NewAccountRepo(c *configs.Config, data *Data) biz.AccountRepo {
 }
 
 func (r *accountRepo) SaveAccount(ctx context.Context, req *biz.AccountRequest) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveAccount(tx, req)
+	})
+}
 
+func saveAccount(tx *gorm.DB, req *biz.AccountRequest) error {
 	var a *Account
-	if err := r.data.db.WithContext(ctx).Model(&Account{}).Where("account_id = ?", req.AccountID).First(&a).Error; err != nil {
+	if err := tx.Model(&Account{}).Where("account_id = ?", req.AccountID).First(&a).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// account_id is unique, so the id of an erased account waiting to
+			// be purged fails to insert
 			a = &Account{
 				AccountID:  req.AccountID,
 				Email:      req.Email,
@@ -67,7 +100,18 @@ func (r *accountRepo) SaveAccount(ctx context.Context, req *biz.AccountRequest)
 	if req.SolanaAddr != "" {
 		a.SolanaAddr = req.SolanaAddr
 	}
-	return r.data.db.WithContext(ctx).Model(&Account{}).Where("account_id = ?", req.AccountID).Save(&a).Error
+	created := a.ID == 0
+	if err := tx.Model(&Account{}).Where("account_id = ?", req.AccountID).Save(&a).Error; err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+	return addOutboxEvent(tx, biz.EventAccountCreated, a.AccountID, &biz.AccountCreatedEvent{
+		AccountID: a.AccountID,
+		Provider:  a.Provider,
+		CreatedAt: a.CreatedAt,
+	})
 }
 
 func (r *accountRepo) UpdateAccountIntegral(ctx context.Context, accountID string, integral int) error {
@@ -78,13 +122,115 @@ func (r *accountRepo) UpdateAccountIntegral(ctx context.Context, accountID strin
 	return nil
 }
 
-func (r *accountRepo) UpdateClaimPoints(ctx context.Context, accountID string, integral, received int) error {
-	if err := r.data.db.WithContext(ctx).Model(&Account{}).Where("account_id = ?", accountID).
-		Updates(Account{Integral: integral, Received: received}).Error; err != nil {
-		return err
+func (r *accountRepo) UpdateClaimPoints(ctx context.Context, accountID string, points int) (bool, error) {
+	claimed := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the balance is checked by the update itself, so concurrent claims
+		// and plays can't both spend it
+		res := tx.Model(&Account{}).Where("account_id = ? and integral >= received + ?", accountID, points).
+			Update("received", gorm.Expr("received + ?", points))
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		var a *Account
+		if err := tx.Model(&Account{}).Where("account_id = ?", accountID).First(&a).Error; err != nil {
+			return err
+		}
+		claim := &ClaimLog{
+			AccountID:  accountID,
+			Points:     points,
+			Received:   a.Received,
+			SolanaAddr: a.SolanaAddr,
+			ClaimCount: a.ClaimCount,
+		}
+		if err := tx.Create(claim).Error; err != nil {
+			return err
+		}
+		if err := addOutboxEvent(tx, biz.EventPointsClaimed, accountID, &biz.PointsClaimedEvent{
+			AccountID:  accountID,
+			Points:     claim.Points,
+			Received:   claim.Received,
+			SolanaAddr: claim.SolanaAddr,
+			ClaimCount: claim.ClaimCount,
+			ClaimedAt:  claim.CreatedAt,
+		}); err != nil {
+			return err
+		}
+		claimed = true
+		return nil
+	})
+	return claimed && err == nil, err
+}
+
+func (r *accountRepo) SearchAccounts(ctx context.Context, req *biz.AccountSearchRequest) ([]*biz.AccountResponse, error) {
+	db := r.data.db.WithContext(ctx).Model(&Account{})
+	if req.AccountIDPrefix != "" {
+		db = db.Where("account_id like ? escape '!'", escapeLike(req.AccountIDPrefix)+"%")
+	}
+	if req.Email != "" {
+		db = db.Where("email = ?", req.Email)
+	}
+	if req.NamePrefix != "" {
+		db = db.Where("lower(name) like ? escape '!'", escapeLike(strings.ToLower(req.NamePrefix))+"%")
+	}
+	if req.Provider != "" {
+		db = db.Where("provider = ?", req.Provider)
+	}
+	if req.SolanaAddr != "" {
+		db = db.Where("solana_addr = ?", req.SolanaAddr)
+	}
+	if req.State != nil {
+		db = db.Where("state = ?", *req.State)
+	}
+	if req.CreatedFrom != nil {
+		db = db.Where("created_at >= ?", *req.CreatedFrom)
+	}
+	if req.CreatedTo != nil {
+		db = db.Where("created_at < ?", *req.CreatedTo)
+	}
+	if req.MinIntegral != nil {
+		db = db.Where("integral >= ?", *req.MinIntegral)
+	}
+	if req.MaxIntegral != nil {
+		db = db.Where("integral <= ?", *req.MaxIntegral)
+	}
+
+	op, dir := ">", "asc"
+	if req.Desc {
+		op, dir = "<", "desc"
+	}
+	if c := req.After; c != nil {
+		var v interface{}
+		switch req.Sort {
+		case biz.AccountSortCreatedAt:
+			v = c.CreatedAt
+		case biz.AccountSortIntegral:
+			v = c.Integral
+		case biz.AccountSortReceived:
+			v = c.Received
+		}
+		if v == nil {
+			db = db.Where("account_id "+op+" ?", c.AccountID)
+		} else {
+			db = db.Where("("+req.Sort+" "+op+" ? or ("+req.Sort+" = ? and account_id "+op+" ?))", v, v, c.AccountID)
+		}
+	}
+	if req.Sort != biz.AccountSortAccountID {
+		db = db.Order(req.Sort + " " + dir)
 	}
-	return nil
 
+	var a []*Account
+	if err := db.Order("account_id " + dir).Limit(req.Limit).Find(&a).Error; err != nil {
+		return nil, err
+	}
+	return makeAccountResponses(a), nil
+}
+
+// escapeLike escapes the LIKE wildcards so user input only matches literally.
+// The escape character is given explicitly as '!', since sqlite has no default
+// and a backslash is itself an escape in mysql string literals.
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
 }
 
 func (r *accountRepo) UpdateAddr(ctx context.Context, accountID string, addr string) error {
@@ -118,7 +264,7 @@ func (r *accountRepo) QueryAccount(ctx context.Context, accountID, email, provid
 
 func (r *accountRepo) QueryAccounts(ctx context.Context) ([]*biz.AccountResponse, error) {
 	var a []*Account
-	if err := r.data.db.WithContext(ctx).Model(&Account{}).Where("state <> ?", -1).Find(&a).Error; err != nil {
+	if err := r.data.db.WithContext(ctx).Model(&Account{}).Where("state not in ?", []int{biz.AccountStateBanned, biz.AccountStateDeleted}).Find(&a).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -127,15 +273,137 @@ func (r *accountRepo) QueryAccounts(ctx context.Context) ([]*biz.AccountResponse
 	return makeAccountResponses(a), nil
 }
 
+func (r *accountRepo) QueryAccountIDs(ctx context.Context, accountIDs []string) ([]string, error) {
+	const batch = 500
+	res := make([]string, 0, len(accountIDs))
+	for i := 0; i < len(accountIDs); i += batch {
+		end := i + batch
+		if end > len(accountIDs) {
+			end = len(accountIDs)
+		}
+		var ids []string
+		if err := r.data.db.WithContext(ctx).Model(&Account{}).Where("account_id in ?", accountIDs[i:end]).
+			Pluck("account_id", &ids).Error; err != nil {
+			return nil, err
+		}
+		res = append(res, ids...)
+	}
+	return res, nil
+}
+
+func (r *accountRepo) QueryClaimLogs(ctx context.Context, accountID string) ([]*biz.ClaimLogResponse, error) {
+	var logs []*ClaimLog
+	if err := r.data.db.WithContext(ctx).Model(&ClaimLog{}).Where("account_id = ?", accountID).
+		Order("id").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	res := make([]*biz.ClaimLogResponse, len(logs))
+	for i := range logs {
+		res[i] = &biz.ClaimLogResponse{
+			AccountID:  logs[i].AccountID,
+			Points:     logs[i].Points,
+			Received:   logs[i].Received,
+			SolanaAddr: logs[i].SolanaAddr,
+			ClaimCount: logs[i].ClaimCount,
+			CreateAt:   logs[i].CreatedAt,
+		}
+	}
+	return res, nil
+}
+
+func (r *accountRepo) EraseAccount(ctx context.Context, req *biz.AccountStateRequest) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var a *Account
+		if err := tx.Model(&Account{}).Where("account_id = ?", req.AccountID).First(&a).Error; err != nil {
+			return err
+		}
+		err := tx.Model(&Account{}).Where("account_id = ?", req.AccountID).Updates(map[string]interface{}{
+			"email":             "",
+			"email_verified_at": nil,
+			"name":              deletedAccountName,
+			"avatar_url":        "",
+			"state":             req.State,
+			"state_reason":      req.Reason,
+			"state_actor":       req.Actor,
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&AccountStateLog{
+			AccountID: req.AccountID,
+			FromState: a.State,
+			ToState:   req.State,
+			Reason:    req.Reason,
+			Actor:     req.Actor,
+		}).Error
+		if err != nil {
+			return err
+		}
+		// appeals are free text written by the user
+		if err = tx.Where("account_id = ?", req.AccountID).Delete(&AccountAppeal{}).Error; err != nil {
+			return err
+		}
+		// the profile history holds every name and avatar the account had
+		if err = tx.Model(&ProfileChangeLog{}).Where("account_id = ?", req.AccountID).
+			Updates(map[string]interface{}{"old_value": "", "new_value": ""}).Error; err != nil {
+			return err
+		}
+		return tx.Where("account_id = ?", req.AccountID).Delete(&Account{}).Error
+	})
+}
+
+func (r *accountRepo) PurgeAccounts(ctx context.Context, before time.Time) (int64, error) {
+	var n int64
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := fenceLease(ctx, tx); err != nil {
+			return err
+		}
+		var ids []string
+		if err := tx.Unscoped().Model(&Account{}).Where("deleted_at is not null and deleted_at < ?", before).
+			Pluck("account_id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := tx.Unscoped().Where("account_id in ? and deleted_at is not null", ids).Delete(&AccountAppeal{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("account_id in ?", ids).Delete(&ProfileChangeLog{}).Error; err != nil {
+			return err
+		}
+		res := tx.Unscoped().Where("deleted_at is not null and deleted_at < ?", before).Delete(&Account{})
+		n = res.RowsAffected
+		return res.Error
+	})
+	return n, err
+}
+
+func (r *accountRepo) AccountErased(ctx context.Context, accountID string) (bool, error) {
+	var count int64
+	if err := r.data.db.WithContext(ctx).Unscoped().Model(&Account{}).
+		Where("account_id = ? and deleted_at is not null", accountID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func makeAccountResponse(a *Account) *biz.AccountResponse {
 	return &biz.AccountResponse{
-		AccountID:  a.AccountID,
-		Integral:   a.Integral,
-		Received:   a.Received,
-		AvatarURL:  a.AvatarURL,
-		Name:       a.Name,
-		SolanaAddr: a.SolanaAddr,
-		ClaimCount: a.ClaimCount,
+		AccountID:     a.AccountID,
+		Integral:      a.Integral,
+		Received:      a.Received,
+		Email:         a.Email,
+		Name:          a.Name,
+		Provider:      a.Provider,
+		AvatarURL:     a.AvatarURL,
+		SolanaAddr:    a.SolanaAddr,
+		ClaimCount:    a.ClaimCount,
+		State:         a.State,
+		StateReason:   a.StateReason,
+		StateExpireAt: a.StateExpireAt,
+		EmailVerified: a.EmailVerifiedAt != nil,
+		CreateAt:      a.CreatedAt,
 	}
 }
 
@@ -0,0 +1,220 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AccountStateLog struct {
+	gorm.Model
+	TenantID  string `gorm:"index;size:64;default:'default'"`
+	AccountID string `gorm:"index;size:255"`
+	FromState int
+	ToState   int
+	Reason    string
+	Actor     string
+	ExpireAt  *time.Time
+}
+
+type AccountAppeal struct {
+	gorm.Model
+	TenantID  string `gorm:"index;size:64;default:'default'"`
+	AppealID  string `gorm:"uniqueIndex;size:64"`
+	AccountID string `gorm:"index;size:255"`
+	Content   string `gorm:"size:2048"`
+	State     int    `gorm:"index"`
+	Actor     string
+	Reply     string `gorm:"size:2048"`
+}
+
+type accountStateRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewAccountStateRepo(c *configs.Config, data *Data) biz.AccountStateRepo {
+	return &accountStateRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func (r *accountStateRepo) UpdateAccountState(ctx context.Context, req *biz.AccountStateRequest) (bool, error) {
+	changed := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := fenceLease(ctx, tx); err != nil {
+			return err
+		}
+		var err error
+		changed, err = updateAccountState(tx, req)
+		return err
+	})
+	return changed && err == nil, err
+}
+
+// updateAccountState changes the account state in tx and records the
+// transition, false when the account is in none of the request's From states.
+func updateAccountState(tx *gorm.DB, req *biz.AccountStateRequest) (bool, error) {
+	var a *Account
+	if err := tx.Model(&Account{}).Where("account_id = ?", req.AccountID).First(&a).Error; err != nil {
+		return false, err
+	}
+	update := tx.Model(&Account{}).Where("account_id = ?", req.AccountID)
+	if len(req.From) > 0 {
+		// checked by the update itself, so a concurrent change isn't undone
+		update = update.Where("state in ?", req.From)
+	}
+	res := update.Updates(map[string]interface{}{
+		"state":           req.State,
+		"state_reason":    req.Reason,
+		"state_actor":     req.Actor,
+		"state_expire_at": req.ExpireAt,
+	})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	// MySQL counts only the rows changed, so a change leaving the row as it
+	// was affects none; a From state is never the new one
+	if len(req.From) > 0 && res.RowsAffected == 0 {
+		return false, nil
+	}
+	log := &AccountStateLog{
+		AccountID: req.AccountID,
+		FromState: a.State,
+		ToState:   req.State,
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		ExpireAt:  req.ExpireAt,
+	}
+	if err := tx.Create(log).Error; err != nil {
+		return false, err
+	}
+	if req.State != biz.AccountStateBanned || a.State == biz.AccountStateBanned {
+		return true, nil
+	}
+	return true, addOutboxEvent(tx, biz.EventAccountBanned, req.AccountID, &biz.AccountBannedEvent{
+		AccountID: req.AccountID,
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		BannedAt:  log.CreatedAt,
+	})
+}
+
+func (r *accountStateRepo) QueryAccountStateLogs(ctx context.Context, accountID string) ([]*biz.AccountStateLogResponse, error) {
+	var logs []*AccountStateLog
+	if err := r.data.db.WithContext(ctx).Model(&AccountStateLog{}).Where("account_id = ?", accountID).
+		Order("id desc").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	res := make([]*biz.AccountStateLogResponse, len(logs))
+	for i := range logs {
+		res[i] = &biz.AccountStateLogResponse{
+			AccountID: logs[i].AccountID,
+			FromState: logs[i].FromState,
+			ToState:   logs[i].ToState,
+			Reason:    logs[i].Reason,
+			Actor:     logs[i].Actor,
+			ExpireAt:  logs[i].ExpireAt,
+			CreateAt:  logs[i].CreatedAt,
+		}
+	}
+	return res, nil
+}
+
+func (r *accountStateRepo) AddAppeal(ctx context.Context, req *biz.AppealRequest) error {
+	appealID := req.AppealID
+	if appealID == "" {
+		appealID = uuid.NewString()
+	}
+	return r.data.db.WithContext(ctx).Create(&AccountAppeal{
+		AppealID:  appealID,
+		AccountID: req.AccountID,
+		Content:   req.Content,
+		State:     biz.AppealPending,
+	}).Error
+}
+
+func (r *accountStateRepo) QueryAppeal(ctx context.Context, appealID string) (*biz.AppealResponse, error) {
+	var a *AccountAppeal
+	if err := r.data.db.WithContext(ctx).Model(&AccountAppeal{}).Where("appeal_id = ?", appealID).First(&a).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return makeAppealResponse(a), nil
+}
+
+func (r *accountStateRepo) QueryPendingAppeal(ctx context.Context, accountID string) (*biz.AppealResponse, error) {
+	var a *AccountAppeal
+	if err := r.data.db.WithContext(ctx).Model(&AccountAppeal{}).Where("account_id = ? and state = ?", accountID, biz.AppealPending).
+		First(&a).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return makeAppealResponse(a), nil
+}
+
+func (r *accountStateRepo) QueryAppeals(ctx context.Context, state int) ([]*biz.AppealResponse, error) {
+	var appeals []*AccountAppeal
+	if err := r.data.db.WithContext(ctx).Model(&AccountAppeal{}).Where("state = ?", state).
+		Order("id").Find(&appeals).Error; err != nil {
+		return nil, err
+	}
+	res := make([]*biz.AppealResponse, len(appeals))
+	for i := range appeals {
+		res[i] = makeAppealResponse(appeals[i])
+	}
+	return res, nil
+}
+
+// errRollback ends a transaction that changed nothing the caller should keep.
+var errRollback = errors.New("rollback")
+
+func (r *accountStateRepo) ResolveAppeal(ctx context.Context, req *biz.ResolveAppealRequest) (bool, error) {
+	resolved := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// only a pending appeal, so concurrent resolutions can't both pass
+		res := tx.Model(&AccountAppeal{}).Where("appeal_id = ? and state = ?", req.AppealID, biz.AppealPending).
+			Updates(map[string]interface{}{"state": req.State, "actor": req.Actor, "reply": req.Reply})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		if req.Reactivate != nil {
+			ok, err := updateAccountState(tx, req.Reactivate)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errRollback
+			}
+		}
+		resolved = true
+		return nil
+	})
+	if errors.Is(err, errRollback) {
+		return false, nil
+	}
+	return resolved, err
+}
+
+func makeAppealResponse(a *AccountAppeal) *biz.AppealResponse {
+	return &biz.AppealResponse{
+		AppealID:  a.AppealID,
+		AccountID: a.AccountID,
+		Content:   a.Content,
+		State:     a.State,
+		Actor:     a.Actor,
+		Reply:     a.Reply,
+		CreateAt:  a.CreatedAt,
+		UpdateAt:  a.UpdatedAt,
+	}
+}
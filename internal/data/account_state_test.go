@@ -0,0 +1,94 @@
+package data
+
+import (
+	"starland-account/internal/biz"
+	"testing"
+)
+
+func TestAccountStateRepoFrom(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, r := NewAccountRepo(c, d), NewAccountStateRepo(c, d)
+	ctx := tenantCtx("default")
+	if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+
+	steps := []struct {
+		name  string
+		req   *biz.AccountStateRequest
+		ok    bool
+		state int
+	}{
+		{"ban", &biz.AccountStateRequest{State: biz.AccountStateBanned, Actor: "chain"}, true, biz.AccountStateBanned},
+		{"unsuspend leaves a ban", &biz.AccountStateRequest{State: biz.AccountStateActive, From: []int{biz.AccountStateSuspended}}, false, biz.AccountStateBanned},
+		{"unban", &biz.AccountStateRequest{State: biz.AccountStateActive, From: []int{biz.AccountStateBanned}}, true, biz.AccountStateActive},
+		{"ban again with the same reason", &biz.AccountStateRequest{State: biz.AccountStateBanned, Actor: "chain"}, true, biz.AccountStateBanned},
+	}
+	for _, st := range steps {
+		st.req.AccountID = "a1"
+		ok, err := r.UpdateAccountState(ctx, st.req)
+		if err != nil || ok != st.ok {
+			t.Fatalf("%s: UpdateAccountState = %v, %v, want %v", st.name, ok, err, st.ok)
+		}
+		got, err := accounts.QueryAccount(ctx, "a1", "", "")
+		if err != nil || got.State != st.state {
+			t.Fatalf("%s: state = %v, %v, want %d", st.name, got, err, st.state)
+		}
+	}
+
+	logs, err := r.QueryAccountStateLogs(ctx, "a1")
+	if err != nil {
+		t.Fatalf("QueryAccountStateLogs: %v", err)
+	}
+	if len(logs) != 3 {
+		t.Errorf("state logs = %d, want 3, the refused change logged none", len(logs))
+	}
+}
+
+func TestAccountStateRepoResolveAppeal(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, r := NewAccountRepo(c, d), NewAccountStateRepo(c, d)
+	ctx := tenantCtx("default")
+	if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	ban := &biz.AccountStateRequest{AccountID: "a1", State: biz.AccountStateBanned, Actor: "chain"}
+	if _, err := r.UpdateAccountState(ctx, ban); err != nil {
+		t.Fatalf("UpdateAccountState: %v", err)
+	}
+	for _, id := range []string{"p1", "p2"} {
+		if err := r.AddAppeal(ctx, &biz.AppealRequest{AppealID: id, AccountID: "a1"}); err != nil {
+			t.Fatalf("AddAppeal: %v", err)
+		}
+	}
+	approve := func(id string) *biz.ResolveAppealRequest {
+		return &biz.ResolveAppealRequest{AppealID: id, State: biz.AppealApproved, Actor: "admin", Reactivate: &biz.AccountStateRequest{
+			AccountID: "a1", State: biz.AccountStateActive, Actor: "admin",
+			From: []int{biz.AccountStateSuspended, biz.AccountStateBanned},
+		}}
+	}
+
+	if ok, err := r.ResolveAppeal(ctx, approve("p1")); err != nil || !ok {
+		t.Fatalf("ResolveAppeal = %v, %v", ok, err)
+	}
+	if ok, err := r.ResolveAppeal(ctx, approve("p1")); err != nil || ok {
+		t.Errorf("ResolveAppeal of a resolved appeal = %v, %v, want false", ok, err)
+	}
+	if got, err := accounts.QueryAccount(ctx, "a1", "", ""); err != nil || got.State != biz.AccountStateActive {
+		t.Fatalf("state = %v, %v, want active", got, err)
+	}
+
+	// the account is active already: the approval changes nothing, the appeal
+	// stays pending
+	if ok, err := r.ResolveAppeal(ctx, approve("p2")); err != nil || ok {
+		t.Errorf("ResolveAppeal of an active account = %v, %v, want false", ok, err)
+	}
+	appeal, err := r.QueryAppeal(ctx, "p2")
+	if err != nil || appeal.State != biz.AppealPending {
+		t.Errorf("appeal = %+v, %v, want it pending", appeal, err)
+	}
+	logs, err := r.QueryAccountStateLogs(ctx, "a1")
+	if err != nil || len(logs) != 2 {
+		t.Errorf("state logs = %d, %v, want the ban and one reactivation", len(logs), err)
+	}
+}
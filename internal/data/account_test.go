@@ -0,0 +1,239 @@
+package data
+
+import (
+	"starland-account/internal/biz"
+	"testing"
+	"time"
+)
+
+func TestAccountRepoSaveAccount(t *testing.T) {
+	c, d := newTestData(t)
+	r := NewAccountRepo(c, d)
+	ctx := tenantCtx("default")
+
+	req := &biz.AccountRequest{AccountID: "a1", Name: "Alice", Email: "a@x.io", Provider: "google"}
+	if err := r.SaveAccount(ctx, req); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	req.Name = "Alicia"
+	if err := r.SaveAccount(ctx, req); err != nil {
+		t.Fatalf("SaveAccount again: %v", err)
+	}
+
+	got, err := r.QueryAccount(ctx, "a1", "", "")
+	if err != nil || got == nil {
+		t.Fatalf("QueryAccount = %v, %v", got, err)
+	}
+	if got.Name != "Alicia" {
+		t.Errorf("Name = %q, want Alicia", got.Name)
+	}
+	if got, err = r.QueryAccount(ctx, "", "a@x.io", "google"); err != nil || got == nil || got.AccountID != "a1" {
+		t.Errorf("QueryAccount by email = %v, %v", got, err)
+	}
+	if got, err = r.QueryAccount(ctx, "missing", "", ""); err != nil || got != nil {
+		t.Errorf("QueryAccount(missing) = %v, %v, want nil, nil", got, err)
+	}
+
+	// only the first save creates the account
+	var events []*OutboxEvent
+	if err = d.db.Where("type = ?", biz.EventAccountCreated).Find(&events).Error; err != nil {
+		t.Fatalf("find events: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("account.created events = %d, want 1", len(events))
+	}
+}
+
+func TestAccountRepoSearchAccounts(t *testing.T) {
+	c, d := newTestData(t)
+	r := NewAccountRepo(c, d)
+	ctx := tenantCtx("default")
+
+	for _, a := range []struct{ id, name string }{
+		{"user_1", "Bob"}, {"user_2", "bobby"}, {"userx3", "Carol"}, {"user%4", "Dave"},
+	} {
+		if err := r.SaveAccount(ctx, &biz.AccountRequest{AccountID: a.id, Name: a.name}); err != nil {
+			t.Fatalf("SaveAccount(%s): %v", a.id, err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		req  *biz.AccountSearchRequest
+		want []string
+	}{
+		{"id prefix is literal", &biz.AccountSearchRequest{AccountIDPrefix: "user_"}, []string{"user_1", "user_2"}},
+		{"percent is literal", &biz.AccountSearchRequest{AccountIDPrefix: "user%"}, []string{"user%4"}},
+		{"name prefix ignores case", &biz.AccountSearchRequest{NamePrefix: "BOB"}, []string{"user_1", "user_2"}},
+		{"descending", &biz.AccountSearchRequest{Desc: true, Limit: 2}, []string{"userx3", "user_2"}},
+		{"after cursor", &biz.AccountSearchRequest{After: &biz.AccountCursor{AccountID: "user_1"}}, []string{"user_2", "userx3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.req.Sort == "" {
+				tt.req.Sort = biz.AccountSortAccountID
+			}
+			if tt.req.Limit == 0 {
+				tt.req.Limit = 10
+			}
+			res, err := r.SearchAccounts(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("SearchAccounts: %v", err)
+			}
+			if got := accountIDs(res); !equalStrings(got, tt.want) {
+				t.Errorf("SearchAccounts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountRepoClaimPoints(t *testing.T) {
+	c, d := newTestData(t)
+	r := NewAccountRepo(c, d)
+	ctx := tenantCtx("default")
+
+	if err := r.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	if err := r.UpdateAccountIntegral(ctx, "a1", 30); err != nil {
+		t.Fatalf("UpdateAccountIntegral: %v", err)
+	}
+	if ok, err := r.UpdateClaimPoints(ctx, "a1", 20); err != nil || !ok {
+		t.Fatalf("UpdateClaimPoints = %v, %v, want true", ok, err)
+	}
+	// a claim read the same balance before the first one, it can't spend it
+	// again
+	if ok, err := r.UpdateClaimPoints(ctx, "a1", 20); err != nil || ok {
+		t.Fatalf("UpdateClaimPoints over the balance = %v, %v, want false", ok, err)
+	}
+
+	got, err := r.QueryAccount(ctx, "a1", "", "")
+	if err != nil {
+		t.Fatalf("QueryAccount: %v", err)
+	}
+	if got.Integral != 30 || got.Received != 20 {
+		t.Errorf("Integral, Received = %d, %d, want 30, 20", got.Integral, got.Received)
+	}
+	logs, err := r.QueryClaimLogs(ctx, "a1")
+	if err != nil {
+		t.Fatalf("QueryClaimLogs: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Points != 20 {
+		t.Errorf("QueryClaimLogs = %+v, want one claim of 20", logs)
+	}
+}
+
+func TestAccountRepoEraseAndPurge(t *testing.T) {
+	c, d := newTestData(t)
+	r := NewAccountRepo(c, d)
+	ctx := tenantCtx("default")
+
+	for _, id := range []string{"a1", "a2"} {
+		if err := r.SaveAccount(ctx, &biz.AccountRequest{AccountID: id, Name: id, Email: id + "@x.io"}); err != nil {
+			t.Fatalf("SaveAccount(%s): %v", id, err)
+		}
+	}
+	err := r.EraseAccount(ctx, &biz.AccountStateRequest{AccountID: "a1", State: biz.AccountStateDeleted, Actor: "a1"})
+	if err != nil {
+		t.Fatalf("EraseAccount: %v", err)
+	}
+	if got, err := r.QueryAccount(ctx, "a1", "", ""); err != nil || got != nil {
+		t.Errorf("QueryAccount(erased) = %v, %v, want nil, nil", got, err)
+	}
+
+	n, err := r.PurgeAccounts(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("PurgeAccounts: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("PurgeAccounts = %d, want 1", n)
+	}
+	var count int64
+	if err = d.db.WithContext(ctx).Unscoped().Model(&Account{}).Count(&count).Error; err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("accounts left = %d, want 1", count)
+	}
+}
+
+func TestAccountRepoSaveErasedAccount(t *testing.T) {
+	c, d := newTestData(t)
+	r := NewAccountRepo(c, d)
+	ctx := tenantCtx("default")
+
+	if err := r.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1", Name: "Alice"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	err := r.EraseAccount(ctx, &biz.AccountStateRequest{AccountID: "a1", State: biz.AccountStateDeleted, Actor: "a1"})
+	if err != nil {
+		t.Fatalf("EraseAccount: %v", err)
+	}
+	// the erased account keeps its id, and its state, until it is purged
+	if err = r.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1", Name: "Alicia"}); err == nil {
+		t.Fatal("SaveAccount of an erased id succeeded")
+	}
+	if erased, err := r.AccountErased(ctx, "a1"); err != nil || !erased {
+		t.Errorf("AccountErased = %v, %v, want true", erased, err)
+	}
+	if n, err := r.PurgeAccounts(ctx, time.Now().Add(time.Second)); err != nil || n != 1 {
+		t.Fatalf("PurgeAccounts = %d, %v, want 1", n, err)
+	}
+	if err = r.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1", Name: "Alicia"}); err != nil {
+		t.Fatalf("SaveAccount after purge: %v", err)
+	}
+	got, err := r.QueryAccount(ctx, "a1", "", "")
+	if err != nil || got == nil || got.Name != "Alicia" {
+		t.Errorf("QueryAccount = %v, %v, want Alicia", got, err)
+	}
+}
+
+func accountIDs(as []*biz.AccountResponse) []string {
+	res := make([]string, len(as))
+	for i := range as {
+		res[i] = as[i].AccountID
+	}
+	return res
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAccountRepoEraseProfileChanges(t *testing.T) {
+	c, d := newTestData(t)
+	r, profiles := NewAccountRepo(c, d), NewProfileRepo(c, d)
+	ctx := tenantCtx("default")
+
+	if err := r.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1", Name: "Alice"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	name := "Alicia"
+	if err := profiles.UpdateProfile(ctx, &biz.ProfileRequest{AccountID: "a1", Name: &name, Actor: "a1"}); err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+	err := r.EraseAccount(ctx, &biz.AccountStateRequest{AccountID: "a1", State: biz.AccountStateDeleted, Actor: "a1"})
+	if err != nil {
+		t.Fatalf("EraseAccount: %v", err)
+	}
+	changes, err := profiles.QueryProfileChanges(ctx, "a1")
+	if err != nil || len(changes) != 1 || changes[0].OldValue != "" || changes[0].NewValue != "" {
+		t.Fatalf("profile changes after erase = %+v, %v, want one without names", changes, err)
+	}
+
+	if _, err = r.PurgeAccounts(ctx, time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("PurgeAccounts: %v", err)
+	}
+	var n int64
+	if err = d.db.WithContext(ctx).Unscoped().Model(&ProfileChangeLog{}).Count(&n).Error; err != nil || n != 0 {
+		t.Errorf("profile changes after purge = %d, %v, want none", n, err)
+	}
+}
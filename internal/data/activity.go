@@ -15,6 +15,7 @@ import (
 type Activity struct {
 	gorm.Model
 	UUID         string `json:"uuid" gorm:"primary_key;size:255"`
+	TenantID     string `gorm:"index;size:64;default:'default'"`
 	ActivityCode int
 	ActivityName string
 	Integral     int
@@ -45,11 +46,19 @@ func (r *activityRepo) QueryActivity(ctx context.Context) ([]*biz.ActivityRespon
 }
 
 func (r *activityRepo) ConsumeActivityLimit(ctx context.Context, key string, n int, timeOut time.Duration) error {
-	_, err := r.data.rdb.WithContext(ctx).Set(key, n, timeOut).Result()
+	key, err := tenantKey(ctx, key)
+	if err != nil {
+		return err
+	}
+	_, err = r.data.rdb.WithContext(ctx).Set(key, n, timeOut).Result()
 	return err
 }
 
 func (r *activityRepo) QueryActivityExpend(ctx context.Context, key string) (int, error) {
+	key, err := tenantKey(ctx, key)
+	if err != nil {
+		return 0, err
+	}
 	value, err := r.data.rdb.WithContext(ctx).Get(key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
@@ -4,18 +4,26 @@ import (
 	"context"
 	"starland-account/configs"
 	"starland-account/internal/biz"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// ActivityLog spells out gorm.Model so created_at can share the account
+// index used by the cursor pagination, created by migration 3.
 type ActivityLog struct {
-	gorm.Model
-	UUID         string `json:"uuid" gorm:"primary_key;size:255"`
-	AccountID    string
+	ID           uint `gorm:"primarykey"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	UUID         string         `json:"uuid" gorm:"primary_key;size:255"`
+	TenantID     string         `gorm:"index;size:64;default:'default'"`
+	AccountID    string         `gorm:"size:255"` // indexed with TenantID, CreatedAt by migration 3
 	ActivityCode int
 	ActivityName string
 	Integral     int
+	ClientID     string `gorm:"size:64"`
 }
 
 type activityLogRepo struct {
@@ -31,42 +39,116 @@ func NewActivityLogRepo(c *configs.Config, data *Data) biz.ActivityLogRepo {
 }
 
 func (r *activityLogRepo) AddActivityLog(ctx context.Context, req *biz.ActivityLogRequest) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := addActivityLog(tx, req)
+		return err
+	})
+}
+
+// EarnPoints credits the points and logs them in one transaction, so the
+// balance and the history can't drift apart.
+func (r *activityLogRepo) EarnPoints(ctx context.Context, req *biz.ActivityLogRequest) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := earnPoints(tx, req)
+		return err
+	})
+}
+
+func earnPoints(tx *gorm.DB, req *biz.ActivityLogRequest) (*ActivityLog, error) {
+	res := tx.Model(&Account{}).Where("account_id = ?", req.AccountID).
+		Update("integral", gorm.Expr("integral + ?", req.Integral))
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return addActivityLog(tx, req)
+}
+
+// addActivityLog writes the log and its points.earned event in tx.
+func addActivityLog(tx *gorm.DB, req *biz.ActivityLogRequest) (*ActivityLog, error) {
 	actlog := &ActivityLog{
 		UUID:         uuid.NewString(),
 		AccountID:    req.AccountID,
 		ActivityCode: req.ActivityCode,
 		ActivityName: req.ActivityName,
 		Integral:     req.Integral,
+		ClientID:     req.ClientID,
+	}
+	if err := tx.Create(actlog).Error; err != nil {
+		return nil, err
+	}
+	err := addOutboxEvent(tx, biz.EventPointsEarned, req.AccountID, &biz.PointsEarnedEvent{
+		AccountID:    req.AccountID,
+		ActivityCode: req.ActivityCode,
+		ActivityName: req.ActivityName,
+		Points:       req.Integral,
+		ClientID:     req.ClientID,
+		EarnedAt:     actlog.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return actlog, nil
+}
+
+func (r *activityLogRepo) QueryActivityLog(ctx context.Context, query *biz.ActivityLogQuery) ([]*biz.ActivityLogResponse, error) {
+	db := r.filterActivityLogs(ctx, query)
+	if c := query.After; c != nil {
+		db = db.Where("(created_at < ? or (created_at = ? and id < ?))", c.CreatedAt, c.CreatedAt, c.ID)
+	} else if query.Page > 1 {
+		db = db.Offset((query.Page - 1) * query.Limit)
 	}
 
-	return r.data.db.WithContext(ctx).Model(&ActivityLog{}).Create(&actlog).Error
+	var actlogs []*ActivityLog
+	if err := db.Order("created_at desc, id desc").Limit(query.Limit).Find(&actlogs).Error; err != nil {
+		return nil, err
+	}
+	return makeActivityLogsToBizRes(actlogs), nil
 }
 
-func (r *activityLogRepo) QueryActivityLog(ctx context.Context, account string, page, limit int) ([]*biz.ActivityLogResponse, int64, error) {
-	var (
-		actlogs []*ActivityLog
-		count   int64
-	)
-	err := r.data.db.WithContext(ctx).Model(&ActivityLog{}).Where("account_id = ?", account).Offset((page - 1) * limit).Limit(limit).Order("updated_at desc ").Find(&actlogs).Error
-	if err != nil {
-		return nil, count, err
+func (r *activityLogRepo) SumActivityLogs(ctx context.Context, query *biz.ActivityLogQuery) ([]*biz.ActivityLogTotal, error) {
+	var totals []*biz.ActivityLogTotal
+	err := r.filterActivityLogs(ctx, query).
+		Select("activity_code, max(activity_name) as activity_name, count(*) as count, coalesce(sum(integral), 0) as integral").
+		Group("activity_code").Order("activity_code").Scan(&totals).Error
+	return totals, err
+}
+
+func (r *activityLogRepo) filterActivityLogs(ctx context.Context, query *biz.ActivityLogQuery) *gorm.DB {
+	db := r.data.db.WithContext(ctx).Model(&ActivityLog{}).Where("account_id = ?", query.AccountID)
+	if query.ActivityCode != nil {
+		db = db.Where("activity_code = ?", *query.ActivityCode)
 	}
-	err = r.data.db.WithContext(ctx).Model(&ActivityLog{}).Where("account_id = ?", account).Count(&count).Error
-	if err != nil {
-		return nil, count, err
+	if query.From != nil {
+		db = db.Where("created_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("created_at < ?", *query.To)
 	}
+	return db
+}
 
-	return makeActivityLogsToBizRes(actlogs), count, nil
+func (r *activityLogRepo) QueryAllActivityLogs(ctx context.Context, account string) ([]*biz.ActivityLogResponse, error) {
+	var actlogs []*ActivityLog
+	err := r.data.db.WithContext(ctx).Model(&ActivityLog{}).Where("account_id = ?", account).Order("id").Find(&actlogs).Error
+	if err != nil {
+		return nil, err
+	}
+	return makeActivityLogsToBizRes(actlogs), nil
 }
 
 func makeActivityLogsToBizRes(actlogs []*ActivityLog) []*biz.ActivityLogResponse {
 	res := make([]*biz.ActivityLogResponse, len(actlogs))
 	for i := range actlogs {
 		res[i] = &biz.ActivityLogResponse{
+			ID:           actlogs[i].ID,
 			AccountID:    actlogs[i].AccountID,
 			ActivityCode: actlogs[i].ActivityCode,
 			ActivityName: actlogs[i].ActivityName,
 			Integral:     actlogs[i].Integral,
+			ClientID:     actlogs[i].ClientID,
 			CreateAt:     actlogs[i].CreatedAt,
 		}
 	}
@@ -0,0 +1,88 @@
+package data
+
+import (
+	"errors"
+	"starland-account/internal/biz"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestActivityLogRepoEarnPoints(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, logs := NewAccountRepo(c, d), NewActivityLogRepo(c, d)
+	ctx := tenantCtx("default")
+
+	if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	for i, code := range []int{1, 2, 1} {
+		err := logs.EarnPoints(ctx, &biz.ActivityLogRequest{AccountID: "a1", ActivityCode: code, ActivityName: "play", Integral: 10 * (i + 1)})
+		if err != nil {
+			t.Fatalf("EarnPoints: %v", err)
+		}
+	}
+	err := logs.EarnPoints(ctx, &biz.ActivityLogRequest{AccountID: "missing", ActivityCode: 1, Integral: 10})
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("EarnPoints(missing) = %v, want ErrRecordNotFound", err)
+	}
+
+	a, err := accounts.QueryAccount(ctx, "a1", "", "")
+	if err != nil {
+		t.Fatalf("QueryAccount: %v", err)
+	}
+	if a.Integral != 60 {
+		t.Errorf("Integral = %d, want 60", a.Integral)
+	}
+
+	totals, err := logs.SumActivityLogs(ctx, &biz.ActivityLogQuery{AccountID: "a1"})
+	if err != nil {
+		t.Fatalf("SumActivityLogs: %v", err)
+	}
+	if len(totals) != 2 || totals[0].Count != 2 || totals[0].Integral != 40 || totals[1].Integral != 20 {
+		t.Errorf("SumActivityLogs = %+v, want code 1: 2 logs of 40 points, code 2: 20 points", totals)
+	}
+}
+
+func TestActivityLogRepoQueryActivityLogPages(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, logs := NewAccountRepo(c, d), NewActivityLogRepo(c, d)
+	ctx := tenantCtx("default")
+
+	if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		if err := logs.EarnPoints(ctx, &biz.ActivityLogRequest{AccountID: "a1", ActivityCode: 1, Integral: i}); err != nil {
+			t.Fatalf("EarnPoints: %v", err)
+		}
+	}
+
+	// pages follow each other newest first without gaps, whether or not
+	// logs share a created_at
+	var got []int
+	query := &biz.ActivityLogQuery{AccountID: "a1", Limit: 2}
+	for {
+		page, err := logs.QueryActivityLog(ctx, query)
+		if err != nil {
+			t.Fatalf("QueryActivityLog: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, l := range page {
+			got = append(got, l.Integral)
+		}
+		last := page[len(page)-1]
+		query.After = &biz.ActivityLogCursor{CreatedAt: last.CreateAt, ID: last.ID}
+	}
+	want := []int{5, 4, 3, 2, 1}
+	if len(got) != len(want) {
+		t.Fatalf("pages = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("pages = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AirdropJob struct {
+	gorm.Model
+	TenantID string `gorm:"index;size:64;default:'default'"`
+	JobID    string `json:"job_id" gorm:"uniqueIndex;size:64"`
+	Operator string
+	FileName string
+	State    int
+	Total    int
+}
+
+type AirdropItem struct {
+	gorm.Model
+	TenantID  string `gorm:"index;size:64;default:'default'"`
+	JobID     string `gorm:"uniqueIndex:idx_job_line;index:idx_job_account;size:64"`
+	Line      int    `gorm:"uniqueIndex:idx_job_line"`
+	AccountID string `gorm:"index:idx_job_account;size:255"`
+	Points    int
+	Reason    string
+	State     int `gorm:"index"`
+	Err       string
+}
+
+type airdropRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewAirdropRepo(c *configs.Config, data *Data) biz.AirdropRepo {
+	return &airdropRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func (r *airdropRepo) CreateAirdropJob(ctx context.Context, req *biz.AirdropJobRequest) error {
+	job := &AirdropJob{
+		JobID:    req.JobID,
+		Operator: req.Operator,
+		FileName: req.FileName,
+		State:    biz.AirdropJobPending,
+		Total:    len(req.Items),
+	}
+	items := make([]*AirdropItem, len(req.Items))
+	for i := range req.Items {
+		items[i] = &AirdropItem{
+			JobID:     req.JobID,
+			Line:      req.Items[i].Line,
+			AccountID: req.Items[i].AccountID,
+			Points:    req.Items[i].Points,
+			Reason:    req.Items[i].Reason,
+			State:     req.Items[i].State,
+			Err:       req.Items[i].Err,
+		}
+	}
+
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(job).Error; err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(items, 500).Error
+	})
+}
+
+func (r *airdropRepo) QueryAirdropJob(ctx context.Context, jobID string) (*biz.AirdropJobResponse, error) {
+	var (
+		job    *AirdropJob
+		counts []struct {
+			State int
+			Count int
+		}
+	)
+	if err := r.data.db.WithContext(ctx).Model(&AirdropJob{}).Where("job_id = ?", jobID).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := r.data.db.WithContext(ctx).Model(&AirdropItem{}).Select("state, count(*) as count").
+		Where("job_id = ?", jobID).Group("state").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	res := &biz.AirdropJobResponse{
+		JobID:    job.JobID,
+		Operator: job.Operator,
+		FileName: job.FileName,
+		State:    job.State,
+		Total:    job.Total,
+		CreateAt: job.CreatedAt,
+		UpdateAt: job.UpdatedAt,
+	}
+	for i := range counts {
+		switch counts[i].State {
+		case biz.AirdropItemPending:
+			res.Pending = counts[i].Count
+		case biz.AirdropItemSucceeded:
+			res.Succeeded = counts[i].Count
+		case biz.AirdropItemFailed:
+			res.Failed = counts[i].Count
+		}
+	}
+	return res, nil
+}
+
+func (r *airdropRepo) QueryUnfinishedAirdropJobs(ctx context.Context) ([]string, error) {
+	var ids []string
+	if err := r.data.db.WithContext(ctx).Model(&AirdropJob{}).Where("state <> ?", biz.AirdropJobFinished).
+		Order("id").Pluck("job_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *airdropRepo) UpdateAirdropJobState(ctx context.Context, jobID string, state int) error {
+	return r.data.db.WithContext(ctx).Model(&AirdropJob{}).Where("job_id = ?", jobID).
+		Update("state", state).Error
+}
+
+func (r *airdropRepo) QueryPendingAirdropItems(ctx context.Context, jobID string, limit int) ([]*biz.AirdropItemResponse, error) {
+	var items []*AirdropItem
+	if err := r.data.db.WithContext(ctx).Model(&AirdropItem{}).Where("job_id = ? and state = ?", jobID, biz.AirdropItemPending).
+		Order("line").Limit(limit).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return makeAirdropItemsToBizRes(items), nil
+}
+
+func (r *airdropRepo) QueryAirdropItems(ctx context.Context, jobID string) ([]*biz.AirdropItemResponse, error) {
+	var items []*AirdropItem
+	if err := r.data.db.WithContext(ctx).Model(&AirdropItem{}).Where("job_id = ?", jobID).
+		Order("line").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return makeAirdropItemsToBizRes(items), nil
+}
+
+func (r *airdropRepo) ApplyAirdropItem(ctx context.Context, jobID string, item *biz.AirdropItemResponse) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&AirdropItem{}).Where("job_id = ? and line = ? and state = ?", jobID, item.Line, biz.AirdropItemPending).
+			Update("state", biz.AirdropItemSucceeded)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// already handled by a previous or concurrent run
+			return nil
+		}
+
+		// only accounts that may earn are credited, a suspension past its
+		// expiry counting as lifted as it does for plays
+		res = tx.Model(&Account{}).Where("account_id = ?", item.AccountID).
+			Where("state = ? or (state = ? and state_expire_at <= ?)", biz.AccountStateActive, biz.AccountStateSuspended, time.Now()).
+			Update("integral", gorm.Expr("integral + ?", item.Points))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			reason, err := airdropRejectReason(tx, item.AccountID)
+			if err != nil {
+				return err
+			}
+			return tx.Model(&AirdropItem{}).Where("job_id = ? and line = ?", jobID, item.Line).
+				Updates(map[string]interface{}{"state": biz.AirdropItemFailed, "err": reason}).Error
+		}
+
+		name := biz.AirdropActivityName
+		if item.Reason != "" {
+			name = item.Reason
+		}
+		_, err := addActivityLog(tx, &biz.ActivityLogRequest{
+			AccountID:    item.AccountID,
+			ActivityCode: biz.AirdropActivityCode,
+			ActivityName: name,
+			Integral:     item.Points,
+		})
+		return err
+	})
+}
+
+// airdropRejectReason tells why the account was not credited, for the report.
+func airdropRejectReason(tx *gorm.DB, accountID string) (string, error) {
+	var a Account
+	err := tx.Model(&Account{}).Where("account_id = ?", accountID).First(&a).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "account not exists", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return "account is " + biz.AccountStates[a.State], nil
+}
+
+func makeAirdropItemsToBizRes(items []*AirdropItem) []*biz.AirdropItemResponse {
+	res := make([]*biz.AirdropItemResponse, len(items))
+	for i := range items {
+		res[i] = &biz.AirdropItemResponse{
+			Line:      items[i].Line,
+			AccountID: items[i].AccountID,
+			Points:    items[i].Points,
+			Reason:    items[i].Reason,
+			State:     items[i].State,
+			Err:       items[i].Err,
+		}
+	}
+	return res
+}
@@ -0,0 +1,78 @@
+package data
+
+import (
+	"starland-account/internal/biz"
+	"testing"
+	"time"
+)
+
+func TestAirdropRepoApplyAirdropItem(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, airdrops := NewAccountRepo(c, d), NewAirdropRepo(c, d)
+	ctx := tenantCtx("default")
+
+	states := NewAccountStateRepo(c, d)
+	for _, id := range []string{"a1", "banned", "lapsed"} {
+		if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: id}); err != nil {
+			t.Fatalf("SaveAccount: %v", err)
+		}
+	}
+	expired := time.Now().Add(-time.Minute)
+	for _, req := range []*biz.AccountStateRequest{
+		{AccountID: "banned", State: biz.AccountStateBanned},
+		{AccountID: "lapsed", State: biz.AccountStateSuspended, ExpireAt: &expired},
+	} {
+		if _, err := states.UpdateAccountState(ctx, req); err != nil {
+			t.Fatalf("UpdateAccountState: %v", err)
+		}
+	}
+	err := airdrops.CreateAirdropJob(ctx, &biz.AirdropJobRequest{JobID: "j1", Items: []*biz.AirdropItemRequest{
+		{Line: 1, AccountID: "a1", Points: 10},
+		{Line: 2, AccountID: "missing", Points: 10},
+		{Line: 3, AccountID: "banned", Points: 10},
+		{Line: 4, AccountID: "lapsed", Points: 10},
+	}})
+	if err != nil {
+		t.Fatalf("CreateAirdropJob: %v", err)
+	}
+
+	items, err := airdrops.QueryPendingAirdropItems(ctx, "j1", 10)
+	if err != nil {
+		t.Fatalf("QueryPendingAirdropItems: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("pending items = %d, want 4", len(items))
+	}
+	// applying twice, as a resumed job does, awards once
+	for i := 0; i < 2; i++ {
+		for _, item := range items {
+			if err = airdrops.ApplyAirdropItem(ctx, "j1", item); err != nil {
+				t.Fatalf("ApplyAirdropItem: %v", err)
+			}
+		}
+	}
+
+	a, err := accounts.QueryAccount(ctx, "a1", "", "")
+	if err != nil {
+		t.Fatalf("QueryAccount: %v", err)
+	}
+	if a.Integral != 10 {
+		t.Errorf("Integral = %d, want 10", a.Integral)
+	}
+	job, err := airdrops.QueryAirdropJob(ctx, "j1")
+	if err != nil {
+		t.Fatalf("QueryAirdropJob: %v", err)
+	}
+	if job.Total != 4 || job.Pending != 0 || job.Succeeded != 2 || job.Failed != 2 {
+		t.Errorf("job = %+v, want 2 succeeded and 2 failed of 4", job)
+	}
+	items, err = airdrops.QueryAirdropItems(ctx, "j1")
+	if err != nil {
+		t.Fatalf("QueryAirdropItems: %v", err)
+	}
+	for _, item := range items {
+		if item.AccountID == "banned" && (item.State != biz.AirdropItemFailed || item.Err != "account is banned") {
+			t.Errorf("item of the banned account = %+v, want failed as banned", item)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package data
+
+import (
+	"context"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type DailyActivityStat struct {
+	gorm.Model
+	TenantID     string `gorm:"uniqueIndex:idx_daily_activity_stat;size:64;default:'default'"`
+	Day          string `gorm:"uniqueIndex:idx_daily_activity_stat;size:10"`
+	ActivityCode int    `gorm:"uniqueIndex:idx_daily_activity_stat"`
+	ActivityName string
+	Plays        int64
+	UniqueUsers  int64
+	PointsIssued int64
+}
+
+type DailyAccountStat struct {
+	gorm.Model
+	TenantID      string `gorm:"uniqueIndex:idx_daily_account_stat;size:64;default:'default'"`
+	Day           string `gorm:"uniqueIndex:idx_daily_account_stat;size:10"`
+	AccountID     string `gorm:"uniqueIndex:idx_daily_account_stat;index;size:255"`
+	Plays         int64
+	PointsIssued  int64
+	Claims        int64
+	PointsClaimed int64
+}
+
+type analyticsRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewAnalyticsRepo(c *configs.Config, data *Data) biz.AnalyticsRepo {
+	return &analyticsRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func (r *analyticsRepo) RollupDay(ctx context.Context, day string, from, to time.Time) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var activities []*DailyActivityStat
+		err := tx.Model(&ActivityLog{}).
+			Select("activity_code, max(activity_name) as activity_name, count(*) as plays, "+
+				"count(distinct account_id) as unique_users, coalesce(sum(integral), 0) as points_issued").
+			Where("created_at >= ? and created_at < ?", from, to).
+			Group("activity_code").Scan(&activities).Error
+		if err != nil {
+			return err
+		}
+
+		var plays []*DailyAccountStat
+		err = tx.Model(&ActivityLog{}).
+			Select("account_id, count(*) as plays, coalesce(sum(integral), 0) as points_issued").
+			Where("created_at >= ? and created_at < ?", from, to).
+			Group("account_id").Scan(&plays).Error
+		if err != nil {
+			return err
+		}
+		var claims []*DailyAccountStat
+		err = tx.Model(&ClaimLog{}).
+			Select("account_id, count(*) as claims, coalesce(sum(points), 0) as points_claimed").
+			Where("created_at >= ? and created_at < ?", from, to).
+			Group("account_id").Scan(&claims).Error
+		if err != nil {
+			return err
+		}
+
+		accounts := make(map[string]*DailyAccountStat, len(plays))
+		for _, p := range plays {
+			p.Day = day
+			accounts[p.AccountID] = p
+		}
+		for _, c := range claims {
+			if a, ok := accounts[c.AccountID]; ok {
+				a.Claims, a.PointsClaimed = c.Claims, c.PointsClaimed
+				continue
+			}
+			c.Day = day
+			accounts[c.AccountID] = c
+		}
+		for _, a := range activities {
+			a.Day = day
+		}
+
+		if err = tx.Unscoped().Where("day = ?", day).Delete(&DailyActivityStat{}).Error; err != nil {
+			return err
+		}
+		if err = tx.Unscoped().Where("day = ?", day).Delete(&DailyAccountStat{}).Error; err != nil {
+			return err
+		}
+		if len(activities) > 0 {
+			if err = tx.CreateInBatches(activities, 500).Error; err != nil {
+				return err
+			}
+		}
+		if len(accounts) == 0 {
+			return nil
+		}
+		rows := make([]*DailyAccountStat, 0, len(accounts))
+		for _, a := range accounts {
+			rows = append(rows, a)
+		}
+		return tx.CreateInBatches(rows, 500).Error
+	})
+}
+
+func (r *analyticsRepo) LatestRollupDay(ctx context.Context) (string, error) {
+	var days []string
+	err := r.data.db.WithContext(ctx).Model(&DailyActivityStat{}).
+		Order("day desc").Limit(1).Pluck("day", &days).Error
+	if err != nil || len(days) == 0 {
+		return "", err
+	}
+	return days[0], nil
+}
+
+func (r *analyticsRepo) QueryActivityStats(ctx context.Context, from, to string, activityCode *int) ([]*biz.DailyActivityStat, error) {
+	db := r.data.db.WithContext(ctx).Model(&DailyActivityStat{}).Where("day >= ? and day <= ?", from, to)
+	if activityCode != nil {
+		db = db.Where("activity_code = ?", *activityCode)
+	}
+	var stats []*DailyActivityStat
+	if err := db.Order("day, activity_code").Find(&stats).Error; err != nil {
+		return nil, err
+	}
+	res := make([]*biz.DailyActivityStat, len(stats))
+	for i := range stats {
+		res[i] = &biz.DailyActivityStat{
+			Day:          stats[i].Day,
+			ActivityCode: stats[i].ActivityCode,
+			ActivityName: stats[i].ActivityName,
+			Plays:        stats[i].Plays,
+			UniqueUsers:  stats[i].UniqueUsers,
+			PointsIssued: stats[i].PointsIssued,
+		}
+	}
+	return res, nil
+}
+
+func (r *analyticsRepo) QueryAccountStats(ctx context.Context, accountID, from, to string) ([]*biz.DailyAccountStat, error) {
+	var stats []*DailyAccountStat
+	err := r.data.db.WithContext(ctx).Model(&DailyAccountStat{}).
+		Where("account_id = ? and day >= ? and day <= ?", accountID, from, to).
+		Order("day").Find(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*biz.DailyAccountStat, len(stats))
+	for i := range stats {
+		res[i] = &biz.DailyAccountStat{
+			Day:           stats[i].Day,
+			AccountID:     stats[i].AccountID,
+			Plays:         stats[i].Plays,
+			PointsIssued:  stats[i].PointsIssued,
+			Claims:        stats[i].Claims,
+			PointsClaimed: stats[i].PointsClaimed,
+		}
+	}
+	return res, nil
+}
+
+func (r *analyticsRepo) QueryDailyTotals(ctx context.Context, from, to string) ([]*biz.DailyTotal, error) {
+	var res []*biz.DailyTotal
+	err := r.data.db.WithContext(ctx).Model(&DailyAccountStat{}).
+		Select("day, coalesce(sum(plays), 0) as plays, sum(case when plays > 0 then 1 else 0 end) as unique_users, "+
+			"coalesce(sum(points_issued), 0) as points_issued, coalesce(sum(points_claimed), 0) as points_claimed").
+		Where("day >= ? and day <= ?", from, to).
+		Group("day").Order("day").Scan(&res).Error
+	return res, err
+}
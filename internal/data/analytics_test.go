@@ -0,0 +1,60 @@
+package data
+
+import (
+	"starland-account/internal/biz"
+	"testing"
+	"time"
+)
+
+func TestAnalyticsRepoRollupDay(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, logs, analytics := NewAccountRepo(c, d), NewActivityLogRepo(c, d), NewAnalyticsRepo(c, d)
+	ctx := tenantCtx("default")
+
+	for _, id := range []string{"a1", "a2"} {
+		if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: id}); err != nil {
+			t.Fatalf("SaveAccount(%s): %v", id, err)
+		}
+	}
+	for _, l := range []struct {
+		account string
+		code    int
+		points  int
+	}{{"a1", 1, 10}, {"a1", 1, 10}, {"a2", 1, 5}, {"a2", 2, 7}} {
+		err := logs.EarnPoints(ctx, &biz.ActivityLogRequest{AccountID: l.account, ActivityCode: l.code, ActivityName: "play", Integral: l.points})
+		if err != nil {
+			t.Fatalf("EarnPoints: %v", err)
+		}
+	}
+	if _, err := accounts.UpdateClaimPoints(ctx, "a1", 15); err != nil {
+		t.Fatalf("UpdateClaimPoints: %v", err)
+	}
+
+	now := time.Now()
+	day := now.Format(biz.StatDayLayout)
+	from, to := now.Add(-time.Hour), now.Add(time.Hour)
+	// a second rollup replaces the first
+	for i := 0; i < 2; i++ {
+		if err := analytics.RollupDay(ctx, day, from, to); err != nil {
+			t.Fatalf("RollupDay: %v", err)
+		}
+	}
+
+	if latest, err := analytics.LatestRollupDay(ctx); err != nil || latest != day {
+		t.Errorf("LatestRollupDay = %q, %v, want %q", latest, err, day)
+	}
+	stats, err := analytics.QueryActivityStats(ctx, day, day, nil)
+	if err != nil {
+		t.Fatalf("QueryActivityStats: %v", err)
+	}
+	if len(stats) != 2 || stats[0].Plays != 3 || stats[0].UniqueUsers != 2 || stats[0].PointsIssued != 25 {
+		t.Errorf("QueryActivityStats = %+v, want activity 1: 3 plays by 2 users for 25 points", stats)
+	}
+	totals, err := analytics.QueryDailyTotals(ctx, day, day)
+	if err != nil {
+		t.Fatalf("QueryDailyTotals: %v", err)
+	}
+	if len(totals) != 1 || totals[0].Plays != 4 || totals[0].UniqueUsers != 2 || totals[0].PointsIssued != 32 || totals[0].PointsClaimed != 15 {
+		t.Errorf("QueryDailyTotals = %+v, want 4 plays by 2 users, 32 issued, 15 claimed", totals)
+	}
+}
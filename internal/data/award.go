@@ -0,0 +1,141 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"time"
+
+	"github.com/go-redis/redis"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	awardQuotaKey = "starland-account:award:quota:%s:%s"
+	awardQuotaTTL = 48 * time.Hour
+)
+
+// awardQuotaScript adds ARGV[1] to the day's usage unless it would go over
+// ARGV[2], returning 1 when it did.
+var awardQuotaScript = redis.NewScript(`
+local used = tonumber(redis.call('GET', KEYS[1]) or '0')
+if used + tonumber(ARGV[1]) > tonumber(ARGV[2]) then
+	return 0
+end
+redis.call('INCRBY', KEYS[1], ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`)
+
+type Award struct {
+	gorm.Model
+	TenantID      string `gorm:"index;size:64;default:'default'"`
+	ClientID      string `gorm:"uniqueIndex:idx_award_ref;size:64"`
+	ExternalRef   string `gorm:"uniqueIndex:idx_award_ref;size:128"`
+	AccountID     string `gorm:"index;size:255"`
+	ActivityCode  int
+	Points        int
+	ActivityLogID uint
+}
+
+type awardRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewAwardRepo(c *configs.Config, data *Data) biz.AwardRepo {
+	return &awardRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func (r *awardRepo) QueryAward(ctx context.Context, clientID, ref string) (*biz.Award, error) {
+	return queryAward(r.data.db.WithContext(ctx), clientID, ref)
+}
+
+func (r *awardRepo) AddAward(ctx context.Context, req *biz.AwardRequest) (*biz.Award, bool, error) {
+	var (
+		award   *biz.Award
+		created bool
+	)
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row := &Award{
+			ClientID:     req.ClientID,
+			ExternalRef:  req.ExternalRef,
+			AccountID:    req.AccountID,
+			ActivityCode: req.ActivityCode,
+			Points:       req.Points,
+		}
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(row)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// lost a race with a retry of the same ref
+			var err error
+			award, err = queryAward(tx, req.ClientID, req.ExternalRef)
+			return err
+		}
+
+		actlog, err := earnPoints(tx, &biz.ActivityLogRequest{
+			AccountID:    req.AccountID,
+			ActivityCode: req.ActivityCode,
+			ActivityName: req.ActivityName,
+			Integral:     req.Points,
+			ClientID:     req.ClientID,
+		})
+		if err != nil {
+			return err
+		}
+		row.ActivityLogID = actlog.ID
+		if err = tx.Model(row).Update("activity_log_id", actlog.ID).Error; err != nil {
+			return err
+		}
+		award, created = makeAward(row), true
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return award, created, nil
+}
+
+func (r *awardRepo) ReserveAwardQuota(ctx context.Context, clientID, day string, points, limit int) (bool, error) {
+	key := fmt.Sprintf(awardQuotaKey, clientID, day)
+	n, err := awardQuotaScript.Run(r.data.rdb.WithContext(ctx), []string{key}, points, limit, awardQuotaTTL.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *awardRepo) ReleaseAwardQuota(ctx context.Context, clientID, day string, points int) error {
+	return r.data.rdb.WithContext(ctx).DecrBy(fmt.Sprintf(awardQuotaKey, clientID, day), int64(points)).Err()
+}
+
+func queryAward(db *gorm.DB, clientID, ref string) (*biz.Award, error) {
+	var award *Award
+	if err := db.Model(&Award{}).Where("client_id = ? and external_ref = ?", clientID, ref).First(&award).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return makeAward(award), nil
+}
+
+func makeAward(award *Award) *biz.Award {
+	return &biz.Award{
+		ClientID:      award.ClientID,
+		ExternalRef:   award.ExternalRef,
+		AccountID:     award.AccountID,
+		ActivityCode:  award.ActivityCode,
+		Points:        award.Points,
+		ActivityLogID: award.ActivityLogID,
+		CreateAt:      award.CreatedAt,
+	}
+}
@@ -0,0 +1,63 @@
+package data
+
+import (
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"testing"
+)
+
+func TestAwardRepoAddAward(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, awards := NewAccountRepo(c, d), NewAwardRepo(c, d)
+	ctx := tenantCtx("default")
+
+	if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	req := &biz.AwardRequest{ClientID: "partner", ExternalRef: "ref-1", AccountID: "a1", ActivityCode: 1000, Points: 25}
+	first, created, err := awards.AddAward(ctx, req)
+	if err != nil || !created {
+		t.Fatalf("AddAward = %v, %v, want created", created, err)
+	}
+	again, created, err := awards.AddAward(ctx, req)
+	if err != nil || created {
+		t.Fatalf("AddAward(duplicate) = %v, %v, want not created", created, err)
+	}
+	if again.ActivityLogID != first.ActivityLogID {
+		t.Errorf("duplicate ActivityLogID = %d, want %d", again.ActivityLogID, first.ActivityLogID)
+	}
+
+	a, err := accounts.QueryAccount(ctx, "a1", "", "")
+	if err != nil {
+		t.Fatalf("QueryAccount: %v", err)
+	}
+	if a.Integral != 25 {
+		t.Errorf("Integral = %d, want 25", a.Integral)
+	}
+}
+
+func TestAwardRepoAddAwardOtherTenant(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, awards := NewAccountRepo(c, d), NewAwardRepo(c, d)
+	for _, id := range []string{"default", "other"} {
+		if err := accounts.SaveAccount(tenantCtx(id), &biz.AccountRequest{AccountID: "a1"}); err != nil {
+			t.Fatalf("SaveAccount: %v", err)
+		}
+	}
+	req := &biz.AwardRequest{ClientID: "partner", ExternalRef: "ref-1", AccountID: "a1", ActivityCode: 1000, Points: 25}
+	if _, created, err := awards.AddAward(tenantCtx("other"), req); err != nil || !created {
+		t.Fatalf("AddAward in other = %v, %v, want created", created, err)
+	}
+
+	// the ref is taken, but by an award this tenant can't see
+	award, created, err := awards.AddAward(tenantCtx("default"), req)
+	if err != nil || created || award != nil {
+		t.Fatalf("AddAward in default = %v, %v, %v, want not created without an award", award, created, err)
+	}
+	if res := biz.NewAwardUsecase(awards).Award(tenantCtx("default"), req, 0); res.Err != bizerr.ErrAwardRefConflict {
+		t.Errorf("Award in default = %+v, want ErrAwardRefConflict", res)
+	}
+	if a, err := accounts.QueryAccount(tenantCtx("default"), "a1", "", ""); err != nil || a.Integral != 0 {
+		t.Errorf("default a1 = %v, %v, want nothing credited", a, err)
+	}
+}
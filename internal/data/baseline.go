@@ -0,0 +1,254 @@
+package data
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The baseline schema, frozen at migration 1. These copies of the models as
+// they were then must not change with the models: a column or index added
+// later goes in a migration of its own.
+
+type baselineAccount struct {
+	ID              uint      `gorm:"primarykey"`
+	CreatedAt       time.Time `gorm:"index"`
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+	TenantID        string         `gorm:"index;size:64;default:'default'"`
+	AccountID       string         `gorm:"primary_key;index;size:255"`
+	Integral        int            `gorm:"index"`
+	Received        int            `gorm:"index"`
+	Email           string         `gorm:"index:idx_member"`
+	Name            string         `gorm:"index;size:255"`
+	Provider        string         `gorm:"index:idx_member"`
+	AvatarURL       string
+	State           int `gorm:"index"`
+	StateReason     string
+	StateActor      string
+	StateExpireAt   *time.Time
+	SolanaAddr      string `gorm:"index;size:64"`
+	ClaimCount      int
+	EmailVerifiedAt *time.Time
+}
+
+func (baselineAccount) TableName() string { return "accounts" }
+
+type baselineActivity struct {
+	gorm.Model
+	UUID         string `gorm:"primary_key;size:255"`
+	TenantID     string `gorm:"index;size:64;default:'default'"`
+	ActivityCode int
+	ActivityName string
+	Integral     int
+	Limit        int
+}
+
+func (baselineActivity) TableName() string { return "activities" }
+
+type baselineActivityLog struct {
+	ID           uint      `gorm:"primarykey"`
+	CreatedAt    time.Time `gorm:"index:idx_activity_log_account,priority:2"`
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	UUID         string         `gorm:"primary_key;size:255"`
+	TenantID     string         `gorm:"index;size:64;default:'default'"`
+	AccountID    string         `gorm:"index:idx_activity_log_account,priority:1;size:255"`
+	ActivityCode int
+	ActivityName string
+	Integral     int
+	ClientID     string `gorm:"size:64"`
+}
+
+func (baselineActivityLog) TableName() string { return "activity_logs" }
+
+type baselineAirdropJob struct {
+	gorm.Model
+	TenantID string `gorm:"index;size:64;default:'default'"`
+	JobID    string `gorm:"uniqueIndex;size:64"`
+	Operator string
+	FileName string
+	State    int
+	Total    int
+}
+
+func (baselineAirdropJob) TableName() string { return "airdrop_jobs" }
+
+type baselineAirdropItem struct {
+	gorm.Model
+	TenantID  string `gorm:"index;size:64;default:'default'"`
+	JobID     string `gorm:"uniqueIndex:idx_job_line;index:idx_job_account;size:64"`
+	Line      int    `gorm:"uniqueIndex:idx_job_line"`
+	AccountID string `gorm:"index:idx_job_account;size:255"`
+	Points    int
+	Reason    string
+	State     int `gorm:"index"`
+	Err       string
+}
+
+func (baselineAirdropItem) TableName() string { return "airdrop_items" }
+
+type baselineAccountStateLog struct {
+	gorm.Model
+	TenantID  string `gorm:"index;size:64;default:'default'"`
+	AccountID string `gorm:"index;size:255"`
+	FromState int
+	ToState   int
+	Reason    string
+	Actor     string
+	ExpireAt  *time.Time
+}
+
+func (baselineAccountStateLog) TableName() string { return "account_state_logs" }
+
+type baselineAccountAppeal struct {
+	gorm.Model
+	TenantID  string `gorm:"index;size:64;default:'default'"`
+	AppealID  string `gorm:"uniqueIndex;size:64"`
+	AccountID string `gorm:"index;size:255"`
+	Content   string `gorm:"size:2048"`
+	State     int    `gorm:"index"`
+	Actor     string
+	Reply     string `gorm:"size:2048"`
+}
+
+func (baselineAccountAppeal) TableName() string { return "account_appeals" }
+
+type baselineRiskReview struct {
+	gorm.Model
+	TenantID     string `gorm:"index;size:64;default:'default'"`
+	AccountID    string `gorm:"index;size:255"`
+	ActivityCode int
+	IP           string
+	DeviceID     string
+	Score        int
+	Action       string
+	Hits         string
+	State        int `gorm:"index"`
+	Actor        string
+}
+
+func (baselineRiskReview) TableName() string { return "risk_reviews" }
+
+type baselineClaimLog struct {
+	gorm.Model
+	TenantID   string `gorm:"index;size:64;default:'default'"`
+	AccountID  string `gorm:"index;size:255"`
+	Points     int
+	Received   int
+	SolanaAddr string
+	ClaimCount int
+}
+
+func (baselineClaimLog) TableName() string { return "claim_logs" }
+
+type baselineProfileChangeLog struct {
+	gorm.Model
+	TenantID  string `gorm:"index;size:64;default:'default'"`
+	AccountID string `gorm:"index:idx_profile_change;size:255"`
+	Field     string `gorm:"index:idx_profile_change;size:32"`
+	OldValue  string `gorm:"size:1024"`
+	NewValue  string `gorm:"size:1024"`
+	Actor     string
+}
+
+func (baselineProfileChangeLog) TableName() string { return "profile_change_logs" }
+
+type baselineDailyActivityStat struct {
+	gorm.Model
+	TenantID     string `gorm:"uniqueIndex:idx_daily_activity_stat;size:64;default:'default'"`
+	Day          string `gorm:"uniqueIndex:idx_daily_activity_stat;size:10"`
+	ActivityCode int    `gorm:"uniqueIndex:idx_daily_activity_stat"`
+	ActivityName string
+	Plays        int64
+	UniqueUsers  int64
+	PointsIssued int64
+}
+
+func (baselineDailyActivityStat) TableName() string { return "daily_activity_stats" }
+
+type baselineDailyAccountStat struct {
+	gorm.Model
+	TenantID      string `gorm:"uniqueIndex:idx_daily_account_stat;size:64;default:'default'"`
+	Day           string `gorm:"uniqueIndex:idx_daily_account_stat;size:10"`
+	AccountID     string `gorm:"uniqueIndex:idx_daily_account_stat;index;size:255"`
+	Plays         int64
+	PointsIssued  int64
+	Claims        int64
+	PointsClaimed int64
+}
+
+func (baselineDailyAccountStat) TableName() string { return "daily_account_stats" }
+
+type baselineOutboxEvent struct {
+	gorm.Model
+	EventID       string `gorm:"uniqueIndex;size:64"`
+	Type          string `gorm:"size:64"`
+	AggregateID   string `gorm:"size:255"`
+	Payload       string `gorm:"type:text"`
+	Attempts      int
+	LastErr       string     `gorm:"size:1024"`
+	NextAttemptAt time.Time  `gorm:"index:idx_outbox_pending,priority:2"`
+	PublishedAt   *time.Time `gorm:"index:idx_outbox_pending,priority:1"`
+}
+
+func (baselineOutboxEvent) TableName() string { return "outbox_events" }
+
+type baselineWebhookSubscription struct {
+	gorm.Model
+	TenantID            string `gorm:"index;size:64;default:'default'"`
+	SubscriptionID      string `gorm:"uniqueIndex;size:64"`
+	URL                 string `gorm:"size:1024"`
+	Secret              string `gorm:"size:255"`
+	Events              string `gorm:"size:1024"`
+	Description         string
+	Enabled             bool `gorm:"index"`
+	ConsecutiveFailures int
+	DisabledReason      string
+}
+
+func (baselineWebhookSubscription) TableName() string { return "webhook_subscriptions" }
+
+type baselineWebhookDelivery struct {
+	gorm.Model
+	TenantID       string `gorm:"index;size:64;default:'default'"`
+	DeliveryID     string `gorm:"uniqueIndex;size:64"`
+	SubscriptionID string `gorm:"uniqueIndex:idx_webhook_delivery_event;size:64"`
+	EventID        string `gorm:"uniqueIndex:idx_webhook_delivery_event;size:64"`
+	EventType      string `gorm:"size:64"`
+	Payload        string `gorm:"type:text"`
+	State          int    `gorm:"index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int
+	NextAttemptAt  time.Time `gorm:"index:idx_webhook_delivery_due,priority:2"`
+	LastStatus     int
+	LastErr        string `gorm:"size:1024"`
+	DeliveredAt    *time.Time
+}
+
+func (baselineWebhookDelivery) TableName() string { return "webhook_deliveries" }
+
+type baselineWebhookAttempt struct {
+	gorm.Model
+	DeliveryID string `gorm:"index;size:64"`
+	Attempt    int
+	StatusCode int
+	Err        string `gorm:"size:1024"`
+	DurationMs int64
+}
+
+func (baselineWebhookAttempt) TableName() string { return "webhook_attempts" }
+
+type baselineAward struct {
+	gorm.Model
+	TenantID      string `gorm:"index;size:64;default:'default'"`
+	ClientID      string `gorm:"uniqueIndex:idx_award_ref;size:64"`
+	ExternalRef   string `gorm:"uniqueIndex:idx_award_ref;size:128"`
+	AccountID     string `gorm:"index;size:255"`
+	ActivityCode  int
+	Points        int
+	ActivityLogID uint
+}
+
+func (baselineAward) TableName() string { return "awards" }
+
+var baselineModels = []any{&baselineAccount{}, &baselineActivity{}, &baselineActivityLog{}, &baselineAirdropJob{}, &baselineAirdropItem{}, &baselineAccountStateLog{}, &baselineAccountAppeal{}, &baselineRiskReview{}, &baselineClaimLog{}, &baselineProfileChangeLog{}, &baselineDailyActivityStat{}, &baselineDailyAccountStat{}, &baselineOutboxEvent{}, &baselineWebhookSubscription{}, &baselineWebhookDelivery{}, &baselineWebhookAttempt{}, &baselineAward{}}
@@ -1,68 +1,132 @@
 package data
 
 import (
+	"context"
+	"fmt"
+	slog "log"
+	"os"
+	"starland-account/configs"
+	"time"
+
 	"github.com/go-redis/redis"
 	"github.com/google/wire"
+	"go.uber.org/zap"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
+)
 
-	slog "log"
-	"starland-account/configs"
-
-	"go.uber.org/zap"
-
-	"os"
-	"time"
+// Database drivers of data.db.driver.
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
 )
 
-var ProviderSet = wire.NewSet(NewData, NewAccountRepo, NewActivityRepo, NewActivityLogRepo)
+var ProviderSet = wire.NewSet(NewData, NewAccountRepo, NewActivityRepo, NewActivityLogRepo, NewAirdropRepo, NewAccountStateRepo, NewRiskRepo, NewRateLimitRepo, NewProfileRepo, NewVerifyRepo, NewAnalyticsRepo, NewOutboxRepo, NewWebhookRepo, NewAwardRepo, NewLeaseRepo)
 
 type Data struct {
 	db  *gorm.DB
 	rdb *redis.Client
 }
 
-func NewData(c *configs.Config) *Data {
-	return &Data{
-		db:  NewDB(c),
+// NewData connects to the database and Redis; the cleanup closes both.
+func NewData(c *configs.Config) (*Data, func(), error) {
+	db, err := NewDB(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	d := &Data{
+		db:  db,
 		rdb: NewRedis(c),
 	}
+	cleanup := func() {
+		if sqlDB, err := d.db.DB(); err == nil {
+			if err = sqlDB.Close(); err != nil {
+				zap.S().Errorf("NewData: close db err: %v", err)
+			}
+		}
+		if err := d.rdb.Close(); err != nil {
+			zap.S().Errorf("NewData: close redis err: %v", err)
+		}
+	}
+	return d, cleanup, nil
+}
+
+// NewDB opens the database of the configured driver, mysql by default, and
+// applies the pending migrations unless data.db.skip_migrate is set.
+func NewDB(c *configs.Config) (*gorm.DB, error) {
+	db, err := OpenDB(c)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), MigrationLockWait)
+	defer cancel()
+	if !c.Data.DB.SkipMigrate {
+		if _, err = MigrateUp(ctx, db, 0); err != nil {
+			return nil, fmt.Errorf("NewDB: migrate err: %w", err)
+		}
+		return db, nil
+	}
+	n, err := pendingMigrations(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("NewDB: check migrations err: %w", err)
+	}
+	if n > 0 {
+		return nil, fmt.Errorf("NewDB: %d migrations pending, run the migrate command", n)
+	}
+	return db, nil
 }
 
-// NewDB .
-func NewDB(c *configs.Config) *gorm.DB {
+// OpenDB opens the database of the configured driver without migrating it.
+func OpenDB(c *configs.Config) (*gorm.DB, error) {
 	newLogger := logger.New(
 		slog.New(os.Stdout, "\r\n", slog.LstdFlags), // io writer
 		logger.Config{
-			SlowThreshold: time.Second, 
-			Colorful:      true,       
+			SlowThreshold: time.Second,
+			Colorful:      true,
 			//IgnoreRecordNotFoundError: false,
 			LogLevel: logger.Info, // Log lever
 		},
 	)
 
-	db, err := gorm.Open(mysql.Open(c.Data.DB.Source), &gorm.Config{
+	dialector, err := openDialector(&c.Data.DB)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{
 		Logger:                                   newLogger,
 		DisableForeignKeyConstraintWhenMigrating: true,
-		NamingStrategy:                           schema.NamingStrategy{
-		},
+		NamingStrategy:                           schema.NamingStrategy{},
 	})
-
 	if err != nil {
-		zap.S().Errorf("failed opening connection to sqlite: %v", err)
-		panic("failed to connect database")
+		return nil, fmt.Errorf("OpenDB: open %s err: %w", dialector.Name(), err)
 	}
 
-	if err = db.AutoMigrate(&Account{},&Activity{},&ActivityLog{}); err != nil {
-		zap.S().Errorf("failed to migrate db: %v", err)
-		panic("failed to connect database")
+	if err = registerTenantScope(db); err != nil {
+		return nil, fmt.Errorf("OpenDB: register tenant scope err: %w", err)
 	}
+	return db, nil
+}
 
-	return db
+func openDialector(c *configs.DBConfig) (gorm.Dialector, error) {
+	switch c.Driver {
+	case "", DriverMySQL:
+		return mysql.Open(c.Source), nil
+	case DriverPostgres:
+		return postgres.Open(c.Source), nil
+	case DriverSQLite:
+		return sqlite.Open(c.Source), nil
+	}
+	return nil, fmt.Errorf("openDialector: unknown driver %q", c.Driver)
 }
 
+// models are the tables the repos use, brought up to date by the migrations.
+var models = []any{&Account{}, &Activity{}, &ActivityLog{}, &AirdropJob{}, &AirdropItem{}, &AccountStateLog{}, &AccountAppeal{}, &RiskReview{}, &ClaimLog{}, &ProfileChangeLog{}, &DailyActivityStat{}, &DailyAccountStat{}, &OutboxEvent{}, &WebhookSubscription{}, &WebhookDelivery{}, &WebhookAttempt{}, &Award{}, &LeaderFence{}}
+
 func NewRedis(cfg *configs.Config) *redis.Client {
 	rdb := redis.NewClient(&redis.Options{
 		Addr:     cfg.Data.Redis.Host,
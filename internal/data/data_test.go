@@ -0,0 +1,44 @@
+package data
+
+import (
+	"context"
+	"os"
+	"starland-account/configs"
+	"starland-account/internal/pkg/tenant"
+	"strings"
+	"testing"
+)
+
+// newTestData opens a private in-memory database; DB_DRIVER and DB_SOURCE
+// run the suite against another database instead. Redis is not available, so
+// only the SQL side of the repos is covered.
+func newTestData(t *testing.T) (*configs.Config, *Data) {
+	t.Helper()
+	c := &configs.Config{Data: &configs.DataConfig{DB: configs.DBConfig{
+		Driver: DriverSQLite,
+		Source: "file:" + strings.ReplaceAll(t.Name(), "/", "_") + "?mode=memory&cache=shared",
+	}}}
+	if driver := os.Getenv("DB_DRIVER"); driver != "" {
+		c.Data.DB = configs.DBConfig{Driver: driver, Source: os.Getenv("DB_SOURCE")}
+	}
+	db, err := NewDB(c)
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("DB: %v", err)
+	}
+	t.Cleanup(func() {
+		if c.Data.DB.Driver != DriverSQLite {
+			// an in-memory database goes with its last connection, others are reset
+			_ = db.Migrator().DropTable(append(models, &SchemaMigration{}, &SchemaLock{})...)
+		}
+		sqlDB.Close()
+	})
+	return c, &Data{db: db}
+}
+
+func tenantCtx(id string) context.Context {
+	return tenant.NewContext(context.Background(), id)
+}
@@ -0,0 +1,139 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// The lease key holds "<token> <owner>" and expires unless renewed; the
+// tokens are counted in a key of their own, which never expires, so they keep
+// growing across terms.
+var (
+	acquireLeaseScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+local token = redis.call('INCR', KEYS[2])
+redis.call('SET', KEYS[1], token .. ' ' .. ARGV[1], 'PX', ARGV[2])
+return token
+`)
+	renewLeaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+	releaseLeaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+)
+
+// LeaderFence holds the newest token that wrote under a lease key; a write
+// under an older one comes from a leader that lost its lease.
+type LeaderFence struct {
+	LeaseKey string `gorm:"primarykey;size:255"`
+	Token    int64
+}
+
+// fenceLease fails with biz.ErrLeaseStale when a newer term than the lease
+// ctx's work is done under already wrote, and otherwise records its token.
+// The row stays locked until tx ends, so a stale leader's write can't
+// commit after the next leader's began. Work outside of a lease passes.
+func fenceLease(ctx context.Context, tx *gorm.DB) error {
+	lease := biz.LeaseFromContext(ctx)
+	if lease == nil {
+		return nil
+	}
+	fence := &LeaderFence{LeaseKey: lease.Key, Token: lease.Token}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(fence).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&LeaderFence{}).Where("lease_key = ? and token <= ?", lease.Key, lease.Token).
+		Update("token", lease.Token).Error; err != nil {
+		return err
+	}
+	// MySQL counts no row when the token was already set, so read it back
+	if err := tx.Where("lease_key = ?", lease.Key).First(fence).Error; err != nil {
+		return err
+	}
+	if fence.Token != lease.Token {
+		return fmt.Errorf("token %d after %d: %w", fence.Token, lease.Token, biz.ErrLeaseStale)
+	}
+	return nil
+}
+
+type leaseRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewLeaseRepo(c *configs.Config, data *Data) biz.LeaseRepo {
+	return &leaseRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func (r *leaseRepo) AcquireLease(ctx context.Context, key, owner string, ttl time.Duration) (*biz.Lease, error) {
+	token, err := acquireLeaseScript.Run(r.data.rdb.WithContext(ctx), []string{key, key + ":token"},
+		owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return nil, err
+	}
+	if token == 0 {
+		return nil, nil
+	}
+	return &biz.Lease{Key: key, Owner: owner, Token: token, TTL: ttl}, nil
+}
+
+func (r *leaseRepo) RenewLease(ctx context.Context, lease *biz.Lease, ttl time.Duration) (bool, error) {
+	n, err := renewLeaseScript.Run(r.data.rdb.WithContext(ctx), []string{lease.Key},
+		leaseValue(lease), ttl.Milliseconds()).Int64()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *leaseRepo) ReleaseLease(ctx context.Context, lease *biz.Lease) error {
+	return releaseLeaseScript.Run(r.data.rdb.WithContext(ctx), []string{lease.Key}, leaseValue(lease)).Err()
+}
+
+func (r *leaseRepo) QueryLease(ctx context.Context, key string) (*biz.Lease, error) {
+	pipe := r.data.rdb.WithContext(ctx).TxPipeline()
+	get := pipe.Get(key)
+	ttl := pipe.PTTL(key)
+	if _, err := pipe.Exec(); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	val, err := get.Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	token, owner, _ := strings.Cut(val, " ")
+	n, err := strconv.ParseInt(token, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse lease %q err: %w", val, err)
+	}
+	return &biz.Lease{Key: key, Owner: owner, Token: n, TTL: ttl.Val()}, nil
+}
+
+func leaseValue(lease *biz.Lease) string {
+	return strconv.FormatInt(lease.Token, 10) + " " + lease.Owner
+}
@@ -0,0 +1,55 @@
+package data
+
+import (
+	"errors"
+	"starland-account/internal/biz"
+	"testing"
+	"time"
+)
+
+func TestFenceLease(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, states := NewAccountRepo(c, d), NewAccountStateRepo(c, d)
+	ctx := tenantCtx("default")
+	for _, id := range []string{"a1", "a2"} {
+		if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: id}); err != nil {
+			t.Fatalf("SaveAccount: %v", err)
+		}
+	}
+	if err := accounts.EraseAccount(ctx, &biz.AccountStateRequest{AccountID: "a2", State: biz.AccountStateDeleted, Actor: "a2"}); err != nil {
+		t.Fatalf("EraseAccount: %v", err)
+	}
+	term := func(token int64) *biz.Lease { return &biz.Lease{Key: "test:leader", Owner: "a", Token: token} }
+	ban := &biz.AccountStateRequest{AccountID: "a1", State: biz.AccountStateBanned, Actor: "chain"}
+
+	// the next leader bans, then the stalled one writes
+	if ok, err := states.UpdateAccountState(biz.NewLeaseContext(ctx, term(2)), ban); err != nil || !ok {
+		t.Fatalf("UpdateAccountState of term 2 = %v, %v", ok, err)
+	}
+	if _, err := states.UpdateAccountState(biz.NewLeaseContext(ctx, term(2)), ban); err != nil {
+		t.Errorf("UpdateAccountState of term 2 again: %v", err)
+	}
+	unban := &biz.AccountStateRequest{AccountID: "a1", State: biz.AccountStateActive, From: []int{biz.AccountStateBanned}}
+	if _, err := states.UpdateAccountState(biz.NewLeaseContext(ctx, term(1)), unban); !errors.Is(err, biz.ErrLeaseStale) {
+		t.Errorf("UpdateAccountState of term 1 = %v, want ErrLeaseStale", err)
+	}
+	if got, err := accounts.QueryAccount(ctx, "a1", "", ""); err != nil || got.State != biz.AccountStateBanned {
+		t.Errorf("state = %v, %v, want the stale write refused", got, err)
+	}
+
+	future := time.Now().Add(time.Hour)
+	if _, err := accounts.PurgeAccounts(biz.NewLeaseContext(ctx, term(1)), future); !errors.Is(err, biz.ErrLeaseStale) {
+		t.Errorf("PurgeAccounts of term 1 = %v, want ErrLeaseStale", err)
+	}
+	if erased, err := accounts.AccountErased(ctx, "a2"); err != nil || !erased {
+		t.Errorf("AccountErased = %v, %v, want a2 kept", erased, err)
+	}
+	if n, err := accounts.PurgeAccounts(biz.NewLeaseContext(ctx, term(3)), future); err != nil || n != 1 {
+		t.Errorf("PurgeAccounts of term 3 = %d, %v, want 1", n, err)
+	}
+
+	// work outside of a lease isn't fenced
+	if ok, err := states.UpdateAccountState(ctx, unban); err != nil || !ok {
+		t.Errorf("UpdateAccountState outside of a term = %v, %v", ok, err)
+	}
+}
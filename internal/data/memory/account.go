@@ -0,0 +1,309 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"starland-account/internal/biz"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type account struct {
+	biz.AccountResponse
+	claims    []*biz.ClaimLogResponse
+	deletedAt *time.Time
+}
+
+// AccountRepo is an in-memory biz.AccountRepo.
+type AccountRepo struct {
+	mu       sync.Mutex
+	accounts map[string]map[string]*account
+}
+
+func NewAccountRepo() *AccountRepo {
+	return &AccountRepo{accounts: make(map[string]map[string]*account)}
+}
+
+// find returns the live account of the context's tenant, nil if there is none.
+func (r *AccountRepo) find(ctx context.Context, accountID string) (*account, error) {
+	id, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if a := r.accounts[id][accountID]; a != nil && a.deletedAt == nil {
+		return a, nil
+	}
+	return nil, nil
+}
+
+func (r *AccountRepo) list(ctx context.Context) ([]*account, error) {
+	id, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*account, 0, len(r.accounts[id]))
+	for _, a := range r.accounts[id] {
+		if a.deletedAt == nil {
+			res = append(res, a)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].AccountID < res[j].AccountID })
+	return res, nil
+}
+
+func (r *AccountRepo) SaveAccount(ctx context.Context, req *biz.AccountRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, err := r.find(ctx, req.AccountID)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		id, _ := tenantID(ctx)
+		// account_id is unique with the erased accounts too, until purged
+		if r.accounts[id][req.AccountID] != nil {
+			return gorm.ErrDuplicatedKey
+		}
+		if r.accounts[id] == nil {
+			r.accounts[id] = make(map[string]*account)
+		}
+		a = &account{AccountResponse: biz.AccountResponse{
+			AccountID:  req.AccountID,
+			ClaimCount: req.ClaimCount,
+			CreateAt:   time.Now(),
+		}}
+		r.accounts[id][req.AccountID] = a
+	} else if a.ClaimCount != 0 {
+		a.ClaimCount = req.ClaimCount
+	}
+	a.Email, a.Name, a.AvatarURL, a.Provider, a.State = req.Email, req.Name, req.AvatarURL, req.Provider, req.State
+	if req.SolanaAddr != "" {
+		a.SolanaAddr = req.SolanaAddr
+	}
+	return nil
+}
+
+func (r *AccountRepo) QueryAccount(ctx context.Context, accountID, email, provider string) (*biz.AccountResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if accountID != "" {
+		a, err := r.find(ctx, accountID)
+		if a == nil || err != nil {
+			return nil, err
+		}
+		res := a.AccountResponse
+		return &res, nil
+	}
+	as, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range as {
+		if a.Email == email && a.Provider == provider {
+			res := a.AccountResponse
+			return &res, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *AccountRepo) UpdateAccountIntegral(ctx context.Context, accountID string, integral int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, err := r.find(ctx, accountID)
+	if a != nil {
+		a.Integral += integral
+	}
+	return err
+}
+
+func (r *AccountRepo) UpdateClaimPoints(ctx context.Context, accountID string, points int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, err := r.find(ctx, accountID)
+	if err != nil || a == nil || a.Integral < a.Received+points {
+		return false, err
+	}
+	a.Received += points
+	a.claims = append(a.claims, &biz.ClaimLogResponse{
+		AccountID:  accountID,
+		Points:     points,
+		Received:   a.Received,
+		SolanaAddr: a.SolanaAddr,
+		ClaimCount: a.ClaimCount,
+		CreateAt:   time.Now(),
+	})
+	return true, nil
+}
+
+// QueryAccounts returns the accounts neither banned nor deleted.
+func (r *AccountRepo) QueryAccounts(ctx context.Context) ([]*biz.AccountResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	as, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var res []*biz.AccountResponse
+	for _, a := range as {
+		if a.State != biz.AccountStateBanned && a.State != biz.AccountStateDeleted {
+			v := a.AccountResponse
+			res = append(res, &v)
+		}
+	}
+	return res, nil
+}
+
+func (r *AccountRepo) SearchAccounts(ctx context.Context, req *biz.AccountSearchRequest) ([]*biz.AccountResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	as, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := func(a *biz.AccountResponse) int64 {
+		switch req.Sort {
+		case biz.AccountSortCreatedAt:
+			return a.CreateAt.UnixNano()
+		case biz.AccountSortIntegral:
+			return int64(a.Integral)
+		case biz.AccountSortReceived:
+			return int64(a.Received)
+		}
+		return 0
+	}
+	// less orders a before b in the requested direction
+	less := func(a, b *biz.AccountResponse) bool {
+		ka, kb := key(a), key(b)
+		if ka == kb {
+			ka, kb = int64(strings.Compare(a.AccountID, b.AccountID)), 0
+		}
+		if req.Desc {
+			return ka > kb
+		}
+		return ka < kb
+	}
+	var after *biz.AccountResponse
+	if c := req.After; c != nil {
+		after = &biz.AccountResponse{AccountID: c.AccountID, CreateAt: c.CreatedAt, Integral: c.Integral, Received: c.Received}
+	}
+
+	var res []*biz.AccountResponse
+	for _, a := range as {
+		v := a.AccountResponse
+		if matchAccount(&v, req) && (after == nil || less(after, &v)) {
+			res = append(res, &v)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return less(res[i], res[j]) })
+	if req.Limit > 0 && len(res) > req.Limit {
+		res = res[:req.Limit]
+	}
+	return res, nil
+}
+
+func matchAccount(a *biz.AccountResponse, req *biz.AccountSearchRequest) bool {
+	switch {
+	case !strings.HasPrefix(a.AccountID, req.AccountIDPrefix),
+		req.Email != "" && a.Email != req.Email,
+		!strings.HasPrefix(strings.ToLower(a.Name), strings.ToLower(req.NamePrefix)),
+		req.Provider != "" && a.Provider != req.Provider,
+		req.SolanaAddr != "" && a.SolanaAddr != req.SolanaAddr,
+		req.State != nil && a.State != *req.State,
+		req.CreatedFrom != nil && a.CreateAt.Before(*req.CreatedFrom),
+		req.CreatedTo != nil && !a.CreateAt.Before(*req.CreatedTo),
+		req.MinIntegral != nil && a.Integral < *req.MinIntegral,
+		req.MaxIntegral != nil && a.Integral > *req.MaxIntegral:
+		return false
+	}
+	return true
+}
+
+func (r *AccountRepo) UpdateAddr(ctx context.Context, accountID string, addr string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, err := r.find(ctx, accountID)
+	if a != nil && addr != "" {
+		a.SolanaAddr = addr
+	}
+	return err
+}
+
+func (r *AccountRepo) QueryAccountIDs(ctx context.Context, accountIDs []string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]string, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		a, err := r.find(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		if a != nil {
+			res = append(res, accountID)
+		}
+	}
+	return res, nil
+}
+
+func (r *AccountRepo) QueryClaimLogs(ctx context.Context, accountID string) ([]*biz.ClaimLogResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, err := r.find(ctx, accountID)
+	if a == nil || err != nil {
+		return nil, err
+	}
+	res := make([]*biz.ClaimLogResponse, len(a.claims))
+	for i := range a.claims {
+		v := *a.claims[i]
+		res[i] = &v
+	}
+	return res, nil
+}
+
+func (r *AccountRepo) EraseAccount(ctx context.Context, req *biz.AccountStateRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, err := r.find(ctx, req.AccountID)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		return gorm.ErrRecordNotFound
+	}
+	now := time.Now()
+	a.Email, a.EmailVerified, a.Name, a.AvatarURL = "", false, "deleted user", ""
+	a.State, a.StateReason = req.State, req.Reason
+	a.deletedAt = &now
+	return nil
+}
+
+func (r *AccountRepo) AccountErased(ctx context.Context, accountID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, err := tenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+	a := r.accounts[id][accountID]
+	return a != nil && a.deletedAt != nil, nil
+}
+
+func (r *AccountRepo) PurgeAccounts(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	for accountID, a := range r.accounts[id] {
+		if a.deletedAt != nil && a.deletedAt.Before(before) {
+			delete(r.accounts[id], accountID)
+			n++
+		}
+	}
+	return n, nil
+}
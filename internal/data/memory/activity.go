@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"starland-account/internal/biz"
+	"sync"
+	"time"
+)
+
+type counter struct {
+	n        int
+	expireAt time.Time
+}
+
+// ActivityRepo is an in-memory biz.ActivityRepo; the limits it counts expire
+// like their Redis keys.
+type ActivityRepo struct {
+	mu         sync.Mutex
+	activities map[string][]*biz.ActivityResponse
+	counters   map[string]map[string]*counter
+}
+
+func NewActivityRepo() *ActivityRepo {
+	return &ActivityRepo{
+		activities: make(map[string][]*biz.ActivityResponse),
+		counters:   make(map[string]map[string]*counter),
+	}
+}
+
+// AddActivity adds an activity to the context's tenant.
+func (r *ActivityRepo) AddActivity(ctx context.Context, act *biz.ActivityResponse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+	v := *act
+	r.activities[id] = append(r.activities[id], &v)
+	return nil
+}
+
+func (r *ActivityRepo) QueryActivity(ctx context.Context) ([]*biz.ActivityResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*biz.ActivityResponse, len(r.activities[id]))
+	for i, act := range r.activities[id] {
+		v := *act
+		res[i] = &v
+	}
+	return res, nil
+}
+
+func (r *ActivityRepo) ConsumeActivityLimit(ctx context.Context, key string, n int, timeOut time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+	if r.counters[id] == nil {
+		r.counters[id] = make(map[string]*counter)
+	}
+	r.counters[id][key] = &counter{n: n, expireAt: time.Now().Add(timeOut)}
+	return nil
+}
+
+func (r *ActivityRepo) QueryActivityExpend(ctx context.Context, key string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	c := r.counters[id][key]
+	if c == nil || !time.Now().Before(c.expireAt) {
+		return 0, nil
+	}
+	return c.n, nil
+}
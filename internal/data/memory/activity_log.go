@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"starland-account/internal/biz"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ActivityLogRepo is an in-memory biz.ActivityLogRepo crediting the accounts
+// of an AccountRepo.
+type ActivityLogRepo struct {
+	mu       sync.Mutex
+	accounts *AccountRepo
+	logs     map[string][]*biz.ActivityLogResponse
+	lastID   uint
+}
+
+func NewActivityLogRepo(accounts *AccountRepo) *ActivityLogRepo {
+	return &ActivityLogRepo{accounts: accounts, logs: make(map[string][]*biz.ActivityLogResponse)}
+}
+
+func (r *ActivityLogRepo) AddActivityLog(ctx context.Context, req *biz.ActivityLogRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.add(ctx, req)
+}
+
+// EarnPoints credits the points and logs them under both repos' locks.
+func (r *ActivityLogRepo) EarnPoints(ctx context.Context, req *biz.ActivityLogRequest) error {
+	r.accounts.mu.Lock()
+	defer r.accounts.mu.Unlock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, err := r.accounts.find(ctx, req.AccountID)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		return gorm.ErrRecordNotFound
+	}
+	a.Integral += req.Integral
+	return r.add(ctx, req)
+}
+
+func (r *ActivityLogRepo) add(ctx context.Context, req *biz.ActivityLogRequest) error {
+	id, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+	r.lastID++
+	r.logs[id] = append(r.logs[id], &biz.ActivityLogResponse{
+		ID:           r.lastID,
+		AccountID:    req.AccountID,
+		ActivityCode: req.ActivityCode,
+		ActivityName: req.ActivityName,
+		Integral:     req.Integral,
+		ClientID:     req.ClientID,
+		CreateAt:     time.Now(),
+	})
+	return nil
+}
+
+func (r *ActivityLogRepo) QueryActivityLog(ctx context.Context, query *biz.ActivityLogQuery) ([]*biz.ActivityLogResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	logs, err := r.filter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	// newest first, like the created_at desc, id desc of the data layer
+	sort.Slice(logs, func(i, j int) bool { return logs[i].ID > logs[j].ID })
+	if c := query.After; c != nil {
+		n := 0
+		for _, l := range logs {
+			if l.CreateAt.Before(c.CreatedAt) || (l.CreateAt.Equal(c.CreatedAt) && l.ID < c.ID) {
+				logs[n] = l
+				n++
+			}
+		}
+		logs = logs[:n]
+	} else if query.Page > 1 {
+		skip := (query.Page - 1) * query.Limit
+		if skip > len(logs) {
+			skip = len(logs)
+		}
+		logs = logs[skip:]
+	}
+	if query.Limit > 0 && len(logs) > query.Limit {
+		logs = logs[:query.Limit]
+	}
+	return logs, nil
+}
+
+func (r *ActivityLogRepo) SumActivityLogs(ctx context.Context, query *biz.ActivityLogQuery) ([]*biz.ActivityLogTotal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	logs, err := r.filter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[int]*biz.ActivityLogTotal)
+	var res []*biz.ActivityLogTotal
+	for _, l := range logs {
+		t := byCode[l.ActivityCode]
+		if t == nil {
+			t = &biz.ActivityLogTotal{ActivityCode: l.ActivityCode}
+			byCode[l.ActivityCode] = t
+			res = append(res, t)
+		}
+		if l.ActivityName > t.ActivityName {
+			t.ActivityName = l.ActivityName
+		}
+		t.Count++
+		t.Integral += int64(l.Integral)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ActivityCode < res[j].ActivityCode })
+	return res, nil
+}
+
+func (r *ActivityLogRepo) QueryAllActivityLogs(ctx context.Context, account string) ([]*biz.ActivityLogResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.filter(ctx, &biz.ActivityLogQuery{AccountID: account})
+}
+
+// filter returns copies of the account's logs matching the query, oldest
+// first.
+func (r *ActivityLogRepo) filter(ctx context.Context, query *biz.ActivityLogQuery) ([]*biz.ActivityLogResponse, error) {
+	id, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var res []*biz.ActivityLogResponse
+	for _, l := range r.logs[id] {
+		switch {
+		case l.AccountID != query.AccountID,
+			query.ActivityCode != nil && l.ActivityCode != *query.ActivityCode,
+			query.From != nil && l.CreateAt.Before(*query.From),
+			query.To != nil && !l.CreateAt.Before(*query.To):
+			continue
+		}
+		v := *l
+		res = append(res, &v)
+	}
+	return res, nil
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"starland-account/internal/biz"
+	"sync"
+	"time"
+)
+
+type lease struct {
+	owner    string
+	token    int64
+	expireAt time.Time
+}
+
+// LeaseRepo is an in-memory biz.LeaseRepo; its leases expire like their Redis
+// keys and the tokens keep growing across them.
+type LeaseRepo struct {
+	mu     sync.Mutex
+	leases map[string]*lease
+	tokens map[string]int64
+}
+
+func NewLeaseRepo() *LeaseRepo {
+	return &LeaseRepo{
+		leases: make(map[string]*lease),
+		tokens: make(map[string]int64),
+	}
+}
+
+// Expire ends the lease under key, as if its holder stopped renewing it.
+func (r *LeaseRepo) Expire(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.leases, key)
+}
+
+func (r *LeaseRepo) AcquireLease(ctx context.Context, key, owner string, ttl time.Duration) (*biz.Lease, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.held(key) != nil {
+		return nil, nil
+	}
+	r.tokens[key]++
+	r.leases[key] = &lease{owner: owner, token: r.tokens[key], expireAt: time.Now().Add(ttl)}
+	return &biz.Lease{Key: key, Owner: owner, Token: r.tokens[key], TTL: ttl}, nil
+}
+
+func (r *LeaseRepo) RenewLease(ctx context.Context, l *biz.Lease, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur := r.held(l.Key)
+	if cur == nil || cur.owner != l.Owner || cur.token != l.Token {
+		return false, nil
+	}
+	cur.expireAt = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (r *LeaseRepo) ReleaseLease(ctx context.Context, l *biz.Lease) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur := r.held(l.Key); cur != nil && cur.owner == l.Owner && cur.token == l.Token {
+		delete(r.leases, l.Key)
+	}
+	return nil
+}
+
+func (r *LeaseRepo) QueryLease(ctx context.Context, key string) (*biz.Lease, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur := r.held(key)
+	if cur == nil {
+		return nil, nil
+	}
+	return &biz.Lease{Key: key, Owner: cur.owner, Token: cur.token, TTL: time.Until(cur.expireAt)}, nil
+}
+
+// held returns the unexpired lease under key.
+func (r *LeaseRepo) held(key string) *lease {
+	l := r.leases[key]
+	if l == nil || time.Now().After(l.expireAt) {
+		return nil
+	}
+	return l
+}
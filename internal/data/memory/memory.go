@@ -0,0 +1,30 @@
+// Package memory implements the account, activity, activity log, lease and
+// verify repos in memory, for tests of the services above them. The repos
+// keep each tenant's records apart like the data layer does, but publish no
+// events.
+package memory
+
+import (
+	"context"
+	"errors"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/tenant"
+)
+
+var errNoTenant = errors.New("no tenant in context")
+
+func tenantID(ctx context.Context) (string, error) {
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return "", errNoTenant
+	}
+	return id, nil
+}
+
+var (
+	_ biz.AccountRepo     = (*AccountRepo)(nil)
+	_ biz.ActivityRepo    = (*ActivityRepo)(nil)
+	_ biz.ActivityLogRepo = (*ActivityLogRepo)(nil)
+	_ biz.LeaseRepo       = (*LeaseRepo)(nil)
+	_ biz.VerifyRepo      = (*VerifyRepo)(nil)
+)
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"starland-account/internal/biz"
+	"strings"
+	"sync"
+	"time"
+)
+
+type verifyCode struct {
+	biz.VerifyCode
+	expireAt time.Time
+}
+
+// VerifyRepo is an in-memory biz.VerifyRepo marking the emails of the
+// accounts of an AccountRepo verified. Codes and resend blocks expire like
+// their Redis keys.
+type VerifyRepo struct {
+	mu       sync.Mutex
+	accounts *AccountRepo
+	codes    map[string]map[string]*verifyCode
+	resends  map[string]time.Time
+}
+
+func NewVerifyRepo(accounts *AccountRepo) *VerifyRepo {
+	return &VerifyRepo{
+		accounts: accounts,
+		codes:    make(map[string]map[string]*verifyCode),
+		resends:  make(map[string]time.Time),
+	}
+}
+
+// find returns the live code of the context's tenant, nil if there is none.
+func (r *VerifyRepo) find(ctx context.Context, purpose, subject string) (*verifyCode, error) {
+	id, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c := r.codes[id][purpose+":"+subject]; c != nil && time.Now().Before(c.expireAt) {
+		return c, nil
+	}
+	return nil, nil
+}
+
+func (r *VerifyRepo) SaveVerifyCode(ctx context.Context, code *biz.VerifyCode, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+	if r.codes[id] == nil {
+		r.codes[id] = make(map[string]*verifyCode)
+	}
+	c := &verifyCode{VerifyCode: *code, expireAt: time.Now().Add(ttl)}
+	c.Attempts = 0
+	r.codes[id][code.Purpose+":"+code.Subject] = c
+	return nil
+}
+
+func (r *VerifyRepo) QueryVerifyCode(ctx context.Context, purpose, subject string) (*biz.VerifyCode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, err := r.find(ctx, purpose, subject)
+	if c == nil || err != nil {
+		return nil, err
+	}
+	v := c.VerifyCode
+	return &v, nil
+}
+
+func (r *VerifyRepo) IncrVerifyAttempts(ctx context.Context, purpose, subject string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, err := r.find(ctx, purpose, subject)
+	if c == nil || err != nil {
+		return 0, err
+	}
+	c.Attempts++
+	return c.Attempts, nil
+}
+
+func (r *VerifyRepo) DeleteVerifyCode(ctx context.Context, purpose, subject string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+	delete(r.codes[id], purpose+":"+subject)
+	return nil
+}
+
+func (r *VerifyRepo) DeleteAccountVerifyCodes(ctx context.Context, accountID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+	for key, c := range r.codes[id] {
+		if c.AccountID == accountID {
+			delete(r.codes[id], key)
+		}
+	}
+	return nil
+}
+
+func (r *VerifyRepo) AcquireResend(ctx context.Context, email string, interval time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, err := tenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+	key := id + ":" + strings.ToLower(email)
+	if time.Now().Before(r.resends[key]) {
+		return false, nil
+	}
+	r.resends[key] = time.Now().Add(interval)
+	return true, nil
+}
+
+func (r *VerifyRepo) MarkEmailVerified(ctx context.Context, accountID, email string) error {
+	r.accounts.mu.Lock()
+	defer r.accounts.mu.Unlock()
+	a, err := r.accounts.find(ctx, accountID)
+	if a != nil && a.Email == email {
+		a.EmailVerified = true
+	}
+	return err
+}
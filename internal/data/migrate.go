@@ -0,0 +1,229 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration is one versioned schema change. Up applies it and Down reverts
+// it, each in a transaction with its row in the history, though MySQL commits
+// DDL statements on its own.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// MigrationStatus is a migration and when it was applied, nil while pending.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// SchemaMigration is the history of the applied migrations.
+type SchemaMigration struct {
+	Version   int    `gorm:"primarykey;autoIncrement:false"`
+	Name      string `gorm:"size:255"`
+	AppliedAt time.Time
+}
+
+// SchemaLock is held by the instance migrating, so replicas starting together
+// migrate one after the other.
+type SchemaLock struct {
+	ID       int    `gorm:"primarykey;autoIncrement:false"`
+	Owner    string `gorm:"size:255"`
+	LockedAt time.Time
+}
+
+const (
+	migrationLockID = 1
+	// MigrationLockWait is how long to wait for another instance's migration.
+	MigrationLockWait = 5 * time.Minute
+	// a lock older than this was left by an instance that died migrating
+	migrationLockStale = time.Hour
+)
+
+var errIrreversible = errors.New("migration can't be reverted")
+
+// MigrateUp applies the pending migrations up to version to, all of them when
+// to is 0, and returns those it applied. It waits for the migration lock
+// until ctx is done.
+func MigrateUp(ctx context.Context, db *gorm.DB, to int) ([]Migration, error) {
+	var applied []Migration
+	err := withMigrationLock(ctx, db, func(done map[int]*SchemaMigration) error {
+		for _, m := range migrations {
+			if done[m.Version] != nil || (to > 0 && m.Version > to) {
+				continue
+			}
+			err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := m.Up(tx); err != nil {
+					return err
+				}
+				return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("MigrateUp: %d %s err: %w", m.Version, m.Name, err)
+			}
+			applied = append(applied, m)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// MigrateDown reverts the applied migrations above version to, newest first,
+// and returns those it reverted.
+func MigrateDown(ctx context.Context, db *gorm.DB, to int) ([]Migration, error) {
+	var reverted []Migration
+	err := withMigrationLock(ctx, db, func(done map[int]*SchemaMigration) error {
+		for v := range done {
+			if v > to && findMigration(v) == nil {
+				return fmt.Errorf("MigrateDown: %d %s is unknown to this version", v, done[v].Name)
+			}
+		}
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m := migrations[i]
+			if done[m.Version] == nil || m.Version <= to {
+				continue
+			}
+			err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := m.Down(tx); err != nil {
+					return err
+				}
+				return tx.Delete(&SchemaMigration{}, m.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("MigrateDown: %d %s err: %w", m.Version, m.Name, err)
+			}
+			reverted = append(reverted, m)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// MigrationStatuses lists the known migrations in order, followed by those
+// applied by a newer version of the service.
+func MigrationStatuses(ctx context.Context, db *gorm.DB) ([]*MigrationStatus, error) {
+	done, err := appliedMigrations(db.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("MigrationStatuses: %w", err)
+	}
+	res := make([]*MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		s := &MigrationStatus{Version: m.Version, Name: m.Name}
+		if a := done[m.Version]; a != nil {
+			s.AppliedAt = &a.AppliedAt
+			delete(done, m.Version)
+		}
+		res = append(res, s)
+	}
+	unknown := make([]*MigrationStatus, 0, len(done))
+	for _, a := range done {
+		a := a
+		unknown = append(unknown, &MigrationStatus{Version: a.Version, Name: a.Name, AppliedAt: &a.AppliedAt})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+	return append(res, unknown...), nil
+}
+
+// pendingMigrations counts the known migrations not applied yet.
+func pendingMigrations(ctx context.Context, db *gorm.DB) (int, error) {
+	done, err := appliedMigrations(db.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, m := range migrations {
+		if done[m.Version] == nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func findMigration(version int) *Migration {
+	for i := range migrations {
+		if migrations[i].Version == version {
+			return &migrations[i]
+		}
+	}
+	return nil
+}
+
+func appliedMigrations(db *gorm.DB) (map[int]*SchemaMigration, error) {
+	done := make(map[int]*SchemaMigration)
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		return done, nil
+	}
+	var rows []*SchemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		done[r.Version] = r
+	}
+	return done, nil
+}
+
+// withMigrationLock runs fn holding the migration lock, with the migrations
+// applied once it was taken.
+func withMigrationLock(ctx context.Context, db *gorm.DB, fn func(map[int]*SchemaMigration) error) error {
+	db = db.WithContext(ctx)
+	for _, table := range []any{&SchemaLock{}, &SchemaMigration{}} {
+		// another instance may create it at the same time
+		if err := db.Migrator().CreateTable(table); err != nil && !db.Migrator().HasTable(table) {
+			return fmt.Errorf("create migration table err: %w", err)
+		}
+	}
+	unlock, err := lockMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	done, err := appliedMigrations(db)
+	if err != nil {
+		return err
+	}
+	return fn(done)
+}
+
+func lockMigrations(ctx context.Context, db *gorm.DB) (func(), error) {
+	host, _ := os.Hostname()
+	owner := fmt.Sprintf("%s/%d", host, os.Getpid())
+	for {
+		err := db.Create(&SchemaLock{ID: migrationLockID, Owner: owner, LockedAt: time.Now()}).Error
+		if err == nil {
+			return func() {
+				// the lock is released even when ctx is done
+				db.WithContext(context.Background()).Where("owner = ?", owner).Delete(&SchemaLock{}, migrationLockID)
+			}, nil
+		}
+		var held SchemaLock
+		if qerr := db.Limit(1).Find(&held, migrationLockID).Error; qerr != nil {
+			return nil, fmt.Errorf("lockMigrations: take lock err: %w", err)
+		}
+		if held.ID != 0 && time.Since(held.LockedAt) > migrationLockStale {
+			if err = db.Where("owner = ?", held.Owner).Delete(&SchemaLock{}, migrationLockID).Error; err != nil {
+				return nil, fmt.Errorf("lockMigrations: break stale lock of %s err: %w", held.Owner, err)
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			if held.ID == 0 {
+				return nil, fmt.Errorf("lockMigrations: take lock err: %w", err)
+			}
+			return nil, fmt.Errorf("lockMigrations: held by %s since %s: %w", held.Owner, held.LockedAt, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestMigrationsOrdered(t *testing.T) {
+	for i, m := range migrations {
+		if i > 0 && m.Version <= migrations[i-1].Version {
+			t.Errorf("migration %d %s follows %d", m.Version, m.Name, migrations[i-1].Version)
+		}
+		if m.Up == nil || m.Down == nil {
+			t.Errorf("migration %d %s lacks up or down", m.Version, m.Name)
+		}
+	}
+}
+
+// TestMigrationsCoverModels checks a migrated database has every model
+// column, so a model change comes with its migration.
+func TestMigrationsCoverModels(t *testing.T) {
+	_, d := newTestData(t)
+	m := d.db.Migrator()
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: d.db}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatalf("parse %T: %v", model, err)
+		}
+		if !m.HasTable(model) {
+			t.Errorf("table %s not migrated", stmt.Schema.Table)
+			continue
+		}
+		for _, f := range stmt.Schema.Fields {
+			if f.DBName != "" && !m.HasColumn(model, f.DBName) {
+				t.Errorf("column %s.%s not migrated", stmt.Schema.Table, f.DBName)
+			}
+		}
+	}
+}
+
+func TestMigrateDownUp(t *testing.T) {
+	_, d := newTestData(t)
+	ctx := context.Background()
+	m := d.db.Migrator()
+
+	statuses, err := MigrationStatuses(ctx, d.db)
+	if err != nil {
+		t.Fatalf("MigrationStatuses: %v", err)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			t.Errorf("migration %d %s pending after NewDB", s.Version, s.Name)
+		}
+	}
+
+	reverted, err := MigrateDown(ctx, d.db, 1)
+	if err != nil {
+		t.Fatalf("MigrateDown: %v", err)
+	}
+	if len(reverted) != len(migrations)-1 || reverted[0].Version != migrations[len(migrations)-1].Version {
+		t.Errorf("MigrateDown reverted %d, want every migration after the baseline newest first", len(reverted))
+	}
+	if m.HasIndex(&Account{}, "idx_accounts_tenant_account") || !m.HasIndex(&ActivityLog{}, "idx_activity_log_account") {
+		t.Error("indexes not reverted")
+	}
+	if _, err = MigrateDown(ctx, d.db, 0); err == nil {
+		t.Error("MigrateDown reverted the baseline")
+	}
+
+	applied, err := MigrateUp(ctx, d.db, 2)
+	if err != nil {
+		t.Fatalf("MigrateUp(2): %v", err)
+	}
+	if len(applied) != 1 || applied[0].Version != 2 {
+		t.Errorf("MigrateUp(2) applied %d migrations, want 2 only", len(applied))
+	}
+	if _, err = MigrateUp(ctx, d.db, 0); err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+	if !m.HasIndex(&Account{}, "idx_accounts_tenant_account") || !m.HasIndex(&ActivityLog{}, "idx_activity_logs_account_created") ||
+		m.HasIndex(&ActivityLog{}, "idx_activity_log_account") {
+		t.Error("indexes not migrated")
+	}
+}
+
+func TestMigrateUniqueAccountID(t *testing.T) {
+	_, d := newTestData(t)
+	ctx := context.Background()
+	if _, err := MigrateDown(ctx, d.db, 1); err != nil {
+		t.Fatalf("MigrateDown: %v", err)
+	}
+
+	// an erased account and the one that took its id, then a live duplicate
+	now := time.Now()
+	for _, a := range []*Account{
+		{TenantID: "default", AccountID: "a1", Name: deletedAccountName},
+		{TenantID: "default", AccountID: "a1", Name: "Alice"},
+		{TenantID: "app", AccountID: "a1", Name: "Alice"},
+	} {
+		if err := d.db.WithContext(tenantCtx(a.TenantID)).Create(a).Error; err != nil {
+			t.Fatalf("create account: %v", err)
+		}
+	}
+	if err := d.db.Exec("update accounts set deleted_at = ? where name = ?", now, deletedAccountName).Error; err != nil {
+		t.Fatalf("erase account: %v", err)
+	}
+	if _, err := MigrateUp(ctx, d.db, 0); err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+	var n int64
+	if err := d.db.Table("accounts").Where("account_id = ?", "a1").Count(&n).Error; err != nil {
+		t.Fatalf("count accounts: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("accounts = %d, want the erased one removed", n)
+	}
+
+	if _, err := MigrateDown(ctx, d.db, 1); err != nil {
+		t.Fatalf("MigrateDown: %v", err)
+	}
+	if err := d.db.WithContext(tenantCtx("app")).Create(&Account{AccountID: "a1"}).Error; err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	_, err := MigrateUp(ctx, d.db, 0)
+	if err == nil || !strings.Contains(err.Error(), "app/a1") {
+		t.Errorf("MigrateUp with duplicates = %v, want app/a1 reported", err)
+	}
+}
+
+func TestMigrationLock(t *testing.T) {
+	_, d := newTestData(t)
+	unlock, err := lockMigrations(context.Background(), d.db)
+	if err != nil {
+		t.Fatalf("lockMigrations: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err = MigrateUp(ctx, d.db, 0); err == nil {
+		t.Error("MigrateUp ran while another instance held the lock")
+	}
+
+	unlock()
+	if _, err = MigrateUp(context.Background(), d.db, 0); err != nil {
+		t.Errorf("MigrateUp after unlock: %v", err)
+	}
+}
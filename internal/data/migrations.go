@@ -0,0 +1,130 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// migrations in version order. The baseline creates the tables as they were
+// at version 1, so every later change to the models is a migration here.
+// MySQL commits DDL on its own, so a migration checks the schema first to
+// pick up where a failed run stopped.
+var migrations = []Migration{
+	{Version: 1, Name: "baseline", Up: migrateBaseline, Down: func(*gorm.DB) error { return errIrreversible }},
+	{Version: 2, Name: "unique_account_id", Up: migrateUniqueAccountID, Down: revertUniqueAccountID},
+	{Version: 3, Name: "activity_log_account_created_index", Up: migrateActivityLogIndex, Down: revertActivityLogIndex},
+	{Version: 4, Name: "leader_fences", Up: migrateLeaderFences, Down: revertLeaderFences},
+}
+
+// migrateBaseline brings a database up to the schema startup used to
+// auto-migrate to, frozen in baselineModels.
+func migrateBaseline(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(baselineModels...); err != nil {
+		return err
+	}
+	// the daily stats were unique per day before tenants, now per tenant and day
+	return dropIndexes(tx, []indexRef{{&DailyActivityStat{}, "idx_daily_activity"}, {&DailyAccountStat{}, "idx_daily_account"}})
+}
+
+// migrateUniqueAccountID makes account_id unique within a tenant. An account
+// erased while waiting to be purged gives way to a newer one with its id, the
+// newest row is kept; accounts live twice are left to an operator to merge.
+func migrateUniqueAccountID(tx *gorm.DB) error {
+	if tx.Migrator().HasIndex(&Account{}, "idx_accounts_tenant_account") {
+		return nil
+	}
+	var erased []uint
+	err := tx.Raw("select a.id from accounts a join accounts newer " +
+		"on newer.tenant_id = a.tenant_id and newer.account_id = a.account_id and newer.id > a.id " +
+		"where a.deleted_at is not null").Scan(&erased).Error
+	if err != nil {
+		return err
+	}
+	if len(erased) > 0 {
+		if err = tx.Exec("delete from accounts where id in ?", erased).Error; err != nil {
+			return err
+		}
+	}
+	var dups []struct {
+		Tenant    string `gorm:"column:tenant_id"`
+		AccountID string
+	}
+	err = tx.Raw("select tenant_id, account_id from accounts " +
+		"group by tenant_id, account_id having count(*) > 1 order by tenant_id, account_id limit 10").Scan(&dups).Error
+	if err != nil {
+		return err
+	}
+	if len(dups) > 0 {
+		ids := make([]string, len(dups))
+		for i, d := range dups {
+			ids[i] = d.Tenant + "/" + d.AccountID
+		}
+		return fmt.Errorf("accounts are duplicated, merge them first: %s", strings.Join(ids, ", "))
+	}
+	if err = tx.Exec("create unique index idx_accounts_tenant_account on accounts (tenant_id, account_id)").Error; err != nil {
+		return err
+	}
+	// led by the tenant, the unique index serves its lookups too
+	return dropIndexes(tx, []indexRef{{&Account{}, "idx_accounts_tenant_id"}})
+}
+
+func revertUniqueAccountID(tx *gorm.DB) error {
+	if !tx.Migrator().HasIndex(&Account{}, "idx_accounts_tenant_id") {
+		if err := tx.Exec("create index idx_accounts_tenant_id on accounts (tenant_id)").Error; err != nil {
+			return err
+		}
+	}
+	return dropIndexes(tx, []indexRef{{&Account{}, "idx_accounts_tenant_account"}})
+}
+
+// migrateActivityLogIndex indexes an account's logs by time within its
+// tenant, replacing the index that left the tenant out.
+func migrateActivityLogIndex(tx *gorm.DB) error {
+	if !tx.Migrator().HasIndex(&ActivityLog{}, "idx_activity_logs_account_created") {
+		err := tx.Exec("create index idx_activity_logs_account_created on activity_logs (tenant_id, account_id, created_at)").Error
+		if err != nil {
+			return err
+		}
+	}
+	return dropIndexes(tx, []indexRef{{&ActivityLog{}, "idx_activity_log_account"}})
+}
+
+func revertActivityLogIndex(tx *gorm.DB) error {
+	if !tx.Migrator().HasIndex(&ActivityLog{}, "idx_activity_log_account") {
+		if err := tx.Exec("create index idx_activity_log_account on activity_logs (account_id, created_at)").Error; err != nil {
+			return err
+		}
+	}
+	return dropIndexes(tx, []indexRef{{&ActivityLog{}, "idx_activity_logs_account_created"}})
+}
+
+// migrateLeaderFences adds the table fencing the writes of leader only workers.
+func migrateLeaderFences(tx *gorm.DB) error {
+	if tx.Migrator().HasTable("leader_fences") {
+		return nil
+	}
+	return tx.Exec("create table leader_fences (lease_key varchar(255) not null primary key, token bigint not null)").Error
+}
+
+func revertLeaderFences(tx *gorm.DB) error {
+	return tx.Migrator().DropTable("leader_fences")
+}
+
+type indexRef struct {
+	model any
+	name  string
+}
+
+func dropIndexes(tx *gorm.DB, indexes []indexRef) error {
+	for _, idx := range indexes {
+		if !tx.Migrator().HasIndex(idx.model, idx.name) {
+			continue
+		}
+		if err := tx.Migrator().DropIndex(idx.model, idx.name); err != nil {
+			return fmt.Errorf("drop index %s err: %w", idx.name, err)
+		}
+	}
+	return nil
+}
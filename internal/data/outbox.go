@@ -0,0 +1,137 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/tenant"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultEventStream       = "starland-account:events"
+	defaultEventStreamMaxLen = 1000000
+)
+
+type OutboxEvent struct {
+	gorm.Model
+	EventID       string `gorm:"uniqueIndex;size:64"`
+	Type          string `gorm:"size:64"`
+	AggregateID   string `gorm:"size:255"`
+	Payload       string `gorm:"type:text"`
+	Attempts      int
+	LastErr       string     `gorm:"size:1024"`
+	NextAttemptAt time.Time  `gorm:"index:idx_outbox_pending,priority:2"`
+	PublishedAt   *time.Time `gorm:"index:idx_outbox_pending,priority:1"`
+}
+
+// addOutboxEvent records the event in tx so it is published if and only if
+// the state change it describes commits.
+func addOutboxEvent(tx *gorm.DB, typ, aggregateID string, data interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	tenantID, _ := tenant.FromContext(tx.Statement.Context)
+	e := &biz.Event{
+		ID:          uuid.NewString(),
+		Type:        typ,
+		Version:     biz.EventVersion,
+		OccurredAt:  now,
+		TenantID:    tenantID,
+		AggregateID: aggregateID,
+		Data:        b,
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&OutboxEvent{
+		EventID:       e.ID,
+		Type:          typ,
+		AggregateID:   aggregateID,
+		Payload:       string(payload),
+		NextAttemptAt: now,
+	}).Error
+}
+
+func eventStream(c *configs.Config) string {
+	if c.Event != nil && c.Event.Stream != "" {
+		return c.Event.Stream
+	}
+	return defaultEventStream
+}
+
+type outboxRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewOutboxRepo(c *configs.Config, data *Data) biz.OutboxRepo {
+	return &outboxRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func (r *outboxRepo) ListPendingEvents(ctx context.Context, now time.Time, limit int) ([]*biz.OutboxEvent, error) {
+	var events []*OutboxEvent
+	err := r.data.db.WithContext(ctx).Model(&OutboxEvent{}).
+		Where("published_at is null and next_attempt_at <= ?", now).
+		Order("id").Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*biz.OutboxEvent, 0, len(events))
+	for i := range events {
+		var e biz.Event
+		if err = json.Unmarshal([]byte(events[i].Payload), &e); err != nil {
+			return nil, err
+		}
+		res = append(res, &biz.OutboxEvent{ID: events[i].ID, Event: &e, Attempts: events[i].Attempts})
+	}
+	return res, nil
+}
+
+// PublishEvent appends the event to the stream with its id and type as
+// separate fields, so consumers can filter without decoding the payload.
+func (r *outboxRepo) PublishEvent(ctx context.Context, e *biz.Event) error {
+	maxLen := int64(defaultEventStreamMaxLen)
+	if r.cfg.Event != nil && r.cfg.Event.MaxLen > 0 {
+		maxLen = r.cfg.Event.MaxLen
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return r.data.rdb.WithContext(ctx).XAdd(&redis.XAddArgs{
+		Stream:       eventStream(r.cfg),
+		MaxLenApprox: maxLen,
+		Values: map[string]interface{}{
+			"id":      e.ID,
+			"type":    e.Type,
+			"payload": string(payload),
+		},
+	}).Err()
+}
+
+func (r *outboxRepo) MarkEventPublished(ctx context.Context, id uint) error {
+	return r.data.db.WithContext(ctx).Model(&OutboxEvent{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"published_at": time.Now(), "attempts": gorm.Expr("attempts + 1"), "last_err": ""}).Error
+}
+
+func (r *outboxRepo) MarkEventFailed(ctx context.Context, id uint, next time.Time, errMsg string) error {
+	return r.data.db.WithContext(ctx).Model(&OutboxEvent{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"next_attempt_at": next, "attempts": gorm.Expr("attempts + 1"), "last_err": truncate(errMsg, 1024)}).Error
+}
+
+func (r *outboxRepo) PurgePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	res := r.data.db.WithContext(ctx).Unscoped().Where("published_at < ?", before).Delete(&OutboxEvent{})
+	return res.RowsAffected, res.Error
+}
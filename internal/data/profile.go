@@ -0,0 +1,105 @@
+package data
+
+import (
+	"context"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProfileChangeLog struct {
+	gorm.Model
+	TenantID  string `gorm:"index;size:64;default:'default'"`
+	AccountID string `gorm:"index:idx_profile_change;size:255"`
+	Field     string `gorm:"index:idx_profile_change;size:32"`
+	OldValue  string `gorm:"size:1024"`
+	NewValue  string `gorm:"size:1024"`
+	Actor     string
+}
+
+type profileRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewProfileRepo(c *configs.Config, data *Data) biz.ProfileRepo {
+	return &profileRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func (r *profileRepo) UpdateProfile(ctx context.Context, req *biz.ProfileRequest) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var a *Account
+		if err := tx.Model(&Account{}).Where("account_id = ?", req.AccountID).First(&a).Error; err != nil {
+			return err
+		}
+
+		updates := make(map[string]interface{}, 2)
+		logs := make([]*ProfileChangeLog, 0, 2)
+		if req.Name != nil {
+			updates["name"] = *req.Name
+			logs = append(logs, &ProfileChangeLog{
+				AccountID: req.AccountID,
+				Field:     biz.ProfileFieldName,
+				OldValue:  a.Name,
+				NewValue:  *req.Name,
+				Actor:     req.Actor,
+			})
+		}
+		if req.AvatarURL != nil {
+			updates["avatar_url"] = *req.AvatarURL
+			logs = append(logs, &ProfileChangeLog{
+				AccountID: req.AccountID,
+				Field:     biz.ProfileFieldAvatarURL,
+				OldValue:  a.AvatarURL,
+				NewValue:  *req.AvatarURL,
+				Actor:     req.Actor,
+			})
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&Account{}).Where("account_id = ?", req.AccountID).Updates(updates).Error; err != nil {
+			return err
+		}
+		return tx.Create(&logs).Error
+	})
+}
+
+func (r *profileRepo) CountProfileChanges(ctx context.Context, accountID, field string, since time.Time) (int64, error) {
+	var count int64
+	err := r.data.db.WithContext(ctx).Model(&ProfileChangeLog{}).
+		Where("account_id = ? and field = ? and created_at >= ?", accountID, field, since).Count(&count).Error
+	return count, err
+}
+
+func (r *profileRepo) ExistAccountName(ctx context.Context, name, exceptAccountID string) (bool, error) {
+	var count int64
+	err := r.data.db.WithContext(ctx).Model(&Account{}).
+		Where("lower(name) = lower(?) and account_id <> ?", name, exceptAccountID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *profileRepo) QueryProfileChanges(ctx context.Context, accountID string) ([]*biz.ProfileChangeResponse, error) {
+	var logs []*ProfileChangeLog
+	if err := r.data.db.WithContext(ctx).Model(&ProfileChangeLog{}).Where("account_id = ?", accountID).
+		Order("id desc").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	res := make([]*biz.ProfileChangeResponse, len(logs))
+	for i := range logs {
+		res[i] = &biz.ProfileChangeResponse{
+			Field:    logs[i].Field,
+			OldValue: logs[i].OldValue,
+			NewValue: logs[i].NewValue,
+			Actor:    logs[i].Actor,
+			CreateAt: logs[i].CreatedAt,
+		}
+	}
+	return res, nil
+}
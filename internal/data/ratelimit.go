@@ -0,0 +1,48 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// rateLimitScript increments a fixed window counter and returns it together
+// with the window's remaining ttl in milliseconds, atomically so the limit
+// holds across replicas.
+var rateLimitScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return {n, redis.call('PTTL', KEYS[1])}
+`)
+
+type rateLimitRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewRateLimitRepo(c *configs.Config, data *Data) biz.RateLimitRepo {
+	return &rateLimitRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func (r *rateLimitRepo) Take(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	res, err := rateLimitScript.Run(r.data.rdb.WithContext(ctx), []string{key}, window.Milliseconds()).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+	vals, ok := res.([]interface{})
+	if !ok || len(vals) != 2 {
+		return 0, 0, fmt.Errorf("unexpected script result %v", res)
+	}
+	n, _ := vals[0].(int64)
+	ttl, _ := vals[1].(int64)
+	return n, time.Duration(ttl) * time.Millisecond, nil
+}
@@ -0,0 +1,166 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"time"
+
+	"github.com/go-redis/redis"
+	"gorm.io/gorm"
+)
+
+type RiskReview struct {
+	gorm.Model
+	TenantID     string `gorm:"index;size:64;default:'default'"`
+	AccountID    string `gorm:"index;size:255"`
+	ActivityCode int
+	IP           string
+	DeviceID     string
+	Score        int
+	Action       string
+	Hits         string
+	State        int `gorm:"index"`
+	Actor        string
+}
+
+// incrWindowScript counts into a window that starts with its first count,
+// setting the expiry in the same step so a key is never left without one.
+var incrWindowScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return n
+`)
+
+// addWindowSetScript adds a member to a window set and returns its size,
+// expiring the set with its first member.
+var addWindowSetScript = redis.NewScript(`
+local added = redis.call('SADD', KEYS[1], ARGV[1])
+local n = redis.call('SCARD', KEYS[1])
+if added == 1 and n == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return n
+`)
+
+type riskRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewRiskRepo(c *configs.Config, data *Data) biz.RiskRepo {
+	return &riskRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func (r *riskRepo) IncrWindow(ctx context.Context, key string, window time.Duration) (int64, error) {
+	key, err := tenantKey(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	return incrWindowScript.Run(r.data.rdb.WithContext(ctx), []string{key}, window.Milliseconds()).Int64()
+}
+
+func (r *riskRepo) AddWindowSet(ctx context.Context, key, member string, window time.Duration) (int64, error) {
+	key, err := tenantKey(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	return addWindowSetScript.Run(r.data.rdb.WithContext(ctx), []string{key}, member, window.Milliseconds()).Int64()
+}
+
+func (r *riskRepo) CountAccountsBySolanaAddr(ctx context.Context, addr string) (int64, error) {
+	var count int64
+	err := r.data.db.WithContext(ctx).Model(&Account{}).Where("solana_addr = ?", addr).Count(&count).Error
+	return count, err
+}
+
+// AddRiskReview queues the account for review unless it already has a pending
+// entry, in which case the entry is refreshed with the latest score.
+func (r *riskRepo) AddRiskReview(ctx context.Context, req *biz.RiskReviewRequest) error {
+	review := &RiskReview{
+		AccountID:    req.AccountID,
+		ActivityCode: req.ActivityCode,
+		IP:           req.IP,
+		DeviceID:     req.DeviceID,
+		Score:        req.Score,
+		Action:       req.Action,
+		Hits:         req.Hits,
+		State:        biz.RiskReviewPending,
+	}
+	res := r.data.db.WithContext(ctx).Model(&RiskReview{}).
+		Where("account_id = ? and state = ?", req.AccountID, biz.RiskReviewPending).
+		Updates(review)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		return nil
+	}
+	return r.data.db.WithContext(ctx).Create(review).Error
+}
+
+func (r *riskRepo) QueryRiskReview(ctx context.Context, id uint) (*biz.RiskReviewResponse, error) {
+	var review *RiskReview
+	if err := r.data.db.WithContext(ctx).Model(&RiskReview{}).Where("id = ?", id).First(&review).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return makeRiskReviewResponse(review), nil
+}
+
+func (r *riskRepo) QueryRiskReviews(ctx context.Context, state int) ([]*biz.RiskReviewResponse, error) {
+	var reviews []*RiskReview
+	if err := r.data.db.WithContext(ctx).Model(&RiskReview{}).Where("state = ?", state).
+		Order("score desc, id").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	res := make([]*biz.RiskReviewResponse, len(reviews))
+	for i := range reviews {
+		res[i] = makeRiskReviewResponse(reviews[i])
+	}
+	return res, nil
+}
+
+func (r *riskRepo) ResolveRiskReview(ctx context.Context, req *biz.ResolveRiskReviewRequest) (bool, error) {
+	resolved := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// only a pending review, so a decision is never overwritten
+		res := tx.Model(&RiskReview{}).Where("id = ? and state = ?", req.ID, biz.RiskReviewPending).
+			Updates(map[string]interface{}{"state": req.State, "actor": req.Actor})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		resolved = true
+		if req.Ban == nil {
+			return nil
+		}
+		_, err := updateAccountState(tx, req.Ban)
+		return err
+	})
+	return resolved && err == nil, err
+}
+
+func makeRiskReviewResponse(r *RiskReview) *biz.RiskReviewResponse {
+	return &biz.RiskReviewResponse{
+		ID:           r.ID,
+		AccountID:    r.AccountID,
+		ActivityCode: r.ActivityCode,
+		IP:           r.IP,
+		DeviceID:     r.DeviceID,
+		Score:        r.Score,
+		Action:       r.Action,
+		Hits:         r.Hits,
+		State:        r.State,
+		Actor:        r.Actor,
+		CreateAt:     r.CreatedAt,
+		UpdateAt:     r.UpdatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package data
+
+import (
+	"starland-account/internal/biz"
+	"testing"
+)
+
+func TestRiskRepoResolveRiskReview(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, risks := NewAccountRepo(c, d), NewRiskRepo(c, d)
+	ctx := tenantCtx("default")
+	if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	if err := risks.AddRiskReview(ctx, &biz.RiskReviewRequest{AccountID: "a1", Score: 80, Hits: "ip_burst"}); err != nil {
+		t.Fatalf("AddRiskReview: %v", err)
+	}
+	reviews, err := risks.QueryRiskReviews(ctx, biz.RiskReviewPending)
+	if err != nil || len(reviews) != 1 {
+		t.Fatalf("QueryRiskReviews = %d, %v, want 1", len(reviews), err)
+	}
+	id := reviews[0].ID
+	confirm := &biz.ResolveRiskReviewRequest{ID: id, State: biz.RiskReviewConfirmed, Actor: "alice", Ban: &biz.AccountStateRequest{
+		AccountID: "a1", State: biz.AccountStateBanned, Actor: "alice",
+		From: []int{biz.AccountStateActive, biz.AccountStateSuspended},
+	}}
+
+	if ok, err := risks.ResolveRiskReview(ctx, confirm); err != nil || !ok {
+		t.Fatalf("ResolveRiskReview = %v, %v", ok, err)
+	}
+	if a, err := accounts.QueryAccount(ctx, "a1", "", ""); err != nil || a.State != biz.AccountStateBanned {
+		t.Errorf("account = %v, %v, want banned", a, err)
+	}
+
+	// a second decision leaves the first one as it was
+	dismiss := &biz.ResolveRiskReviewRequest{ID: id, State: biz.RiskReviewCleared, Actor: "bob"}
+	if ok, err := risks.ResolveRiskReview(ctx, dismiss); err != nil || ok {
+		t.Errorf("ResolveRiskReview of a resolved review = %v, %v, want false", ok, err)
+	}
+	review, err := risks.QueryRiskReview(ctx, id)
+	if err != nil || review.State != biz.RiskReviewConfirmed || review.Actor != "alice" {
+		t.Errorf("review = %+v, %v, want confirmed by alice", review, err)
+	}
+}
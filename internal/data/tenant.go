@@ -0,0 +1,97 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"starland-account/internal/pkg/tenant"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const tenantField = "TenantID"
+
+var errNoTenant = errors.New("no tenant in context")
+
+// registerTenantScope isolates tenants: every query, update and delete of a
+// model with a TenantID field is limited to the tenant of the statement's
+// context, and creates stamp it. Statements on those models without a tenant
+// fail rather than see every tenant's rows.
+func registerTenantScope(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("tenant:create", stampTenant); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", scopeTenant); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("tenant:row", scopeTenant); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", func(db *gorm.DB) {
+		// Save writes every field, so keep the tenant it would otherwise blank
+		stampTenant(db)
+		scopeTenant(db)
+	}); err != nil {
+		return err
+	}
+	return cb.Delete().Before("gorm:delete").Register("tenant:delete", scopeTenant)
+}
+
+func scopeTenant(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil || db.Statement.Schema.LookUpField(tenantField) == nil {
+		return
+	}
+	id, ok := tenant.FromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(errNoTenant)
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: "tenant_id"}, Value: id},
+	}})
+}
+
+func stampTenant(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	field := db.Statement.Schema.LookUpField(tenantField)
+	if field == nil {
+		return
+	}
+	id, ok := tenant.FromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(errNoTenant)
+		return
+	}
+	ctx, rv := db.Statement.Context, db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if v := reflect.Indirect(rv.Index(i)); v.CanAddr() {
+				_ = field.Set(ctx, v, id)
+			}
+		}
+	case reflect.Struct:
+		if rv.CanAddr() {
+			_ = field.Set(ctx, rv, id)
+		}
+	}
+}
+
+// tenantKey namespaces a Redis key by the context's tenant. The default
+// tenant keeps the unprefixed keys it used before tenants existed.
+func tenantKey(ctx context.Context, key string) (string, error) {
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return "", errNoTenant
+	}
+	if id == tenant.Default {
+		return key, nil
+	}
+	const prefix = "starland-account:"
+	return prefix + id + ":" + strings.TrimPrefix(key, prefix), nil
+}
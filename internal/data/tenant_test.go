@@ -0,0 +1,73 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"starland-account/internal/biz"
+	"testing"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	c, d := newTestData(t)
+	accounts, logs, webhooks := NewAccountRepo(c, d), NewActivityLogRepo(c, d), NewWebhookRepo(c, d)
+	app1, app2 := tenantCtx("app1"), tenantCtx("app2")
+
+	// the same account id is a different account in each tenant
+	for _, ctx := range []context.Context{app1, app2} {
+		if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+			t.Fatalf("SaveAccount: %v", err)
+		}
+	}
+	if err := logs.EarnPoints(app1, &biz.ActivityLogRequest{AccountID: "a1", ActivityCode: 1, Integral: 10}); err != nil {
+		t.Fatalf("EarnPoints: %v", err)
+	}
+	if err := webhooks.SaveSubscription(app1, &biz.WebhookSubscription{SubscriptionID: "s1", Enabled: true}); err != nil {
+		t.Fatalf("SaveSubscription: %v", err)
+	}
+	if err := webhooks.AddDeliveries(app1, []*biz.WebhookDelivery{{DeliveryID: "d1", SubscriptionID: "s1", EventID: "e1"}}); err != nil {
+		t.Fatalf("AddDeliveries: %v", err)
+	}
+
+	a1, err := accounts.QueryAccount(app1, "a1", "", "")
+	if err != nil {
+		t.Fatalf("QueryAccount(app1): %v", err)
+	}
+	a2, err := accounts.QueryAccount(app2, "a1", "", "")
+	if err != nil {
+		t.Fatalf("QueryAccount(app2): %v", err)
+	}
+	if a1.Integral != 10 || a2.Integral != 0 {
+		t.Errorf("Integral = %d, %d, want 10, 0", a1.Integral, a2.Integral)
+	}
+	if res, err := logs.QueryAllActivityLogs(app2, "a1"); err != nil || len(res) != 0 {
+		t.Errorf("QueryAllActivityLogs(app2) = %d logs, %v, want none", len(res), err)
+	}
+	if sub, err := webhooks.QuerySubscription(app2, "s1"); err != nil || sub != nil {
+		t.Errorf("QuerySubscription(app2) = %v, %v, want nil, nil", sub, err)
+	}
+	if res, err := webhooks.QueryAttempts(app2, "d1"); err != nil || len(res) != 0 {
+		t.Errorf("QueryAttempts(app2) = %d, %v, want none", len(res), err)
+	}
+
+	// updates and deletes don't reach the other tenant
+	if err = accounts.UpdateAddr(app2, "a1", "addr"); err != nil {
+		t.Fatalf("UpdateAddr: %v", err)
+	}
+	if a1, _ = accounts.QueryAccount(app1, "a1", "", ""); a1.SolanaAddr != "" {
+		t.Errorf("app1 SolanaAddr = %q, want unchanged", a1.SolanaAddr)
+	}
+	if err = webhooks.DeleteSubscription(app2, "s1"); err != nil {
+		t.Fatalf("DeleteSubscription: %v", err)
+	}
+	if sub, _ := webhooks.QuerySubscription(app1, "s1"); sub == nil {
+		t.Error("app1 subscription deleted by app2")
+	}
+
+	// statements without a tenant fail instead of seeing every tenant
+	if _, err = accounts.QueryAccount(context.Background(), "a1", "", ""); !errors.Is(err, errNoTenant) {
+		t.Errorf("QueryAccount without tenant = %v, want errNoTenant", err)
+	}
+	if err = accounts.SaveAccount(context.Background(), &biz.AccountRequest{AccountID: "a2"}); !errors.Is(err, errNoTenant) {
+		t.Errorf("SaveAccount without tenant = %v, want errNoTenant", err)
+	}
+}
@@ -0,0 +1,138 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const verifyKeyPrefix = "starland-account:verify:"
+
+type verifyRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewVerifyRepo(c *configs.Config, data *Data) biz.VerifyRepo {
+	return &verifyRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func verifyKey(ctx context.Context, purpose, subject string) (string, error) {
+	return tenantKey(ctx, verifyKeyPrefix+purpose+":"+subject)
+}
+
+// verifyAccountKey indexes the keys of an account's codes, as magic links are
+// keyed by their token.
+func verifyAccountKey(ctx context.Context, accountID string) (string, error) {
+	return tenantKey(ctx, verifyKeyPrefix+"account:"+accountID)
+}
+
+func (r *verifyRepo) SaveVerifyCode(ctx context.Context, code *biz.VerifyCode, ttl time.Duration) error {
+	key, err := verifyKey(ctx, code.Purpose, code.Subject)
+	if err != nil {
+		return err
+	}
+	pipe := r.data.rdb.WithContext(ctx).TxPipeline()
+	pipe.Del(key)
+	pipe.HMSet(key, map[string]interface{}{
+		"code":       code.Code,
+		"account_id": code.AccountID,
+		"email":      code.Email,
+		"attempts":   0,
+	})
+	pipe.Expire(key, ttl)
+	if code.AccountID != "" {
+		index, err := verifyAccountKey(ctx, code.AccountID)
+		if err != nil {
+			return err
+		}
+		// codes share the policy's ttl, so the index outlives every code in it
+		pipe.SAdd(index, key)
+		pipe.Expire(index, ttl)
+	}
+	_, err = pipe.Exec()
+	return err
+}
+
+func (r *verifyRepo) QueryVerifyCode(ctx context.Context, purpose, subject string) (*biz.VerifyCode, error) {
+	key, err := verifyKey(ctx, purpose, subject)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := r.data.rdb.WithContext(ctx).HGetAll(key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 {
+		return nil, nil
+	}
+	attempts, _ := strconv.ParseInt(vals["attempts"], 10, 64)
+	return &biz.VerifyCode{
+		Purpose:   purpose,
+		Subject:   subject,
+		Code:      vals["code"],
+		AccountID: vals["account_id"],
+		Email:     vals["email"],
+		Attempts:  attempts,
+	}, nil
+}
+
+func (r *verifyRepo) IncrVerifyAttempts(ctx context.Context, purpose, subject string) (int64, error) {
+	key, err := verifyKey(ctx, purpose, subject)
+	if err != nil {
+		return 0, err
+	}
+	return r.data.rdb.WithContext(ctx).HIncrBy(key, "attempts", 1).Result()
+}
+
+func (r *verifyRepo) DeleteVerifyCode(ctx context.Context, purpose, subject string) error {
+	key, err := verifyKey(ctx, purpose, subject)
+	if err != nil {
+		return err
+	}
+	return r.data.rdb.WithContext(ctx).Del(key).Err()
+}
+
+func (r *verifyRepo) DeleteAccountVerifyCodes(ctx context.Context, accountID string) error {
+	index, err := verifyAccountKey(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	key, err := verifyKey(ctx, biz.VerifyPurposeEmail, accountID)
+	if err != nil {
+		return err
+	}
+	rdb := r.data.rdb.WithContext(ctx)
+	keys, err := rdb.SMembers(index).Result()
+	if err != nil {
+		return fmt.Errorf("smembers %s err: %w", index, err)
+	}
+	return rdb.Del(append(keys, key, index)...).Err()
+}
+
+func (r *verifyRepo) AcquireResend(ctx context.Context, email string, interval time.Duration) (bool, error) {
+	key, err := tenantKey(ctx, verifyKeyPrefix+"resend:"+strings.ToLower(email))
+	if err != nil {
+		return false, err
+	}
+	ok, err := r.data.rdb.WithContext(ctx).SetNX(key, 1, interval).Result()
+	if err != nil && err != redis.Nil {
+		return false, fmt.Errorf("setnx %s err: %w", key, err)
+	}
+	return ok, nil
+}
+
+func (r *verifyRepo) MarkEmailVerified(ctx context.Context, accountID, email string) error {
+	return r.data.db.WithContext(ctx).Model(&Account{}).
+		Where("account_id = ? and email = ? and email_verified_at is null", accountID, email).
+		Update("email_verified_at", time.Now()).Error
+}
@@ -0,0 +1,394 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookSubscription struct {
+	gorm.Model
+	TenantID            string `gorm:"index;size:64;default:'default'"`
+	SubscriptionID      string `gorm:"uniqueIndex;size:64"`
+	URL                 string `gorm:"size:1024"`
+	Secret              string `gorm:"size:255"`
+	Events              string `gorm:"size:1024"`
+	Description         string
+	Enabled             bool `gorm:"index"`
+	ConsecutiveFailures int
+	DisabledReason      string
+}
+
+type WebhookDelivery struct {
+	gorm.Model
+	TenantID       string `gorm:"index;size:64;default:'default'"`
+	DeliveryID     string `gorm:"uniqueIndex;size:64"`
+	SubscriptionID string `gorm:"uniqueIndex:idx_webhook_delivery_event;size:64"`
+	EventID        string `gorm:"uniqueIndex:idx_webhook_delivery_event;size:64"`
+	EventType      string `gorm:"size:64"`
+	Payload        string `gorm:"type:text"`
+	State          int    `gorm:"index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int
+	NextAttemptAt  time.Time `gorm:"index:idx_webhook_delivery_due,priority:2"`
+	LastStatus     int
+	LastErr        string `gorm:"size:1024"`
+	DeliveredAt    *time.Time
+}
+
+type WebhookAttempt struct {
+	gorm.Model
+	DeliveryID string `gorm:"index;size:64"`
+	Attempt    int
+	StatusCode int
+	Err        string `gorm:"size:1024"`
+	DurationMs int64
+}
+
+type webhookRepo struct {
+	cfg  *configs.Config
+	data *Data
+}
+
+func NewWebhookRepo(c *configs.Config, data *Data) biz.WebhookRepo {
+	return &webhookRepo{
+		cfg:  c,
+		data: data,
+	}
+}
+
+func (r *webhookRepo) SaveSubscription(ctx context.Context, sub *biz.WebhookSubscription) error {
+	return r.data.db.WithContext(ctx).Create(&WebhookSubscription{
+		SubscriptionID: sub.SubscriptionID,
+		URL:            sub.URL,
+		Secret:         sub.Secret,
+		Events:         strings.Join(sub.Events, ","),
+		Description:    sub.Description,
+		Enabled:        sub.Enabled,
+	}).Error
+}
+
+func (r *webhookRepo) QuerySubscription(ctx context.Context, id string) (*biz.WebhookSubscription, error) {
+	var sub *WebhookSubscription
+	if err := r.data.db.WithContext(ctx).Model(&WebhookSubscription{}).Where("subscription_id = ?", id).First(&sub).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return makeWebhookSubscription(sub), nil
+}
+
+func (r *webhookRepo) QuerySubscriptions(ctx context.Context, enabledOnly bool) ([]*biz.WebhookSubscription, error) {
+	db := r.data.db.WithContext(ctx).Model(&WebhookSubscription{})
+	if enabledOnly {
+		db = db.Where("enabled = ?", true)
+	}
+	var subs []*WebhookSubscription
+	if err := db.Order("id").Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	res := make([]*biz.WebhookSubscription, len(subs))
+	for i := range subs {
+		res[i] = makeWebhookSubscription(subs[i])
+	}
+	return res, nil
+}
+
+func (r *webhookRepo) SetSubscriptionEnabled(ctx context.Context, id string, enabled bool, reason string) error {
+	updates := map[string]interface{}{"enabled": enabled, "disabled_reason": reason}
+	if enabled {
+		updates["consecutive_failures"] = 0
+		updates["disabled_reason"] = ""
+	}
+	return r.data.db.WithContext(ctx).Model(&WebhookSubscription{}).Where("subscription_id = ?", id).Updates(updates).Error
+}
+
+// DeleteSubscription also drops the subscription's pending deliveries.
+func (r *webhookRepo) DeleteSubscription(ctx context.Context, id string) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&WebhookSubscription{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("subscription_id = ? and state = ?", id, biz.WebhookDeliveryPending).Delete(&WebhookDelivery{}).Error
+	})
+}
+
+func (r *webhookRepo) AddDeliveries(ctx context.Context, deliveries []*biz.WebhookDelivery) error {
+	rows := make([]*WebhookDelivery, len(deliveries))
+	for i, d := range deliveries {
+		rows[i] = &WebhookDelivery{
+			DeliveryID:     d.DeliveryID,
+			SubscriptionID: d.SubscriptionID,
+			EventID:        d.EventID,
+			EventType:      d.EventType,
+			Payload:        d.Payload,
+			State:          d.State,
+			NextAttemptAt:  d.NextAttemptAt,
+		}
+	}
+	return r.data.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+func (r *webhookRepo) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*biz.WebhookDelivery, error) {
+	// deliveries of disabled subscriptions wait until they are enabled again
+	db := r.data.db.WithContext(ctx)
+	enabled := db.Model(&WebhookSubscription{}).Select("subscription_id").Where("enabled = ?", true)
+	var ds []*WebhookDelivery
+	err := db.Model(&WebhookDelivery{}).
+		Where("state = ? and next_attempt_at <= ?", biz.WebhookDeliveryPending, now).
+		Where("subscription_id in (?)", enabled).
+		Order("next_attempt_at").Limit(limit).Find(&ds).Error
+	if err != nil {
+		return nil, err
+	}
+	return makeWebhookDeliveries(ds), nil
+}
+
+func (r *webhookRepo) ClaimDelivery(ctx context.Context, id uint, prev, lease time.Time) (bool, error) {
+	res := r.data.db.WithContext(ctx).Model(&WebhookDelivery{}).
+		Where("id = ? and state = ? and next_attempt_at = ?", id, biz.WebhookDeliveryPending, prev).
+		Update("next_attempt_at", lease)
+	return res.RowsAffected == 1, res.Error
+}
+
+func (r *webhookRepo) SaveDeliveryResult(ctx context.Context, d *biz.WebhookDelivery, a *biz.WebhookAttempt, ok bool, disableAfter int) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&WebhookAttempt{
+			DeliveryID: a.DeliveryID,
+			Attempt:    a.Attempt,
+			StatusCode: a.StatusCode,
+			Err:        truncate(a.Err, 1024),
+			DurationMs: a.Duration.Milliseconds(),
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&WebhookDelivery{}).Where("id = ?", d.ID).Updates(map[string]interface{}{
+			"state":           d.State,
+			"attempts":        d.Attempts,
+			"next_attempt_at": d.NextAttemptAt,
+			"last_status":     d.LastStatus,
+			"last_err":        truncate(d.LastErr, 1024),
+			"delivered_at":    d.DeliveredAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		subs := tx.Model(&WebhookSubscription{}).Where("subscription_id = ?", d.SubscriptionID)
+		if ok {
+			return subs.Update("consecutive_failures", 0).Error
+		}
+		if err = subs.Update("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error; err != nil {
+			return err
+		}
+		if disableAfter <= 0 {
+			return nil
+		}
+		return tx.Model(&WebhookSubscription{}).
+			Where("subscription_id = ? and enabled = ? and consecutive_failures >= ?", d.SubscriptionID, true, disableAfter).
+			Updates(map[string]interface{}{"enabled": false, "disabled_reason": "too many consecutive failures"}).Error
+	})
+}
+
+func (r *webhookRepo) QueryDelivery(ctx context.Context, deliveryID string) (*biz.WebhookDelivery, error) {
+	var d *WebhookDelivery
+	if err := r.data.db.WithContext(ctx).Model(&WebhookDelivery{}).Where("delivery_id = ?", deliveryID).First(&d).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return makeWebhookDelivery(d), nil
+}
+
+func (r *webhookRepo) QueryDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*biz.WebhookDelivery, error) {
+	var ds []*WebhookDelivery
+	err := r.data.db.WithContext(ctx).Model(&WebhookDelivery{}).Where("subscription_id = ?", subscriptionID).
+		Order("id desc").Limit(limit).Find(&ds).Error
+	if err != nil {
+		return nil, err
+	}
+	return makeWebhookDeliveries(ds), nil
+}
+
+func (r *webhookRepo) QueryAttempts(ctx context.Context, deliveryID string) ([]*biz.WebhookAttempt, error) {
+	var as []*WebhookAttempt
+	db := r.data.db.WithContext(ctx)
+	// attempts carry no tenant, the delivery they belong to does
+	delivery := db.Model(&WebhookDelivery{}).Select("delivery_id").Where("delivery_id = ?", deliveryID)
+	if err := db.Model(&WebhookAttempt{}).Where("delivery_id in (?)", delivery).
+		Order("id").Find(&as).Error; err != nil {
+		return nil, err
+	}
+	res := make([]*biz.WebhookAttempt, len(as))
+	for i := range as {
+		res[i] = &biz.WebhookAttempt{
+			DeliveryID: as[i].DeliveryID,
+			Attempt:    as[i].Attempt,
+			StatusCode: as[i].StatusCode,
+			Err:        as[i].Err,
+			Duration:   time.Duration(as[i].DurationMs) * time.Millisecond,
+			CreateAt:   as[i].CreatedAt,
+		}
+	}
+	return res, nil
+}
+
+func (r *webhookRepo) ResetDelivery(ctx context.Context, deliveryID string) error {
+	return r.data.db.WithContext(ctx).Model(&WebhookDelivery{}).Where("delivery_id = ?", deliveryID).
+		Updates(map[string]interface{}{
+			"state":           biz.WebhookDeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		}).Error
+}
+
+// ReadStreamEvents reads the event stream as a member of the consumer group,
+// creating the group at the stream's end on first use. A start of "0" reads
+// the consumer's own unacknowledged events, ">" reads new ones.
+func (r *webhookRepo) ReadStreamEvents(ctx context.Context, group, consumer, start string, count int, block time.Duration) ([]*biz.StreamEvent, error) {
+	rdb := r.data.rdb.WithContext(ctx)
+	stream := eventStream(r.cfg)
+	if err := rdb.XGroupCreateMkStream(stream, group, "$").Err(); err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil, err
+	}
+	streams, err := rdb.XReadGroup(&redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{stream, start},
+		Count:    int64(count),
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*biz.StreamEvent
+	for _, s := range streams {
+		res = append(res, makeStreamEvents(s.Messages)...)
+	}
+	return res, nil
+}
+
+func (r *webhookRepo) ClaimStreamEvents(ctx context.Context, group, consumer string, minIdle time.Duration, count int) ([]*biz.StreamEvent, error) {
+	rdb := r.data.rdb.WithContext(ctx)
+	stream := eventStream(r.cfg)
+	pending, err := rdb.XPendingExt(&redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  group,
+		Start:  "-",
+		End:    "+",
+		Count:  int64(count),
+	}).Result()
+	if err != nil {
+		if err == redis.Nil || strings.HasPrefix(err.Error(), "NOGROUP") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ids []string
+	for _, p := range pending {
+		if p.Consumer != consumer && p.Idle >= minIdle {
+			ids = append(ids, p.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	// XCLAIM checks the idle time again, so of two instances claiming at once
+	// only one gets each event
+	msgs, err := rdb.XClaim(&redis.XClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	return makeStreamEvents(msgs), nil
+}
+
+// makeStreamEvents leaves Event nil for messages without a readable payload.
+func makeStreamEvents(msgs []redis.XMessage) []*biz.StreamEvent {
+	res := make([]*biz.StreamEvent, 0, len(msgs))
+	for _, m := range msgs {
+		se := &biz.StreamEvent{MessageID: m.ID}
+		if payload, ok := m.Values["payload"].(string); ok {
+			var e biz.Event
+			if json.Unmarshal([]byte(payload), &e) == nil {
+				se.Event = &e
+			}
+		}
+		res = append(res, se)
+	}
+	return res
+}
+
+func (r *webhookRepo) AckStreamEvents(ctx context.Context, group string, ids ...string) error {
+	return r.data.rdb.WithContext(ctx).XAck(eventStream(r.cfg), group, ids...).Err()
+}
+
+func makeWebhookSubscription(sub *WebhookSubscription) *biz.WebhookSubscription {
+	var events []string
+	if sub.Events != "" {
+		events = strings.Split(sub.Events, ",")
+	}
+	return &biz.WebhookSubscription{
+		SubscriptionID:      sub.SubscriptionID,
+		URL:                 sub.URL,
+		Secret:              sub.Secret,
+		Events:              events,
+		Description:         sub.Description,
+		Enabled:             sub.Enabled,
+		ConsecutiveFailures: sub.ConsecutiveFailures,
+		DisabledReason:      sub.DisabledReason,
+		CreateAt:            sub.CreatedAt,
+	}
+}
+
+func makeWebhookDelivery(d *WebhookDelivery) *biz.WebhookDelivery {
+	return &biz.WebhookDelivery{
+		ID:             d.ID,
+		DeliveryID:     d.DeliveryID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		State:          d.State,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatus:     d.LastStatus,
+		LastErr:        d.LastErr,
+		DeliveredAt:    d.DeliveredAt,
+		CreateAt:       d.CreatedAt,
+	}
+}
+
+func makeWebhookDeliveries(ds []*WebhookDelivery) []*biz.WebhookDelivery {
+	res := make([]*biz.WebhookDelivery, len(ds))
+	for i := range ds {
+		res[i] = makeWebhookDelivery(ds[i])
+	}
+	return res
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,75 @@
+package data
+
+import (
+	"starland-account/internal/biz"
+	"testing"
+	"time"
+)
+
+func TestWebhookRepoDeliveries(t *testing.T) {
+	c, d := newTestData(t)
+	r := NewWebhookRepo(c, d)
+	ctx := tenantCtx("default")
+
+	for _, sub := range []*biz.WebhookSubscription{
+		{SubscriptionID: "on", URL: "https://a.io", Events: []string{biz.WebhookAllEvents}, Enabled: true},
+		{SubscriptionID: "off", URL: "https://b.io", Events: []string{biz.WebhookAllEvents}, Enabled: true},
+	} {
+		if err := r.SaveSubscription(ctx, sub); err != nil {
+			t.Fatalf("SaveSubscription: %v", err)
+		}
+	}
+	if err := r.SetSubscriptionEnabled(ctx, "off", false, "test"); err != nil {
+		t.Fatalf("SetSubscriptionEnabled: %v", err)
+	}
+
+	now := time.Now()
+	deliveries := []*biz.WebhookDelivery{
+		{DeliveryID: "d1", SubscriptionID: "on", EventID: "e1", NextAttemptAt: now},
+		{DeliveryID: "d2", SubscriptionID: "off", EventID: "e1", NextAttemptAt: now},
+	}
+	// a redelivered event is enqueued once
+	for _, id := range []string{"", "-again"} {
+		deliveries[0].DeliveryID += id
+		deliveries[1].DeliveryID += id
+		if err := r.AddDeliveries(ctx, deliveries); err != nil {
+			t.Fatalf("AddDeliveries: %v", err)
+		}
+	}
+
+	due, err := r.ListDueDeliveries(ctx, now.Add(time.Second), 10)
+	if err != nil {
+		t.Fatalf("ListDueDeliveries: %v", err)
+	}
+	if len(due) != 1 || due[0].DeliveryID != "d1" {
+		t.Fatalf("ListDueDeliveries = %+v, want d1 only", due)
+	}
+
+	lease := now.Add(time.Minute)
+	if ok, err := r.ClaimDelivery(ctx, due[0].ID, due[0].NextAttemptAt, lease); err != nil || !ok {
+		t.Fatalf("ClaimDelivery = %v, %v, want won", ok, err)
+	}
+	if ok, err := r.ClaimDelivery(ctx, due[0].ID, due[0].NextAttemptAt, lease); err != nil || ok {
+		t.Fatalf("ClaimDelivery again = %v, %v, want lost", ok, err)
+	}
+
+	d1 := due[0]
+	d1.State, d1.Attempts, d1.LastStatus = biz.WebhookDeliveryDead, 1, 500
+	if err = r.SaveDeliveryResult(ctx, d1, &biz.WebhookAttempt{DeliveryID: "d1", Attempt: 1, StatusCode: 500}, false, 1); err != nil {
+		t.Fatalf("SaveDeliveryResult: %v", err)
+	}
+	sub, err := r.QuerySubscription(ctx, "on")
+	if err != nil {
+		t.Fatalf("QuerySubscription: %v", err)
+	}
+	if sub.Enabled || sub.ConsecutiveFailures != 1 {
+		t.Errorf("subscription = %+v, want disabled after 1 failure", sub)
+	}
+	attempts, err := r.QueryAttempts(ctx, "d1")
+	if err != nil {
+		t.Fatalf("QueryAttempts: %v", err)
+	}
+	if len(attempts) != 1 || attempts[0].StatusCode != 500 {
+		t.Errorf("QueryAttempts = %+v, want one 500", attempts)
+	}
+}
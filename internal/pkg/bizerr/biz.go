@@ -6,9 +6,10 @@ import (
 )
 
 type BizError struct {
-	msg   string
-	code  ErrCode
-	cause error
+	msg    string
+	code   ErrCode
+	reason string
+	cause  error
 }
 
 func NewBizError(msg string, code ErrCode) *BizError {
@@ -27,6 +28,22 @@ func (e *BizError) Msg() string {
 	return e.msg
 }
 
+// Reason is the stable machine readable name of the error, e.g.
+// NOT_ENOUGH_POINTS, falling back to the one of its code.
+func (e *BizError) Reason() string {
+	if e.reason != "" {
+		return e.reason
+	}
+	return e.code.Reason()
+}
+
+// WithReason returns a copy of the error named reason.
+func (e *BizError) WithReason(reason string) *BizError {
+	e1 := *e
+	e1.reason = reason
+	return &e1
+}
+
 func (e *BizError) Error() string {
 	if e.cause != nil {
 		return fmt.Sprintf("(%d) %s: %s", e.code, e.msg, e.cause.Error())
@@ -44,22 +61,49 @@ func (e *BizError) Cause() error {
 
 func (e *BizError) Wrap(err error) error {
 	return &BizError{
-		msg:   e.msg,
-		code:  e.code,
-		cause: err,
+		msg:    e.msg,
+		code:   e.code,
+		reason: e.reason,
+		cause:  err,
 	}
 }
 
 func (e *BizError) Wrapf(err error, msg string, args ...interface{}) error {
 	return &BizError{
-		msg:   fmt.Sprintf("%s: %s", e.msg, fmt.Sprintf(msg, args...)),
-		code:  e.code,
-		cause: err,
+		msg:    fmt.Sprintf("%s: %s", e.msg, fmt.Sprintf(msg, args...)),
+		code:   e.code,
+		reason: e.reason,
+		cause:  err,
 	}
 }
 
+// Errorf wraps a cause written for the client, which ClientMessage shows.
 func (e *BizError) Errorf(format string, args ...interface{}) error {
-	return e.Wrap(fmt.Errorf(format, args...))
+	return e.Wrap(&clientError{msg: fmt.Sprintf(format, args...)})
+}
+
+// ClientMessage is the message for clients: Msg, followed by the cause when it
+// was written for them, by Errorf or as a ClientMessage of its own such as
+// the failing fields of a validation error. Other causes, wrapped from
+// parsers, drivers and the like, are only for the logs.
+func (e *BizError) ClientMessage() string {
+	var c interface{ ClientMessage() string }
+	if e.cause != nil && errors.As(e.cause, &c) {
+		return e.msg + ": " + c.ClientMessage()
+	}
+	return e.msg
+}
+
+type clientError struct {
+	msg string
+}
+
+func (e *clientError) Error() string {
+	return e.msg
+}
+
+func (e *clientError) ClientMessage() string {
+	return e.msg
 }
 
 func ErrorToBizError(err error) (bool, *BizError) {
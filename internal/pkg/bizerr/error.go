@@ -6,6 +6,15 @@ func (c ErrCode) Int32() int32 {
 	return int32(c)
 }
 
+// Reason is the stable name errors of the code go by unless they set their
+// own.
+func (c ErrCode) Reason() string {
+	if r, ok := codeReasons[c]; ok {
+		return r
+	}
+	return "INTERNAL_ERROR"
+}
+
 const (
 	AuthenticationFailed   ErrCode = 401
 	BadRequest             ErrCode = 65531
@@ -14,15 +23,72 @@ const (
 	VerificationCodeFailed ErrCode = 65540
 	NotExist               ErrCode = 65541
 	PostureNotExist        ErrCode = 65542
+	AccountDisabled        ErrCode = 65543
+	RiskRejected           ErrCode = 65544
+	Conflict               ErrCode = 65545
+	TooManyRequests        ErrCode = 65546
+	EmailNotVerified       ErrCode = 65547
 )
 
+var codeReasons = map[ErrCode]string{
+	AuthenticationFailed:   "UNAUTHENTICATED",
+	BadRequest:             "BAD_REQUEST",
+	InternalError:          "INTERNAL_ERROR",
+	TimeOut:                "TIMEOUT",
+	VerificationCodeFailed: "VERIFICATION_CODE_FAILED",
+	NotExist:               "NOT_FOUND",
+	PostureNotExist:        "NOT_FOUND",
+	AccountDisabled:        "ACCOUNT_DISABLED",
+	RiskRejected:           "RISK_REJECTED",
+	Conflict:               "CONFLICT",
+	TooManyRequests:        "TOO_MANY_REQUESTS",
+	EmailNotVerified:       "EMAIL_NOT_VERIFIED",
+}
+
 var (
-	ErrInternalError          = NewBizError("internal bizerr", InternalError)
-	ErrCheckQrResultError     = NewBizError("qr code check bizerr", InternalError)
-	ErrVerificationCodeFailed = NewBizError("check verification code failed", VerificationCodeFailed)
-	ErrModelNotExist          = NewBizError("model not exists", NotExist)
-	ErrPostureNotExist        = NewBizError("posture not exists", NotExist)
-	ErrClothingNotExist       = NewBizError("clothing not exists", NotExist)
-	ErrAccountNotExist        = NewBizError("account not exists", NotExist)
-	ErrActivityNotExist       = NewBizError("activity not exists", NotExist)
+	ErrInternalError           = NewBizError("internal bizerr", InternalError)
+	ErrCheckQrResultError      = NewBizError("qr code check bizerr", InternalError)
+	ErrAuthenticationFailed    = NewBizError("authentication failed", AuthenticationFailed)
+	ErrVerificationCodeFailed  = NewBizError("check verification code failed", VerificationCodeFailed)
+	ErrModelNotExist           = NewBizError("model not exists", NotExist).WithReason("MODEL_NOT_EXISTS")
+	ErrPostureNotExist         = NewBizError("posture not exists", NotExist).WithReason("POSTURE_NOT_EXISTS")
+	ErrClothingNotExist        = NewBizError("clothing not exists", NotExist).WithReason("CLOTHING_NOT_EXISTS")
+	ErrAccountNotExist         = NewBizError("account not exists", NotExist).WithReason("ACCOUNT_NOT_EXISTS")
+	ErrActivityNotExist        = NewBizError("activity not exists", NotExist).WithReason("ACTIVITY_NOT_EXISTS")
+	ErrAirdropJobNotExist      = NewBizError("airdrop job not exists", NotExist).WithReason("AIRDROP_JOB_NOT_EXISTS")
+	ErrAirdropJobRunning       = NewBizError("airdrop job is run by another instance", Conflict).WithReason("AIRDROP_JOB_RUNNING")
+	ErrBadRequest              = NewBizError("bad request", BadRequest)
+	ErrInvalidArgument         = NewBizError("invalid request", BadRequest).WithReason("VALIDATION_FAILED")
+	ErrAccountSuspended        = NewBizError("account is suspended", AccountDisabled).WithReason("ACCOUNT_SUSPENDED")
+	ErrAccountBanned           = NewBizError("account is banned", AccountDisabled).WithReason("ACCOUNT_BANNED")
+	ErrAccountDeleted          = NewBizError("account is deleted", AccountDisabled).WithReason("ACCOUNT_DELETED")
+	ErrAppealNotExist          = NewBizError("appeal not exists", NotExist).WithReason("APPEAL_NOT_EXISTS")
+	ErrAppealNotAllowed        = NewBizError("account is not suspended or banned", BadRequest).WithReason("APPEAL_NOT_ALLOWED")
+	ErrAppealPending           = NewBizError("an appeal is already pending", BadRequest).WithReason("APPEAL_PENDING")
+	ErrAppealResolved          = NewBizError("appeal is already resolved", BadRequest).WithReason("APPEAL_RESOLVED")
+	ErrRiskReviewNotExist      = NewBizError("risk review not exists", NotExist).WithReason("RISK_REVIEW_NOT_EXISTS")
+	ErrRiskReviewResolved      = NewBizError("risk review is already resolved", BadRequest).WithReason("RISK_REVIEW_RESOLVED")
+	ErrRiskThrottled           = NewBizError("too many requests, try again later", RiskRejected).WithReason("RISK_THROTTLED")
+	ErrRiskBlocked             = NewBizError("request rejected", RiskRejected).WithReason("RISK_BLOCKED")
+	ErrInvalidName             = NewBizError("invalid name", BadRequest).WithReason("INVALID_NAME")
+	ErrInvalidAvatarURL        = NewBizError("invalid avatar url", BadRequest).WithReason("INVALID_AVATAR_URL")
+	ErrNameTaken               = NewBizError("name is already taken", Conflict).WithReason("NAME_TAKEN")
+	ErrNameChangeLimited       = NewBizError("name changed too often, try again later", TooManyRequests).WithReason("NAME_CHANGE_LIMITED")
+	ErrInvalidImage            = NewBizError("invalid image", BadRequest).WithReason("INVALID_IMAGE")
+	ErrEmailNotVerified        = NewBizError("email is not verified", EmailNotVerified)
+	ErrEmailMissing            = NewBizError("account has no email", BadRequest).WithReason("EMAIL_MISSING")
+	ErrVerifyAttemptsExceeded  = NewBizError("too many wrong codes, request a new one", TooManyRequests).WithReason("VERIFY_ATTEMPTS_EXCEEDED")
+	ErrVerifyResendLimited     = NewBizError("code sent recently, try again later", TooManyRequests).WithReason("VERIFY_RESEND_LIMITED")
+	ErrWebhookNotExist         = NewBizError("webhook not exists", NotExist).WithReason("WEBHOOK_NOT_EXISTS")
+	ErrWebhookDeliveryNotExist = NewBizError("webhook delivery not exists", NotExist).WithReason("WEBHOOK_DELIVERY_NOT_EXISTS")
+	ErrAwardQuotaExceeded      = NewBizError("daily award quota exceeded", TooManyRequests).WithReason("AWARD_QUOTA_EXCEEDED")
+	ErrAwardRefConflict        = NewBizError("external ref already used for a different award", Conflict).WithReason("AWARD_REF_CONFLICT")
+	ErrNotEnoughPoints         = NewBizError("not enough points", Conflict).WithReason("NOT_ENOUGH_POINTS")
+	ErrActivityLimitReached    = NewBizError("you've reached the limit", TooManyRequests).WithReason("ACTIVITY_LIMIT_REACHED")
+	ErrWalletMismatch          = NewBizError("points address does not match the claimed wallet", Conflict).WithReason("WALLET_MISMATCH")
+	ErrRateLimited             = NewBizError("too many requests", TooManyRequests).WithReason("RATE_LIMITED")
+	ErrDailyPointsReached      = NewBizError("daily points cap reached", TooManyRequests).WithReason("DAILY_POINTS_REACHED")
+	ErrClaimLimitExceeded      = NewBizError("claim exceeds the per claim cap", BadRequest).WithReason("CLAIM_LIMIT_EXCEEDED")
+	ErrNotLeader               = NewBizError("not the leader", Conflict).WithReason("NOT_LEADER")
+	ErrAccountStateConflict    = NewBizError("account is not in a state the change applies to", Conflict).WithReason("ACCOUNT_STATE_CONFLICT")
 )
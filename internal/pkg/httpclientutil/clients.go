@@ -49,3 +49,15 @@ func MustStdClient() *http.Client {
 func GetHttpClient() *httpclient.Client {
 	return httpClientPool.Get().(*httpclient.Client)
 }
+
+// NewHttpClient returns a client that makes a single attempt per call, for
+// callers that schedule their own retries. heimdall leaves the timeout of a
+// client passed in alone, so it is set on the client here.
+func NewHttpClient(timeout time.Duration) *httpclient.Client {
+	client := MustStdClient()
+	client.Timeout = timeout
+	return httpclient.NewClient(
+		httpclient.WithHTTPClient(client),
+		httpclient.WithHTTPTimeout(timeout),
+	)
+}
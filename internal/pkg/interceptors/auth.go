@@ -0,0 +1,36 @@
+package interceptors
+
+import (
+	"context"
+	"starland-account/configs"
+	"starland-account/internal/pkg/tenant"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// healthPrefix is left open so probes don't need the token.
+const healthPrefix = "/grpc.health.v1.Health/"
+
+// Auth resolves the tenant from the x-token metadata, like the X-Token header
+// of the HTTP API.
+func Auth() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthPrefix) {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		token := ""
+		if v := md.Get("x-token"); len(v) > 0 {
+			token = v[0]
+		}
+		t := tenant.FindByToken(configs.GetConfig(), token)
+		if t == nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return handler(tenant.NewContext(ctx, t.ID), req)
+	}
+}
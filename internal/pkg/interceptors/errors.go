@@ -0,0 +1,62 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"starland-account/internal/pkg/bizerr"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	errorDomain     = "starland-account"
+	internalMessage = "internal error"
+)
+
+var bizCodes = map[bizerr.ErrCode]codes.Code{
+	bizerr.AuthenticationFailed:   codes.Unauthenticated,
+	bizerr.BadRequest:             codes.InvalidArgument,
+	bizerr.TimeOut:                codes.DeadlineExceeded,
+	bizerr.VerificationCodeFailed: codes.InvalidArgument,
+	bizerr.NotExist:               codes.NotFound,
+	bizerr.PostureNotExist:        codes.NotFound,
+	bizerr.AccountDisabled:        codes.PermissionDenied,
+	bizerr.RiskRejected:           codes.ResourceExhausted,
+	bizerr.Conflict:               codes.AlreadyExists,
+	bizerr.TooManyRequests:        codes.ResourceExhausted,
+	bizerr.EmailNotVerified:       codes.FailedPrecondition,
+}
+
+// Errors turns service errors into statuses, as middlewares.ErrorHandler does
+// for HTTP. Business errors keep their message and get a matching code;
+// internal errors only say so, their details are left to the logs. Every
+// status carries the error's reason, e.g. NOT_ENOUGH_POINTS, as an ErrorInfo
+// detail for clients to branch on.
+func Errors() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return resp, err
+		}
+		var e *bizerr.BizError
+		if errors.As(err, &e) {
+			if c, ok := bizCodes[e.Code()]; ok {
+				return resp, withReason(status.New(c, e.ClientMessage()), e.Reason())
+			}
+		}
+		return resp, withReason(status.New(codes.Internal, internalMessage), bizerr.ErrInternalError.Reason())
+	}
+}
+
+func withReason(st *status.Status, reason string) error {
+	if d, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}); err == nil {
+		st = d
+	}
+	return st.Err()
+}
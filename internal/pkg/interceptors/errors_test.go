@@ -0,0 +1,65 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"starland-account/internal/pkg/bizerr"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantMsg    string
+		wantReason string
+	}{
+		{
+			name:       "business error",
+			err:        fmt.Errorf("ClaimPoints: %w", bizerr.ErrNotEnoughPoints),
+			wantCode:   codes.AlreadyExists,
+			wantMsg:    "not enough points",
+			wantReason: "NOT_ENOUGH_POINTS",
+		},
+		{
+			name:       "internal business error",
+			err:        bizerr.ErrInternalError.Wrap(errors.New("dial tcp 10.0.0.1:3306: connection refused")),
+			wantCode:   codes.Internal,
+			wantMsg:    "internal error",
+			wantReason: "INTERNAL_ERROR",
+		},
+		{
+			name:       "other error",
+			err:        errors.New("signature: open privateKeyFile err: open /keys/key.pem: no such file"),
+			wantCode:   codes.Internal,
+			wantMsg:    "internal error",
+			wantReason: "INTERNAL_ERROR",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Errors()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test"},
+				func(context.Context, interface{}) (interface{}, error) { return nil, tt.err })
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMsg {
+				t.Errorf("status = %s %q, want %s %q", st.Code(), st.Message(), tt.wantCode, tt.wantMsg)
+			}
+			var reason string
+			for _, d := range st.Details() {
+				if info, ok := d.(*errdetails.ErrorInfo); ok {
+					reason = info.Reason
+				}
+			}
+			if reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Logging logs every call with its status and latency. Errors are logged in
+// full, before Errors hides internal details from the client.
+func Logging() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		begin := time.Now()
+		resp, err := handler(ctx, req)
+		addr := ""
+		if p, ok := peer.FromContext(ctx); ok {
+			addr = p.Addr.String()
+		}
+		if err != nil {
+			zap.S().Errorf("grpc | %s | %s | %s | %v", addr, info.FullMethod, time.Since(begin), err)
+		} else {
+			zap.S().Infof("grpc | %s | %s | %s | %s", addr, info.FullMethod, time.Since(begin), status.Code(err))
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LogMailer appends mail to a file, or the log when no file is set, instead
+// of delivering it. Meant for development and tests.
+type LogMailer struct {
+	from string
+	file string
+	mu   sync.Mutex
+}
+
+func NewLogMailer(from, file string) *LogMailer {
+	return &LogMailer{from: from, file: file}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg *Message) error {
+	if m.file == "" {
+		zap.S().Infof("LogMailer: from: %s to: %s subject: %s body: %s", m.from, msg.To, msg.Subject, msg.Body)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, err := os.OpenFile(m.file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("Send: open %s err: %w", m.file, err)
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "Date: %s\nFrom: %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC1123Z), m.from, msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("Send: write %s err: %w", m.file, err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"starland-account/configs"
+)
+
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers plain text mail.
+type Mailer interface {
+	Send(context.Context, *Message) error
+}
+
+func NewMailer(c *configs.Config) (Mailer, error) {
+	ec := c.Email
+	if ec == nil {
+		ec = &configs.EmailConfig{Mailer: DriverLog}
+	}
+	switch ec.Mailer {
+	case "", DriverLog:
+		return NewLogMailer(ec.From, ec.LogFile), nil
+	case DriverSMTP:
+		return NewSMTPMailer(ec.From, &ec.SMTP), nil
+	}
+	return nil, fmt.Errorf("NewMailer: unknown mailer %q", ec.Mailer)
+}
@@ -0,0 +1,97 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"starland-account/configs"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SMTPMailer struct {
+	from string
+	cfg  *configs.SMTPConfig
+}
+
+func NewSMTPMailer(from string, c *configs.SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{from: from, cfg: c}
+}
+
+// Send delivers over implicit TLS when configured (usually port 465),
+// otherwise upgrades with STARTTLS when the server offers it.
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	tlsCfg := &tls.Config{ServerName: m.cfg.Host}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if m.cfg.TLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsCfg)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("Send: dial %s err: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("Send: new client err: %w", err)
+	}
+	defer c.Close()
+
+	if !m.cfg.TLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err = c.StartTLS(tlsCfg); err != nil {
+				return fmt.Errorf("Send: starttls err: %w", err)
+			}
+		}
+	}
+	if m.cfg.Username != "" {
+		if err = c.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return fmt.Errorf("Send: auth err: %w", err)
+		}
+	}
+	if err = c.Mail(m.from); err != nil {
+		return fmt.Errorf("Send: mail from err: %w", err)
+	}
+	if err = c.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("Send: rcpt %s err: %w", msg.To, err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("Send: data err: %w", err)
+	}
+	if _, err = w.Write(buildMessage(m.from, msg)); err != nil {
+		return fmt.Errorf("Send: write err: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("Send: close data err: %w", err)
+	}
+	return c.Quit()
+}
+
+func buildMessage(from string, msg *Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
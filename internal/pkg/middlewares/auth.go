@@ -1,19 +1,137 @@
 package middlewares
 
 import (
-	"net/http"
+	"crypto/subtle"
 	"starland-account/configs"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/tenant"
+	"starland-account/internal/pkg/util"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func Auth() func(ctx *fiber.Ctx) error {
+// Auth resolves the tenant from X-Token; everything behind it runs in that
+// tenant. Paths under the exempt prefixes authenticate on their own, as the
+// award API does with ClientAuth, and are let through.
+func Auth(exempt ...string) func(ctx *fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
-		token := ctx.Get("X-Token")
-		if configs.GetConfig().Token != token {
-			return ctx.SendStatus(http.StatusUnauthorized)
-		} else {
-			return ctx.Next()
+		for _, prefix := range exempt {
+			if strings.HasPrefix(ctx.Path(), prefix) {
+				return ctx.Next()
+			}
+		}
+		t := tenant.FindByToken(configs.GetConfig(), ctx.Get("X-Token"))
+		if t == nil {
+			return bizerr.ErrAuthenticationFailed
+		}
+		ctx.Locals(tenant.ContextKey, t.ID)
+		return ctx.Next()
+	}
+}
+
+// AdminAuth authenticates X-Admin-Token and stores the admin's name under
+// AdminKey, for the audit trail of the changes it makes.
+func AdminAuth() func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		name := findAdmin(configs.GetConfig(), ctx.Get("X-Admin-Token"))
+		if name == "" {
+			return bizerr.ErrAuthenticationFailed
+		}
+		ctx.Locals(AdminKey, name)
+		return ctx.Next()
+	}
+}
+
+// Admin returns the name of the admin AdminAuth authenticated.
+func Admin(ctx *fiber.Ctx) string {
+	name, _ := ctx.Locals(AdminKey).(string)
+	return name
+}
+
+func findAdmin(c *configs.Config, token string) string {
+	if token == "" {
+		return ""
+	}
+	for i := range c.Admins {
+		if c.Admins[i].Name != "" && subtle.ConstantTimeCompare([]byte(c.Admins[i].Token), []byte(token)) == 1 {
+			return c.Admins[i].Name
+		}
+	}
+	if c.AdminToken != "" && subtle.ConstantTimeCompare([]byte(c.AdminToken), []byte(token)) == 1 {
+		return sharedAdmin
+	}
+	return ""
+}
+
+const (
+	// ClientKey is the ctx.Locals key ClientAuth stores the client id under.
+	ClientKey = "client_id"
+	// AdminKey is the ctx.Locals key AdminAuth stores the admin's name under.
+	AdminKey = "admin"
+
+	// sharedAdmin is the name of whoever holds admin_token
+	sharedAdmin = "admin"
+
+	defaultMaxSkew = 5 * time.Minute
+)
+
+// ClientAuth authenticates server to server calls in place of Auth: X-Api-Key
+// names an award client, X-Timestamp must be within award.max_skew seconds of
+// now and X-Signature is util.SignRequest of the request with the client's
+// secret. The request runs in the client's tenant.
+func ClientAuth() func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		c := configs.GetConfig()
+		ac := c.Award
+		if ac == nil {
+			return bizerr.ErrAuthenticationFailed
+		}
+		client := FindAwardClient(ac, ctx.Get("X-Api-Key"))
+		if client == nil {
+			return bizerr.ErrAuthenticationFailed
+		}
+		clientTenant := client.Tenant
+		if clientTenant == "" {
+			clientTenant = tenant.Default
+		}
+		if tenant.Find(c, clientTenant) == nil {
+			return bizerr.ErrAuthenticationFailed
+		}
+
+		ts := ctx.Get("X-Timestamp")
+		unix, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return bizerr.ErrAuthenticationFailed
+		}
+		skew := defaultMaxSkew
+		if ac.MaxSkew > 0 {
+			skew = ac.MaxSkew * time.Second
+		}
+		if d := time.Since(time.Unix(unix, 0)); d > skew || d < -skew {
+			return bizerr.ErrAuthenticationFailed
+		}
+		if !util.VerifyRequest(client.Secret, ctx.Method(), ctx.OriginalURL(), ts, ctx.Body(), ctx.Get("X-Signature")) {
+			return bizerr.ErrAuthenticationFailed
+		}
+
+		ctx.Locals(tenant.ContextKey, clientTenant)
+		ctx.Locals(ClientKey, client.ID)
+		return ctx.Next()
+	}
+}
+
+// FindAwardClient returns the client with the api key, or nil.
+func FindAwardClient(ac *configs.AwardConfig, apiKey string) *configs.AwardClientConfig {
+	if apiKey == "" {
+		return nil
+	}
+	for i := range ac.Clients {
+		if subtle.ConstantTimeCompare([]byte(ac.Clients[i].APIKey), []byte(apiKey)) == 1 {
+			return &ac.Clients[i]
 		}
 	}
+	return nil
 }
@@ -0,0 +1,122 @@
+package middlewares
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"starland-account/configs"
+	"starland-account/internal/pkg/tenant"
+	"starland-account/internal/pkg/util"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newClientAuthApp(t *testing.T) *fiber.App {
+	t.Helper()
+	configs.Replace(&configs.Config{
+		Tenants: []configs.TenantConfig{{ID: "other", Token: "other-token"}},
+		Award: &configs.AwardConfig{
+			MaxSkew: 60,
+			Clients: []configs.AwardClientConfig{
+				{ID: "partner", APIKey: "partner-key", Secret: "partner-secret"},
+				{ID: "other-partner", APIKey: "other-key", Secret: "other-secret", Tenant: "other"},
+				{ID: "gone", APIKey: "gone-key", Secret: "gone-secret", Tenant: "gone"},
+			},
+		},
+	})
+	t.Cleanup(func() { configs.Replace(&configs.Config{}) })
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Post("/v1/internal/awards", ClientAuth(), func(ctx *fiber.Ctx) error {
+		id, _ := tenant.FromContext(ctx.Context())
+		client, _ := ctx.Locals(ClientKey).(string)
+		return ctx.SendString(id + "/" + client)
+	})
+	return app
+}
+
+// signedRequest signs the request as an award client does; the mutators
+// tamper with it after signing.
+func signedRequest(apiKey, secret string, ts time.Time, body string, mutate ...func(*http.Request)) *http.Request {
+	const target = "/v1/internal/awards?dry_run=1"
+	unix := strconv.FormatInt(ts.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set("X-Api-Key", apiKey)
+	req.Header.Set("X-Timestamp", unix)
+	req.Header.Set("X-Signature", util.SignRequest(secret, http.MethodPost, target, unix, []byte(body)))
+	for _, fn := range mutate {
+		fn(req)
+	}
+	return req
+}
+
+func TestClientAuth(t *testing.T) {
+	app := newClientAuthApp(t)
+	now := time.Now()
+	body := `{"items":[{"external_ref":"r1","account_id":"a1","points":5}]}`
+	cases := []struct {
+		name   string
+		req    *http.Request
+		status int
+		want   string
+	}{
+		{"signed", signedRequest("partner-key", "partner-secret", now, body), http.StatusOK, "default/partner"},
+		{"runs in the client's tenant", signedRequest("other-key", "other-secret", now, body), http.StatusOK, "other/other-partner"},
+		{"within the skew", signedRequest("partner-key", "partner-secret", now.Add(-50*time.Second), body), http.StatusOK, "default/partner"},
+		{"unknown api key", signedRequest("guess", "partner-secret", now, body), http.StatusUnauthorized, ""},
+		{"another client's secret", signedRequest("partner-key", "other-secret", now, body), http.StatusUnauthorized, ""},
+		{"unconfigured tenant", signedRequest("gone-key", "gone-secret", now, body), http.StatusUnauthorized, ""},
+		{"stale", signedRequest("partner-key", "partner-secret", now.Add(-2*time.Minute), body), http.StatusUnauthorized, ""},
+		{"from the future", signedRequest("partner-key", "partner-secret", now.Add(2*time.Minute), body), http.StatusUnauthorized, ""},
+		{"garbled timestamp", signedRequest("partner-key", "partner-secret", now, body, func(r *http.Request) {
+			r.Header.Set("X-Timestamp", "yesterday")
+		}), http.StatusUnauthorized, ""},
+		{"timestamp changed after signing", signedRequest("partner-key", "partner-secret", now, body, func(r *http.Request) {
+			r.Header.Set("X-Timestamp", strconv.FormatInt(now.Unix()-1, 10))
+		}), http.StatusUnauthorized, ""},
+		{"body changed after signing", signedRequest("partner-key", "partner-secret", now, body, func(r *http.Request) {
+			tampered := strings.Replace(body, `"points":5`, `"points":500`, 1)
+			r.Body, r.ContentLength = io.NopCloser(strings.NewReader(tampered)), int64(len(tampered))
+		}), http.StatusUnauthorized, ""},
+		{"query changed after signing", signedRequest("partner-key", "partner-secret", now, body, func(r *http.Request) {
+			r.URL.RawQuery, r.RequestURI = "dry_run=0", "/v1/internal/awards?dry_run=0"
+		}), http.StatusUnauthorized, ""},
+		{"unsigned", signedRequest("partner-key", "partner-secret", now, body, func(r *http.Request) {
+			r.Header.Del("X-Signature")
+		}), http.StatusUnauthorized, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, err := app.Test(c.req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			got, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != c.status {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, c.status, got)
+			}
+			if c.want != "" && string(got) != c.want {
+				t.Errorf("ran as %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestClientAuthUnconfigured(t *testing.T) {
+	app := newClientAuthApp(t)
+	configs.Replace(&configs.Config{})
+	resp, err := app.Test(signedRequest("partner-key", "partner-secret", time.Now(), "{}"), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d without award config, want 401", resp.StatusCode)
+	}
+}
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"net/http"
+	"starland-account/configs"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+)
+
+// routeRequests counts requests by matched route, so traffic left on v1 can
+// be followed until it is retired.
+var routeRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "starland_account_http_route_requests_total",
+	Help: "HTTP requests by API version, method and route.",
+}, []string{"version", "method", "route"})
+
+// RouteUsage counts every request that reached a versioned route; requests
+// stopped by a middleware, or matching none, are left to fiberprometheus.
+func RouteUsage() func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		err := ctx.Next()
+		route := ctx.Route()
+		if route.Method == "USE" {
+			return err
+		}
+		if version, _, ok := strings.Cut(strings.TrimPrefix(route.Path, "/"), "/"); ok && strings.HasPrefix(version, "v") {
+			routeRequests.WithLabelValues(version, route.Method, route.Path).Inc()
+		}
+		return err
+	}
+}
+
+// Deprecated marks a route as deprecated and links its successor, a path
+// whose :param segments are filled from the request's params; params the
+// route lacks are left as {param}. The Sunset header is sent when
+// http.v1_sunset is set.
+func Deprecated(conf *configs.Config, successor string) func(ctx *fiber.Ctx) error {
+	sunset := ""
+	if conf != nil && conf.HTTP != nil && conf.HTTP.V1Sunset != "" {
+		t, err := time.Parse("2006-01-02", conf.HTTP.V1Sunset)
+		if err != nil {
+			zap.S().Errorf("Deprecated: invalid v1_sunset %s err: %v", conf.HTTP.V1Sunset, err)
+		} else {
+			sunset = t.UTC().Format(http.TimeFormat)
+		}
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		ctx.Set("Deprecation", "true")
+		if sunset != "" {
+			ctx.Set("Sunset", sunset)
+		}
+		ctx.Set(fiber.HeaderLink, "<"+successorPath(ctx, successor)+`>; rel="successor-version"`)
+		return ctx.Next()
+	}
+}
+
+func successorPath(ctx *fiber.Ctx, successor string) string {
+	segs := strings.Split(successor, "/")
+	for i, seg := range segs {
+		if !strings.HasPrefix(seg, ":") {
+			continue
+		}
+		if v := ctx.Params(seg[1:]); v != "" {
+			segs[i] = v
+		} else {
+			segs[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
@@ -0,0 +1,60 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/util"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+var bizStatuses = map[bizerr.ErrCode]int{
+	bizerr.AuthenticationFailed:   http.StatusUnauthorized,
+	bizerr.BadRequest:             http.StatusBadRequest,
+	bizerr.VerificationCodeFailed: http.StatusBadRequest,
+	bizerr.NotExist:               http.StatusNotFound,
+	bizerr.PostureNotExist:        http.StatusNotFound,
+	bizerr.AccountDisabled:        http.StatusForbidden,
+	bizerr.EmailNotVerified:       http.StatusForbidden,
+	bizerr.Conflict:               http.StatusConflict,
+	bizerr.RiskRejected:           http.StatusTooManyRequests,
+	bizerr.TooManyRequests:        http.StatusTooManyRequests,
+}
+
+// ErrorHandler is the app's fiber.ErrorHandler, so handlers just return their
+// errors. Business errors get the status of their code and their reason as
+// code, with the failing fields for validation errors; anything else is a 500
+// whose details only go to the log. Every error body carries the request id.
+func ErrorHandler(ctx *fiber.Ctx, err error) error {
+	status, res := http.StatusInternalServerError, &util.Response{
+		Code: bizerr.ErrInternalError.Reason(),
+		Msg:  http.StatusText(http.StatusInternalServerError),
+	}
+
+	var (
+		be *bizerr.BizError
+		fe *fiber.Error
+	)
+	switch {
+	case errors.As(err, &be):
+		if s, ok := bizStatuses[be.Code()]; ok {
+			status = s
+			res.Code = be.Reason()
+			res.Msg = be.ClientMessage()
+			errors.As(err, &res.Errors)
+		}
+	case errors.As(err, &fe):
+		status = fe.Code
+		res.Code = strings.ToUpper(strings.ReplaceAll(http.StatusText(fe.Code), " ", "_"))
+		res.Msg = fe.Message
+	}
+
+	res.RequestID = ctx.GetRespHeader(fiber.HeaderXRequestID)
+	if status >= http.StatusInternalServerError {
+		zap.S().Errorf("ErrorHandler: %s %s request: %s err: %v", ctx.Method(), ctx.Path(), res.RequestID, err)
+	}
+	return ctx.Status(status).JSON(res)
+}
@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/util"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// ValidateRequest rejects JSON bodies that don't match the operation's
+// request body schema in doc as bad requests. Routes missing from doc and
+// other content types pass through.
+func ValidateRequest(doc *openapi3.T) (func(ctx *fiber.Ctx) error, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx *fiber.Ctx) error {
+		if !strings.HasPrefix(ctx.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
+			return ctx.Next()
+		}
+		req, err := adaptor.ConvertRequest(ctx, false)
+		if err != nil {
+			return bizerr.ErrBadRequest.Wrap(err)
+		}
+		route, params, err := router.FindRoute(req)
+		if err != nil || route.Operation.RequestBody == nil {
+			return ctx.Next()
+		}
+		err = openapi3filter.ValidateRequestBody(ctx.Context(), &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: params,
+			Route:      route,
+		}, route.Operation.RequestBody.Value)
+		if err != nil {
+			return requestError(err)
+		}
+		return ctx.Next()
+	}, nil
+}
+
+// requestError reports schema violations as field errors, like
+// util.Validate, and drops the dump of the schema and value kin-openapi
+// appends.
+func requestError(err error) error {
+	if re, ok := err.(*openapi3filter.RequestError); ok {
+		if se, ok := re.Err.(*openapi3.SchemaError); ok {
+			return bizerr.ErrInvalidArgument.Wrap(util.FieldErrors{{
+				Field:  strings.Join(se.JSONPointer(), "."),
+				Reason: se.Reason,
+			}})
+		}
+		return bizerr.ErrBadRequest.Wrap(re)
+	}
+	return bizerr.ErrBadRequest.Wrap(err)
+}
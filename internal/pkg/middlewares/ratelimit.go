@@ -0,0 +1,165 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/tenant"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+)
+
+const (
+	RateLimitKeyIP      = "ip"
+	RateLimitKeyAccount = "account"
+	RateLimitKeyToken   = "token"
+
+	rateLimitKey = "starland-account:ratelimit:%s:%s:%s"
+)
+
+type RateLimiter interface {
+	Take(context.Context, string, int, time.Duration) (*biz.RateLimitResult, error)
+}
+
+// RateLimit applies the first route policy matching the request, or the
+// default policy, from the rate_limit config. It is mounted after Auth, so
+// account and token policies count against the tenant the request
+// authenticated as. The config is read per request so policy changes apply on
+// reload. Limiter errors let the request through.
+func RateLimit(limiter RateLimiter) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		if _, ok := tenant.FromContext(ctx.Context()); !ok {
+			// exempt from Auth, counted by RateLimitUnauthenticated
+			return ctx.Next()
+		}
+		return rateLimit(ctx, limiter, rateLimitSubject)
+	}
+}
+
+// RateLimitUnauthenticated is mounted before Auth and applies the same
+// policies, counted by IP only, to the requests Auth is going to refuse, so
+// nothing a caller without a valid X-Token sends can use up a tenant's or an
+// account's quota, and guessing tokens is limited too. Authenticated requests
+// pass on to RateLimit.
+func RateLimitUnauthenticated(limiter RateLimiter) func(ctx *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		if tenant.FindByToken(configs.GetConfig(), ctx.Get("X-Token")) != nil {
+			return ctx.Next()
+		}
+		return rateLimit(ctx, limiter, func(*fiber.Ctx, string, map[string]string) (string, string) {
+			return RateLimitKeyIP, ctx.IP()
+		})
+	}
+}
+
+type subjectFunc func(ctx *fiber.Ctx, key string, params map[string]string) (string, string)
+
+func rateLimit(ctx *fiber.Ctx, limiter RateLimiter, subject subjectFunc) error {
+	rc := configs.GetConfig().RateLimit
+	if rc == nil || !rc.Enable {
+		return ctx.Next()
+	}
+
+	name, policy, params := "default", &rc.Default, map[string]string(nil)
+	for i := range rc.Routes {
+		if p, ok := matchRoute(&rc.Routes[i], ctx.Method(), ctx.Path()); ok {
+			name, policy, params = rc.Routes[i].Method+" "+rc.Routes[i].Path, &rc.Routes[i], p
+			break
+		}
+	}
+	if policy.Limit <= 0 || policy.Window <= 0 {
+		return ctx.Next()
+	}
+
+	kind, value := subject(ctx, policy.Key, params)
+	key := fmt.Sprintf(rateLimitKey, name, kind, value)
+	res, err := limiter.Take(ctx.Context(), key, policy.Limit, policy.Window*time.Second)
+	if err != nil {
+		zap.S().Errorf("RateLimit: take(%s) err: %v", key, err)
+		return ctx.Next()
+	}
+
+	reset := strconv.Itoa(int(math.Ceil(res.Reset.Seconds())))
+	ctx.Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+	ctx.Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	ctx.Set("X-RateLimit-Reset", reset)
+	if !res.Allowed {
+		ctx.Set(fiber.HeaderRetryAfter, reset)
+		return bizerr.ErrRateLimited
+	}
+	return ctx.Next()
+}
+
+// rateLimitSubject resolves what an authenticated request is counted against:
+// the tenant Auth resolved for token policies, and for account policies the
+// account the tenant acts for, counted apart per tenant as ids may collide.
+// It falls back to the client IP when the account is missing.
+func rateLimitSubject(ctx *fiber.Ctx, key string, params map[string]string) (string, string) {
+	id, _ := tenant.FromContext(ctx.Context())
+	switch key {
+	case RateLimitKeyAccount:
+		if account := requestAccount(ctx, params); account != "" {
+			if id != tenant.Default {
+				account = id + "/" + account
+			}
+			return RateLimitKeyAccount, account
+		}
+	case RateLimitKeyToken:
+		return RateLimitKeyToken, id
+	}
+	return RateLimitKeyIP, ctx.IP()
+}
+
+func requestAccount(ctx *fiber.Ctx, params map[string]string) string {
+	for _, name := range []string{"id", "account"} {
+		if v := params[name]; v != "" {
+			return v
+		}
+	}
+	for _, name := range []string{"account", "account_id"} {
+		if v := ctx.Query(name); v != "" {
+			return v
+		}
+	}
+	if strings.HasPrefix(ctx.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
+		body := ctx.Body()
+		for _, name := range []string{"account", "account_id"} {
+			if v := gjson.GetBytes(body, name).String(); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// matchRoute matches fiber style paths with :param segments and returns the
+// captured params.
+func matchRoute(p *configs.RateLimitPolicyConfig, method, path string) (map[string]string, bool) {
+	if p.Method != "" && !strings.EqualFold(p.Method, method) {
+		return nil, false
+	}
+	want := strings.Split(strings.Trim(p.Path, "/"), "/")
+	got := strings.Split(strings.Trim(path, "/"), "/")
+	if len(want) != len(got) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i := range want {
+		if strings.HasPrefix(want[i], ":") {
+			params[want[i][1:]] = got[i]
+			continue
+		}
+		if want[i] != got[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
@@ -0,0 +1,195 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeRateLimitRepo counts the hits per key in memory; Take fails while err
+// is set.
+type fakeRateLimitRepo struct {
+	hits map[string]int64
+	err  error
+}
+
+func (r *fakeRateLimitRepo) Take(_ context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	if r.err != nil {
+		return 0, 0, r.err
+	}
+	r.hits[key]++
+	return r.hits[key], window, nil
+}
+
+func newRateLimitApp(t *testing.T) (*fiber.App, *fakeRateLimitRepo) {
+	t.Helper()
+	configs.Replace(&configs.Config{
+		Token:   "default-token",
+		Tenants: []configs.TenantConfig{{ID: "other", Token: "other-token"}},
+		RateLimit: &configs.RateLimitConfig{
+			Enable:  true,
+			Default: configs.RateLimitPolicyConfig{Key: RateLimitKeyIP, Limit: 3, Window: 60},
+			Routes: []configs.RateLimitPolicyConfig{
+				{Method: http.MethodGet, Path: "/v1/account/:id", Key: RateLimitKeyAccount, Limit: 1, Window: 60},
+				{Method: http.MethodPost, Path: "/v1/activity/play", Key: RateLimitKeyToken, Limit: 1, Window: 30},
+			},
+		},
+	})
+	t.Cleanup(func() { configs.Replace(&configs.Config{}) })
+
+	repo := &fakeRateLimitRepo{hits: make(map[string]int64)}
+	limiter := biz.NewRateLimitUsecase(repo)
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(RateLimitUnauthenticated(limiter), Auth(), RateLimit(limiter))
+	ok := func(ctx *fiber.Ctx) error { return ctx.SendStatus(http.StatusOK) }
+	app.Get("/v1/account/:id", ok)
+	app.Post("/v1/activity/play", ok)
+	app.Get("/v1/activitys", ok)
+	return app, repo
+}
+
+func doRateLimited(t *testing.T, app *fiber.App, method, target, token, body string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("X-Token", token)
+	}
+	if body != "" {
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	}
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, target, err)
+	}
+	return resp
+}
+
+func TestRateLimit(t *testing.T) {
+	type call struct {
+		method, target, token, body string
+		status                      int
+	}
+	cases := []struct {
+		name  string
+		calls []call
+	}{
+		{
+			name: "account policy counts each tenant's account apart",
+			calls: []call{
+				{http.MethodGet, "/v1/account/a1", "default-token", "", http.StatusOK},
+				{http.MethodGet, "/v1/account/a1", "other-token", "", http.StatusOK},
+				{http.MethodGet, "/v1/account/a1", "default-token", "", http.StatusTooManyRequests},
+				{http.MethodGet, "/v1/account/a2", "default-token", "", http.StatusOK},
+			},
+		},
+		{
+			name: "token policy counts the tenant, whatever account the body names",
+			calls: []call{
+				{http.MethodPost, "/v1/activity/play", "default-token", `{"account":"a1"}`, http.StatusOK},
+				{http.MethodPost, "/v1/activity/play", "default-token", `{"account":"a2"}`, http.StatusTooManyRequests},
+				{http.MethodPost, "/v1/activity/play", "other-token", `{"account":"a1"}`, http.StatusOK},
+			},
+		},
+		{
+			name: "default policy for unmatched routes",
+			calls: []call{
+				{http.MethodGet, "/v1/activitys", "default-token", "", http.StatusOK},
+				{http.MethodGet, "/v1/activitys", "default-token", "", http.StatusOK},
+				{http.MethodGet, "/v1/activitys", "default-token", "", http.StatusOK},
+				{http.MethodGet, "/v1/activitys", "default-token", "", http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "unauthenticated requests don't use up the account's quota",
+			calls: []call{
+				{http.MethodGet, "/v1/account/a1", "guess", "", http.StatusUnauthorized},
+				{http.MethodGet, "/v1/account/a1", "", "", http.StatusTooManyRequests},
+				{http.MethodGet, "/v1/account/a1", "default-token", "", http.StatusOK},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			app, _ := newRateLimitApp(t)
+			for i, call := range c.calls {
+				resp := doRateLimited(t, app, call.method, call.target, call.token, call.body)
+				if resp.StatusCode != call.status {
+					t.Errorf("call %d: %s %s = %d, want %d", i, call.method, call.target, resp.StatusCode, call.status)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	app, _ := newRateLimitApp(t)
+	resp := doRateLimited(t, app, http.MethodPost, "/v1/activity/play", "default-token", `{"account":"a1"}`)
+	for header, want := range map[string]string{"X-RateLimit-Limit": "1", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "30"} {
+		if got := resp.Header.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+	if got := resp.Header.Get(fiber.HeaderRetryAfter); got != "" {
+		t.Errorf("Retry-After = %q on an allowed request", got)
+	}
+
+	resp = doRateLimited(t, app, http.MethodPost, "/v1/activity/play", "default-token", `{"account":"a1"}`)
+	if got := resp.Header.Get(fiber.HeaderRetryAfter); resp.StatusCode != http.StatusTooManyRequests || got != "30" {
+		t.Errorf("refused request = %d with Retry-After %q, want 429 with 30", resp.StatusCode, got)
+	}
+}
+
+func TestRateLimitLimiterDown(t *testing.T) {
+	app, repo := newRateLimitApp(t)
+	repo.err = errors.New("redis down")
+	for i := 0; i < 3; i++ {
+		if resp := doRateLimited(t, app, http.MethodGet, "/v1/account/a1", "default-token", ""); resp.StatusCode != http.StatusOK {
+			t.Fatalf("call %d = %d, want the request let through", i, resp.StatusCode)
+		}
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	app, repo := newRateLimitApp(t)
+	c := *configs.GetConfig()
+	c.RateLimit = &configs.RateLimitConfig{Enable: false, Default: c.RateLimit.Default, Routes: c.RateLimit.Routes}
+	configs.Replace(&c)
+	for i := 0; i < 3; i++ {
+		if resp := doRateLimited(t, app, http.MethodGet, "/v1/account/a1", "default-token", ""); resp.StatusCode != http.StatusOK {
+			t.Fatalf("call %d = %d, want the request let through", i, resp.StatusCode)
+		}
+	}
+	if len(repo.hits) != 0 {
+		t.Errorf("hits = %v, want nothing counted", repo.hits)
+	}
+}
+
+func TestMatchRoute(t *testing.T) {
+	policy := &configs.RateLimitPolicyConfig{Method: http.MethodGet, Path: "/v1/account/:id"}
+	cases := []struct {
+		method, path string
+		ok           bool
+		id           string
+	}{
+		{http.MethodGet, "/v1/account/a1", true, "a1"},
+		{"get", "/v1/account/a1/", true, "a1"},
+		{http.MethodPost, "/v1/account/a1", false, ""},
+		{http.MethodGet, "/v1/account", false, ""},
+		{http.MethodGet, "/v1/account/a1/export", false, ""},
+		{http.MethodGet, "/v2/account/a1", false, ""},
+	}
+	for _, c := range cases {
+		params, ok := matchRoute(policy, c.method, c.path)
+		if ok != c.ok || params["id"] != c.id {
+			t.Errorf("matchRoute(%s %s) = %v, %v, want id %q, %v", c.method, c.path, params, ok, c.id, c.ok)
+		}
+	}
+}
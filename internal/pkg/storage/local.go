@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"starland-account/configs"
+	"strings"
+)
+
+const defaultLocalDir = "./image"
+
+// LocalStorage keeps objects on the local filesystem, e.g. the /app/image
+// volume mounted by control.sh.
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStorage(c *configs.LocalStorageConfig) *LocalStorage {
+	dir := c.Dir
+	if dir == "" {
+		dir = defaultLocalDir
+	}
+	return &LocalStorage{dir: dir, baseURL: strings.TrimRight(c.BaseURL, "/")}
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("Put: mkdir err: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", fmt.Errorf("Put: write %s err: %w", key, err)
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("Put: rename %s err: %w", key, err)
+	}
+	return s.baseURL + "/" + key, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("Delete: remove %s err: %w", key, err)
+	}
+	return nil
+}
+
+// Dir is the root directory objects are written to.
+func (s *LocalStorage) Dir() string {
+	return s.dir
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(s.dir, clean), nil
+}
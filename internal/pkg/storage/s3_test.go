@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"starland-account/configs"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testBucket    = "avatars"
+)
+
+// s3Stub is a MinIO-like path style S3 endpoint keeping objects in memory.
+// Writes must carry a valid SigV4 signature, reads are public.
+type s3Stub struct {
+	mu      sync.Mutex
+	objects map[string]s3Object
+}
+
+type s3Object struct {
+	data        []byte
+	contentType string
+}
+
+func newS3Stub(t *testing.T) (*s3Stub, *httptest.Server) {
+	t.Helper()
+	stub := &s3Stub{objects: make(map[string]s3Object)}
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+	return stub, srv
+}
+
+func (s *s3Stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	key := r.URL.Path
+	if !strings.HasPrefix(key, "/"+testBucket+"/") {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodGet:
+		obj, ok := s.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		_, _ = w.Write(obj.data)
+	case http.MethodPut, http.MethodDelete:
+		if !verifySigV4(r, body) {
+			http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+			return
+		}
+		if r.Method == http.MethodPut {
+			s.objects[key] = s3Object{data: body, contentType: r.Header.Get("Content-Type")}
+		} else {
+			delete(s.objects, key)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// verifySigV4 recomputes the signature of the request from the headers its
+// Authorization names, as S3 does.
+func verifySigV4(r *http.Request, body []byte) bool {
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), s3Algorithm+" ")
+	fields := make(map[string]string)
+	for _, part := range strings.Split(auth, ", ") {
+		if k, v, ok := strings.Cut(part, "="); ok {
+			fields[k] = v
+		}
+	}
+	scope := strings.SplitN(fields["Credential"], "/", 2)
+	if len(scope) != 2 || scope[0] != testAccessKey {
+		return false
+	}
+	if r.Header.Get("X-Amz-Content-Sha256") != sha256Hex(body) {
+		return false
+	}
+
+	signed := strings.Split(fields["SignedHeaders"], ";")
+	if !sort.StringsAreSorted(signed) {
+		return false
+	}
+	var canonicalHeaders strings.Builder
+	for _, name := range signed {
+		v := r.Header.Get(name)
+		if name == "host" {
+			v = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(v) + "\n")
+	}
+	canonicalRequest := strings.Join([]string{
+		r.Method, r.URL.EscapedPath(), r.URL.RawQuery,
+		canonicalHeaders.String(), fields["SignedHeaders"], r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	stringToSign := strings.Join([]string{
+		s3Algorithm, r.Header.Get("X-Amz-Date"), scope[1], sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	// date/region/service/aws4_request
+	parts := strings.Split(scope[1], "/")
+	if len(parts) != 4 {
+		return false
+	}
+	key := []byte("AWS4" + testSecretKey)
+	for _, p := range parts {
+		key = hmacSHA256(key, p)
+	}
+	want := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	return hmac.Equal([]byte(want), []byte(fields["Signature"]))
+}
+
+func newTestS3(t *testing.T, endpoint, secretKey string) *S3Storage {
+	t.Helper()
+	s, err := NewS3Storage(&configs.S3StorageConfig{
+		Endpoint:  endpoint,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: secretKey,
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatalf("NewS3Storage: %v", err)
+	}
+	return s
+}
+
+func TestS3Storage(t *testing.T) {
+	stub, srv := newS3Stub(t)
+	s := newTestS3(t, srv.URL, testSecretKey)
+	ctx := context.Background()
+	data := []byte("\x89PNG\r\n\x1a\nnot really")
+
+	url, err := s.Put(ctx, "avatar/a1/x_64.png", data, "image/png")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if want := srv.URL + "/" + testBucket + "/avatar/a1/x_64.png"; url != want {
+		t.Errorf("Put url = %s, want %s", url, want)
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	got, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !bytes.Equal(got, data) || resp.Header.Get("Content-Type") != "image/png" {
+		t.Errorf("GET = %d %s %q, want the stored png", resp.StatusCode, resp.Header.Get("Content-Type"), got)
+	}
+
+	if err = s.Delete(ctx, "avatar/a1/x_64.png"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	stub.mu.Lock()
+	n := len(stub.objects)
+	stub.mu.Unlock()
+	if n != 0 {
+		t.Errorf("%d objects left after Delete", n)
+	}
+}
+
+func TestS3StorageBadSignature(t *testing.T) {
+	stub, srv := newS3Stub(t)
+	s := newTestS3(t, srv.URL, "not-the-secret")
+
+	if _, err := s.Put(context.Background(), "avatar/a1/x_64.png", []byte("x"), "image/png"); err == nil {
+		t.Fatal("Put signed with the wrong secret succeeded")
+	}
+	if len(stub.objects) != 0 {
+		t.Errorf("object stored despite the bad signature")
+	}
+}
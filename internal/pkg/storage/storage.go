@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"starland-account/configs"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// Storage stores public objects such as avatars.
+type Storage interface {
+	// Put stores the object under key and returns its public URL.
+	Put(ctx context.Context, key string, data []byte, contentType string) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func NewStorage(c *configs.Config) (Storage, error) {
+	sc := c.Storage
+	if sc == nil {
+		sc = &configs.StorageConfig{Driver: DriverLocal}
+	}
+	switch sc.Driver {
+	case "", DriverLocal:
+		return NewLocalStorage(&sc.Local), nil
+	case DriverS3:
+		return NewS3Storage(&sc.S3)
+	}
+	return nil, fmt.Errorf("NewStorage: unknown driver %q", sc.Driver)
+}
@@ -0,0 +1,91 @@
+package tenant
+
+import (
+	"context"
+	"crypto/subtle"
+	"starland-account/configs"
+)
+
+// Default owns the data from before tenants existed.
+const Default = "default"
+
+type contextKey struct{ name string }
+
+// ContextKey carries the tenant id in context values, including fiber's
+// Locals, which handlers see through ctx.Context().
+var ContextKey = &contextKey{"tenant"}
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ContextKey, id)
+}
+
+// FromContext returns the request's tenant id, false outside of a tenant.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(ContextKey).(string)
+	return id, ok && id != ""
+}
+
+// Detach returns a background context with ctx's tenant, for work that
+// outlives the request, such as a streamed body.
+func Detach(ctx context.Context) context.Context {
+	id, _ := FromContext(ctx)
+	return NewContext(context.Background(), id)
+}
+
+// Tenants returns the configured tenants, with the default tenant completed
+// from the top level config and added when it is not configured.
+func Tenants(c *configs.Config) []*configs.TenantConfig {
+	res := make([]*configs.TenantConfig, 0, len(c.Tenants)+1)
+	hasDefault := false
+	for i := range c.Tenants {
+		t := c.Tenants[i]
+		if t.ID == Default {
+			hasDefault = true
+			if t.Token == "" {
+				t.Token = c.Token
+			}
+			if t.PrivatePath == "" {
+				t.PrivatePath = c.PrivatePath
+			}
+		}
+		if t.Env == "" {
+			t.Env = c.Env
+		}
+		res = append(res, &t)
+	}
+	if !hasDefault {
+		res = append(res, &configs.TenantConfig{ID: Default, Token: c.Token, PrivatePath: c.PrivatePath, Env: c.Env})
+	}
+	return res
+}
+
+// Find returns the tenant with the id, or nil.
+func Find(c *configs.Config, id string) *configs.TenantConfig {
+	for _, t := range Tenants(c) {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// FindByToken returns the tenant whose token is token, or nil.
+func FindByToken(c *configs.Config, token string) *configs.TenantConfig {
+	if token == "" {
+		return nil
+	}
+	for _, t := range Tenants(c) {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) == 1 {
+			return t
+		}
+	}
+	return nil
+}
+
+// Each runs fn once per tenant with ctx scoped to it, for background work
+// spanning tenants.
+func Each(ctx context.Context, c *configs.Config, fn func(context.Context, *configs.TenantConfig)) {
+	for _, t := range Tenants(c) {
+		fn(NewContext(ctx, t.ID), t)
+	}
+}
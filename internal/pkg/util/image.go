@@ -0,0 +1,91 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const maxImagePixels = 4096 * 4096
+
+var ErrUnsupportedImage = errors.New("unsupported image type")
+
+// DecodeImage sniffs and decodes a jpeg, png, gif or webp image, rejecting
+// images too large to decode safely. Only pixel data is kept, so metadata
+// such as EXIF is dropped.
+func DecodeImage(data []byte) (image.Image, string, error) {
+	contentType := http.DetectContentType(data)
+	var (
+		decodeConfig func([]byte) (image.Config, error)
+		decode       func([]byte) (image.Image, error)
+	)
+	switch contentType {
+	case "image/jpeg":
+		decodeConfig = func(b []byte) (image.Config, error) { return jpeg.DecodeConfig(bytes.NewReader(b)) }
+		decode = func(b []byte) (image.Image, error) { return jpeg.Decode(bytes.NewReader(b)) }
+	case "image/png":
+		decodeConfig = func(b []byte) (image.Config, error) { return png.DecodeConfig(bytes.NewReader(b)) }
+		decode = func(b []byte) (image.Image, error) { return png.Decode(bytes.NewReader(b)) }
+	case "image/gif":
+		decodeConfig = func(b []byte) (image.Config, error) { return gif.DecodeConfig(bytes.NewReader(b)) }
+		decode = func(b []byte) (image.Image, error) { return gif.Decode(bytes.NewReader(b)) }
+	case "image/webp":
+		decodeConfig = func(b []byte) (image.Config, error) { return webp.DecodeConfig(bytes.NewReader(b)) }
+		decode = func(b []byte) (image.Image, error) { return webp.Decode(bytes.NewReader(b)) }
+	default:
+		return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedImage, contentType)
+	}
+
+	cfg, err := decodeConfig(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("DecodeImage: decode config err: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxImagePixels {
+		return nil, "", fmt.Errorf("DecodeImage: image %dx%d is too large", cfg.Width, cfg.Height)
+	}
+	img, err := decode(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("DecodeImage: decode err: %w", err)
+	}
+	return img, contentType, nil
+}
+
+// Thumbnail center-crops the image to a square and scales it to size x size.
+func Thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	edge := b.Dx()
+	if b.Dy() < edge {
+		edge = b.Dy()
+	}
+	x0 := b.Min.X + (b.Dx()-edge)/2
+	y0 := b.Min.Y + (b.Dy()-edge)/2
+	crop := image.Rect(x0, y0, x0+edge, y0+edge)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+	return dst
+}
+
+// EncodeImage encodes as jpeg for jpeg sources and png otherwise, keeping
+// transparency, and returns the content type and file extension.
+func EncodeImage(img image.Image, sourceType string) ([]byte, string, string, error) {
+	var buf bytes.Buffer
+	if sourceType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", "", fmt.Errorf("EncodeImage: jpeg err: %w", err)
+		}
+		return buf.Bytes(), "image/jpeg", "jpg", nil
+	}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", "", fmt.Errorf("EncodeImage: png err: %w", err)
+	}
+	return buf.Bytes(), "image/png", "png", nil
+}
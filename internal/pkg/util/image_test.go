@@ -0,0 +1,125 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withExif returns the jpeg with an APP1 Exif segment after its SOI marker.
+func withExif(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+	payload := append([]byte("Exif\x00\x00"), []byte("GPS 52.37N 4.89E")...)
+	n := len(payload) + 2
+	segment := append([]byte{0xff, 0xe1, byte(n >> 8), byte(n)}, payload...)
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func TestDecodeImageRejects(t *testing.T) {
+	// a gif claiming a 5000x5000 screen, checked before decoding the pixels
+	var huge bytes.Buffer
+	if err := gif.Encode(&huge, image.NewPaletted(image.Rect(0, 0, 1, 1), color.Palette{color.Black}), nil); err != nil {
+		t.Fatalf("encode gif: %v", err)
+	}
+	b := huge.Bytes()
+	b[6], b[7], b[8], b[9] = 0x88, 0x13, 0x88, 0x13
+
+	tests := []struct {
+		name        string
+		data        []byte
+		unsupported bool
+	}{
+		{name: "text", data: []byte("hello, not an image"), unsupported: true},
+		{name: "pdf", data: []byte("%PDF-1.4\n%âãÏÓ\n"), unsupported: true},
+		{name: "svg", data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), unsupported: true},
+		{name: "truncated png", data: encodePNG(t, image.NewRGBA(image.Rect(0, 0, 8, 8)))[:20]},
+		{name: "too many pixels", data: b},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := DecodeImage(tt.data)
+			if err == nil {
+				t.Fatal("DecodeImage succeeded")
+			}
+			if got := errors.Is(err, ErrUnsupportedImage); got != tt.unsupported {
+				t.Errorf("DecodeImage err = %v, unsupported %v, want %v", err, got, tt.unsupported)
+			}
+		})
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	// a 300x200 image, red on the sides the square crop cuts off, blue in the
+	// middle
+	src := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	for x := 0; x < 300; x++ {
+		for y := 0; y < 200; y++ {
+			c := color.RGBA{B: 255, A: 255}
+			if x < 50 || x >= 250 {
+				c = color.RGBA{R: 255, A: 255}
+			}
+			src.Set(x, y, c)
+		}
+	}
+	img, contentType, err := DecodeImage(encodePNG(t, src))
+	if err != nil || contentType != "image/png" {
+		t.Fatalf("DecodeImage = %s, %v", contentType, err)
+	}
+	for _, size := range []int{256, 128, 64} {
+		thumb := Thumbnail(img, size)
+		if b := thumb.Bounds(); b.Dx() != size || b.Dy() != size {
+			t.Errorf("Thumbnail(%d) is %dx%d", size, b.Dx(), b.Dy())
+		}
+		for _, p := range []image.Point{{0, 0}, {size - 1, size / 2}, {size / 2, size / 2}} {
+			if r, _, _, _ := thumb.At(p.X, p.Y).RGBA(); r > 0x1000 {
+				t.Errorf("Thumbnail(%d) at %v is red, want the center crop", size, p)
+			}
+		}
+	}
+}
+
+func TestEncodeImageStripsExif(t *testing.T) {
+	data := withExif(t, image.NewRGBA(image.Rect(0, 0, 32, 32)))
+	if !bytes.Contains(data, []byte("Exif")) {
+		t.Fatal("test image has no exif")
+	}
+	img, contentType, err := DecodeImage(data)
+	if err != nil || contentType != "image/jpeg" {
+		t.Fatalf("DecodeImage = %s, %v", contentType, err)
+	}
+	out, outType, ext, err := EncodeImage(Thumbnail(img, 16), contentType)
+	if err != nil {
+		t.Fatalf("EncodeImage: %v", err)
+	}
+	if outType != "image/jpeg" || ext != "jpg" {
+		t.Errorf("EncodeImage = %s %s, want image/jpeg jpg", outType, ext)
+	}
+	if bytes.Contains(out, []byte("Exif")) || bytes.Contains(out, []byte("GPS")) {
+		t.Error("EncodeImage kept the exif segment")
+	}
+
+	// anything but jpeg becomes png, keeping transparency
+	_, outType, ext, err = EncodeImage(image.NewNRGBA(image.Rect(0, 0, 4, 4)), "image/webp")
+	if err != nil || outType != "image/png" || ext != "png" {
+		t.Errorf("EncodeImage of webp = %s %s, %v, want image/png png", outType, ext, err)
+	}
+}
@@ -7,30 +7,28 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 )
 
-var (
-	onceLimiter            sync.Once
-	attributeSlidingWindow AttributeSlidingWindow
-)
-
 type AttributeSlidingWindow struct {
-	mu  sync.Mutex // guards
+	mu  sync.Mutex // guards hub
 	hub map[string]fiber.Handler
 }
 
-// New creates a new sliding window middleware handler
+// New creates a new sliding window middleware handler with one limiter per
+// key returned by cfg.KeyGenerator. Limits are kept in process memory.
 func (as *AttributeSlidingWindow) New(cfg limiter.Config) fiber.Handler {
-	onceLimiter.Do(func() {
-		attributeSlidingWindow = AttributeSlidingWindow{hub: make(map[string]fiber.Handler, 0)}
-	})
-
 	return func(ctx *fiber.Ctx) error {
-		if itemLimiter, ok := attributeSlidingWindow.hub[cfg.KeyGenerator(ctx)]; ok {
-			return itemLimiter(ctx)
-		} else {
-			as.mu.Lock()
-			defer as.mu.Unlock()
-			attributeSlidingWindow.hub[cfg.KeyGenerator(ctx)] = limiter.SlidingWindow{}.New(cfg)
-			return attributeSlidingWindow.hub[cfg.KeyGenerator(ctx)](ctx)
+		key := cfg.KeyGenerator(ctx)
+
+		as.mu.Lock()
+		if as.hub == nil {
+			as.hub = make(map[string]fiber.Handler)
+		}
+		itemLimiter, ok := as.hub[key]
+		if !ok {
+			itemLimiter = limiter.SlidingWindow{}.New(cfg)
+			as.hub[key] = itemLimiter
 		}
+		as.mu.Unlock()
+
+		return itemLimiter(ctx)
 	}
 }
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"starland-account/internal/pkg/bizerr"
+	"strconv"
+	"time"
+)
+
+// InvalidRequest reports a request that could not be parsed; business errors
+// from the parse helpers pass through.
+func InvalidRequest(err error) error {
+	if ok, _ := bizerr.ErrorToBizError(err); ok {
+		return err
+	}
+	return bizerr.ErrBadRequest.Wrap(err)
+}
+
+// ParseQueryTime accepts RFC 3339 timestamps or plain dates.
+func ParseQueryTime(name, v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", v); err != nil {
+			return nil, bizerr.ErrBadRequest.Errorf("invalid %s: %s", name, v)
+		}
+	}
+	return &t, nil
+}
+
+func ParseQueryInt(name, v string) (*int, error) {
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, bizerr.ErrBadRequest.Errorf("invalid %s: %s", name, v)
+	}
+	return &n, nil
+}
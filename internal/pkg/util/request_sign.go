@@ -0,0 +1,22 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SignRequest returns the hex HMAC-SHA256, keyed by secret, of the canonical
+// request "<method>\n<uri>\n<timestamp>\n<hex sha256 of body>".
+func SignRequest(secret, method, uri, timestamp string, body []byte) string {
+	sum := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(method + "\n" + uri + "\n" + timestamp + "\n" + hex.EncodeToString(sum[:])))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyRequest checks sig against SignRequest in constant time.
+func VerifyRequest(secret, method, uri, timestamp string, body []byte, sig string) bool {
+	expected := SignRequest(secret, method, uri, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(sig))
+}
@@ -1,11 +1,11 @@
 package util
 
 import (
+	"crypto/rand"
 	"fmt"
-	"math/rand"
+	"math/big"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/bwmarrin/snowflake"
 )
@@ -25,13 +25,17 @@ func GetSnowflakeID() string {
 	return snowflakeNode.Generate().String()
 }
 
+// GenValidateCode returns a numeric code of the given width drawn from
+// crypto/rand, so codes can't be predicted from the clock.
 func GenValidateCode(width int) string {
-	numeric := [10]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
-	r := len(numeric)
-	rand.Seed(time.Now().UnixNano())
 	var sb strings.Builder
+	max := big.NewInt(10)
 	for i := 0; i < width; i++ {
-		fmt.Fprintf(&sb, "%d", numeric[rand.Intn(r)])
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Fprintf(&sb, "%d", n.Int64())
 	}
 	return sb.String()
 }
@@ -0,0 +1,124 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"starland-account/internal/pkg/bizerr"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError is a request field failing validation, named as the client
+// sent it, e.g. items[0].points.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i := range e {
+		msgs[i] = e[i].Field + " " + e[i].Reason
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ClientMessage lets bizerr show the failing fields to the client.
+func (e FieldErrors) ClientMessage() string {
+	return e.Error()
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		for _, key := range []string{"json", "query", "params", "form"} {
+			if name, _, _ := strings.Cut(f.Tag.Get(key), ","); name != "" && name != "-" {
+				return name
+			}
+		}
+		return f.Name
+	})
+	if err := v.RegisterValidation("solana_addr", isSolanaAddr); err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// isSolanaAddr accepts base58 encoded 32 byte public keys.
+func isSolanaAddr(fl validator.FieldLevel) bool {
+	_, err := solana.PublicKeyFromBase58(fl.Field().String())
+	return err == nil
+}
+
+// Validate checks req against its validate tags. Failures come back as
+// bizerr.ErrInvalidArgument wrapping the FieldErrors.
+func Validate(req interface{}) error {
+	err := validate.Struct(req)
+	if err == nil {
+		return nil
+	}
+	var ves validator.ValidationErrors
+	if !errors.As(err, &ves) {
+		return bizerr.ErrInternalError.Wrap(fmt.Errorf("Validate: %w", err))
+	}
+	fes := make(FieldErrors, len(ves))
+	for i, fe := range ves {
+		field := fe.Namespace()
+		// drop the struct name, anonymous request structs have none
+		if _, rest, ok := strings.Cut(field, "."); ok {
+			field = rest
+		}
+		fes[i] = &FieldError{Field: field, Reason: fieldReason(fe)}
+	}
+	return bizerr.ErrInvalidArgument.Wrap(fes)
+}
+
+func fieldReason(fe validator.FieldError) string {
+	sized := ""
+	switch fe.Kind() {
+	case reflect.String:
+		sized = " characters"
+	case reflect.Slice, reflect.Map:
+		sized = " items"
+	}
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return "is required without " + strings.ToLower(fe.Param())
+	case "min":
+		if sized == "" {
+			return "must be at least " + fe.Param()
+		}
+		return "must have at least " + fe.Param() + sized
+	case "max":
+		if sized == "" {
+			return "must be at most " + fe.Param()
+		}
+		return "must have at most " + fe.Param() + sized
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be at least " + fe.Param()
+	case "lte":
+		return "must be at most " + fe.Param()
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "email":
+		return "must be an email address"
+	case "http_url", "url":
+		return "must be an http(s) url"
+	case "solana_addr":
+		return "must be a base58 solana public key"
+	case "datetime":
+		return "must be a date like " + fe.Param()
+	}
+	return "is invalid"
+}
@@ -0,0 +1,294 @@
+// Package worker runs the background tasks of the service: each worker runs
+// until its context is canceled, and is restarted with a backoff when it
+// panics.
+package worker
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+	// unhealthyAfter is how many panics in a row take a worker down in
+	// Healthy; a single panic is only a restart.
+	unhealthyAfter = 3
+)
+
+// Func is the body of a worker. It returns once ctx is done; returning before
+// ends the worker for good.
+type Func func(ctx context.Context)
+
+type Worker struct {
+	Name string
+	Run  Func
+	// Leader runs the worker only on the instance leading, when the runner
+	// has an elector; for jobs that must not run on every replica.
+	Leader bool
+}
+
+// Elector elects the instance running the workers that want a leader.
+type Elector interface {
+	// Lead waits until this instance leads or ctx is done, then returns a
+	// context canceled once it stops leading, released with the cancel func.
+	Lead(ctx context.Context) (context.Context, context.CancelFunc, error)
+}
+
+// Status is the health of a worker.
+type Status struct {
+	Name        string     `json:"name"`
+	Running     bool       `json:"running"`
+	Standby     bool       `json:"standby,omitempty"` // waiting to lead
+	Stopped     bool       `json:"stopped,omitempty"` // not started, or returned for good
+	Restarts    int        `json:"restarts"`
+	Failures    int        `json:"failures,omitempty"` // panics in a row
+	LastPanic   string     `json:"last_panic,omitempty"`
+	LastPanicAt *time.Time `json:"last_panic_at,omitempty"`
+}
+
+// Runner starts and stops a set of workers.
+type Runner struct {
+	alert   func(string)
+	elector Elector
+	mu      sync.Mutex
+	workers []*Worker
+	status  map[string]*Status
+	cancel  context.CancelFunc
+	done    sync.WaitGroup
+}
+
+// NewRunner returns a runner calling alert, when not nil, on every panic.
+func NewRunner(alert func(msg string)) *Runner {
+	return &Runner{alert: alert, status: make(map[string]*Status)}
+}
+
+// Add registers workers; those added after Start don't run.
+func (r *Runner) Add(workers ...Worker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range workers {
+		w := workers[i]
+		r.workers = append(r.workers, &w)
+		r.status[w.Name] = &Status{Name: w.Name, Stopped: true}
+	}
+}
+
+// Elect has the workers that want a leader run only while e leads; without
+// it they run like the others. It is set before Start.
+func (r *Runner) Elect(e Elector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.elector = e
+}
+
+// Start runs every worker in its own goroutine until Stop or ctx is done.
+func (r *Runner) Start(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cancel != nil {
+		return
+	}
+	ctx, r.cancel = context.WithCancel(ctx)
+	for _, w := range r.workers {
+		fn := w.Run
+		if w.Leader && r.elector != nil {
+			fn = r.lead(w.Name, fn)
+		}
+		r.status[w.Name].Stopped = false
+		r.done.Add(1)
+		go r.run(ctx, w.Name, fn)
+	}
+}
+
+// Stop cancels the workers and waits for them to return, or for ctx to be
+// done, whichever comes first.
+func (r *Runner) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	cancel := r.cancel
+	r.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.done.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		var running []string
+		for _, s := range r.Health() {
+			if s.Running {
+				running = append(running, s.Name)
+			}
+		}
+		return fmt.Errorf("Stop: workers %v still running: %w", running, ctx.Err())
+	}
+}
+
+// Health returns the status of every worker, in the order they were added.
+func (r *Runner) Health() []Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]Status, len(r.workers))
+	for i, w := range r.workers {
+		res[i] = *r.status[w.Name]
+	}
+	return res
+}
+
+// Healthy reports whether every worker is running or waiting out the backoff
+// of a restart, as long as it hasn't panicked unhealthyAfter times in a row.
+func (r *Runner) Healthy() bool {
+	for _, s := range r.Health() {
+		if s.Stopped || s.Failures >= unhealthyAfter {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Runner) run(ctx context.Context, name string, fn Func) {
+	defer r.done.Done()
+	defer r.stopped(name)
+	backoff, failures := minBackoff, 0
+	for {
+		started := time.Now()
+		r.setRunning(name, true)
+		// a worker running for a while is past its failures, even before it
+		// panics again
+		recovered := time.AfterFunc(maxBackoff, func() { r.setFailures(name, 0) })
+		p := runOnce(ctx, fn)
+		recovered.Stop()
+		r.setRunning(name, false)
+		if p == nil || ctx.Err() != nil {
+			return
+		}
+
+		// a worker that ran for a while before panicking starts over
+		if time.Since(started) > maxBackoff {
+			backoff, failures = minBackoff, 0
+		}
+		failures++
+		msg := fmt.Sprintf("worker %s panic: %v, restarting in %s", name, p, backoff)
+		zap.S().Errorf("%s\n%s", msg, p.stack)
+		r.panicked(name, fmt.Sprint(p.value), failures)
+		if r.alert != nil {
+			r.alert(msg)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// lead runs fn for every term the runner's elector leads, with a context
+// canceled when the term ends.
+func (r *Runner) lead(name string, fn Func) Func {
+	return func(ctx context.Context) {
+		for {
+			r.setStandby(name, true)
+			term, cancel, err := r.elector.Lead(ctx)
+			r.setStandby(name, false)
+			if err != nil {
+				return
+			}
+			fn(term)
+			ended := term.Err() != nil
+			cancel()
+			if !ended {
+				return
+			}
+		}
+	}
+}
+
+type panicValue struct {
+	value any
+	stack []byte
+}
+
+func (p *panicValue) String() string {
+	return fmt.Sprint(p.value)
+}
+
+// runOnce runs fn, returning what it panicked with.
+func runOnce(ctx context.Context, fn Func) (p *panicValue) {
+	defer func() {
+		if v := recover(); v != nil {
+			p = &panicValue{value: v, stack: debug.Stack()}
+		}
+	}()
+	fn(ctx)
+	return nil
+}
+
+func (r *Runner) setRunning(name string, running bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status[name].Running = running
+}
+
+func (r *Runner) setStandby(name string, standby bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status[name].Standby = standby
+}
+
+func (r *Runner) setFailures(name string, failures int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status[name].Failures = failures
+}
+
+func (r *Runner) stopped(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status[name].Stopped = true
+}
+
+func (r *Runner) panicked(name, value string, failures int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	s := r.status[name]
+	s.Restarts++
+	s.Failures = failures
+	s.LastPanic, s.LastPanicAt = value, &now
+}
+
+// Every returns a worker body calling fn every interval until ctx is done;
+// the first call is right away when now is set.
+func Every(interval time.Duration, now bool, fn func(ctx context.Context)) Func {
+	return func(ctx context.Context) {
+		if now {
+			fn(ctx)
+		}
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				fn(ctx)
+			}
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package worker
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunnerRestartsAfterPanic(t *testing.T) {
+	var runs int32
+	alerts := make(chan string, 1)
+	r := NewRunner(func(msg string) { alerts <- msg })
+	r.Add(Worker{Name: "flaky", Run: func(ctx context.Context) {
+		if atomic.AddInt32(&runs, 1) == 1 {
+			panic("boom")
+		}
+		<-ctx.Done()
+	}})
+	r.Start(context.Background())
+
+	select {
+	case msg := <-alerts:
+		if !strings.Contains(msg, "flaky") || !strings.Contains(msg, "boom") {
+			t.Errorf("alert = %q, want the worker and the panic", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no alert on panic")
+	}
+	if !r.Healthy() {
+		t.Errorf("not Healthy waiting out the backoff of one panic: %+v", r.Health()[0])
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for !r.Health()[0].Running && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	s := r.Health()[0]
+	if !s.Running || s.Restarts != 1 || s.Failures != 1 || s.LastPanic != "boom" || s.LastPanicAt == nil {
+		t.Errorf("status = %+v, want running after one restart", s)
+	}
+
+	if err := r.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if r.Healthy() {
+		t.Error("Healthy after Stop")
+	}
+}
+
+func TestRunnerUnhealthy(t *testing.T) {
+	r := NewRunner(nil)
+	r.Add(Worker{Name: "crashing", Run: func(context.Context) { panic("boom") }},
+		Worker{Name: "done", Run: func(context.Context) {}})
+	if r.Healthy() {
+		t.Error("Healthy before Start")
+	}
+	r.Start(context.Background())
+	defer r.Stop(context.Background())
+
+	// restarts after 1s and 2s, then panics a third time in a row
+	deadline := time.Now().Add(5 * time.Second)
+	for r.Health()[0].Failures < unhealthyAfter && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	health := r.Health()
+	if s := health[0]; s.Failures != unhealthyAfter || s.Stopped {
+		t.Errorf("crashing = %+v, want %d failures in a row", s, unhealthyAfter)
+	}
+	if s := health[1]; !s.Stopped || s.Running {
+		t.Errorf("done = %+v, want stopped after returning", s)
+	}
+	if r.Healthy() {
+		t.Error("Healthy with a worker crashing and one returned")
+	}
+}
+
+func TestRunnerStopDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	r := NewRunner(nil)
+	r.Add(Worker{Name: "stuck", Run: func(ctx context.Context) { <-release }})
+	r.Start(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := r.Stop(ctx); err == nil || !strings.Contains(err.Error(), "stuck") {
+		t.Errorf("Stop = %v, want the stuck worker reported", err)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	var calls int32
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		Every(10*time.Millisecond, true, func(context.Context) { atomic.AddInt32(&calls, 1) })(ctx)
+		close(done)
+	}()
+	time.Sleep(35 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Every did not return on cancel")
+	}
+	if n := atomic.LoadInt32(&calls); n < 2 {
+		t.Errorf("calls = %d, want the first right away and then every tick", n)
+	}
+}
+
+// fakeElector leads for each context sent on terms, until it is canceled.
+type fakeElector struct {
+	terms chan context.Context
+}
+
+func (e *fakeElector) Lead(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case term := <-e.terms:
+		ctx, cancel := context.WithCancel(ctx)
+		stop := context.AfterFunc(term, cancel)
+		return ctx, func() { stop(); cancel() }, nil
+	}
+}
+
+func TestRunnerLeader(t *testing.T) {
+	e := &fakeElector{terms: make(chan context.Context)}
+	started := make(chan struct{})
+	var ended int32
+	r := NewRunner(nil)
+	r.Elect(e)
+	r.Add(Worker{Name: "leader", Leader: true, Run: func(ctx context.Context) {
+		started <- struct{}{}
+		<-ctx.Done()
+		atomic.AddInt32(&ended, 1)
+	}}, Worker{Name: "everywhere", Run: func(ctx context.Context) { <-ctx.Done() }})
+	r.Start(context.Background())
+	defer r.Stop(context.Background())
+
+	waitStandby := func(want bool) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for r.Health()[0].Standby != want && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if s := r.Health()[0]; s.Standby != want || !s.Running {
+			t.Fatalf("status = %+v, want running with standby %v", s, want)
+		}
+	}
+	waitStandby(true)
+
+	for term := 1; term <= 2; term++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		e.terms <- ctx
+		<-started
+		waitStandby(false)
+		cancel()
+		waitStandby(true)
+		if n := atomic.LoadInt32(&ended); n != int32(term) {
+			t.Fatalf("worker ended %d times after term %d", n, term)
+		}
+	}
+	if !r.Healthy() {
+		t.Error("not Healthy while waiting to lead")
+	}
+}
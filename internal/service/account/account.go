@@ -13,7 +13,9 @@ import (
 	"os"
 	config "starland-account/configs"
 	"starland-account/internal/biz"
-	"time"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/tenant"
+	"sync"
 
 	bin "github.com/gagliardetto/binary"
 
@@ -23,6 +25,8 @@ import (
 	"go.uber.org/zap"
 )
 
+const solanaCheckActor = "solana-checker"
+
 type UserPoints struct {
 	Authority     solana.PublicKey
 	Points        uint64
@@ -72,62 +76,86 @@ func (s *AccountService) QueryAccount(ctx context.Context, accountID string) (*A
 
 func (s *AccountService) ClaimPoints(ctx context.Context, req *ClaimPointsRequest) (string, error) {
 	zap.S().Infof("ClaimPoints: req: %+v", *req)
-	account, err := s.QueryAccount(ctx, req.AccountID)
+	if err := s.account.CheckAccountState(ctx, req.AccountID); err != nil {
+		return "", fmt.Errorf("ClaimPoints: check account state err: %w", err)
+	}
+	account, err := s.account.QueryAccount(ctx, req.AccountID, "", "")
 	if err != nil {
 		return "", fmt.Errorf("ClaimPoints: query account err: %w", err)
 	}
-	received := account.Received + req.Points
-	if account.Integral < received {
-		return "", fmt.Errorf("Not enough points")
+	if s.cfg.Email != nil && s.cfg.Email.RequireVerifiedForClaim && account.Email != "" && !account.EmailVerified {
+		return "", fmt.Errorf("ClaimPoints: %w", bizerr.ErrEmailNotVerified)
+	}
+	if err = s.account.CheckClaimPoints(account, req.Points); err != nil {
+		return "", fmt.Errorf("ClaimPoints: %w", err)
+	}
+	t := s.tenant(ctx)
+	if t == nil {
+		return "", fmt.Errorf("ClaimPoints: %w", bizerr.ErrAuthenticationFailed)
+	}
+	if t.MaxClaimPoints > 0 && req.Points > t.MaxClaimPoints {
+		return "", fmt.Errorf("ClaimPoints: %w", bizerr.ErrClaimLimitExceeded)
 	}
 
 	zap.S().Infof("ClaimPoints: account: %+v", *account)
 
 	if req.IsOK {
-		err = s.account.UpdateClaimPoints(ctx, req.AccountID, account.Integral, received)
+		err = s.account.ClaimPoints(ctx, account, req.Points)
 		if err != nil {
 			return "", fmt.Errorf("ClaimPoints: save account err: %w", err)
 		}
 	}
 
-	return signature(account.AccountID, account.ClaimCount)
+	return signature(t.PrivatePath, account.AccountID, account.ClaimCount)
+}
+
+// tenant returns the config of the context's tenant, nil outside of one.
+func (s *AccountService) tenant(ctx context.Context) *config.TenantConfig {
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return tenant.Find(s.cfg, id)
 }
 
 func makeBizToAccountResponse(req *biz.AccountResponse) *AccountResponse {
 	return &AccountResponse{
-		AccountID:  req.AccountID,
-		Integral:   req.Integral,
-		Received:   req.Received,
-		Email:      req.Email,
-		Name:       req.Name,
-		Provider:   req.Provider,
-		AvatarURL:  req.AvatarURL,
-		SolanaAddr: req.SolanaAddr,
+		AccountID:     req.AccountID,
+		Integral:      req.Integral,
+		Received:      req.Received,
+		Email:         req.Email,
+		Name:          req.Name,
+		Provider:      req.Provider,
+		AvatarURL:     req.AvatarURL,
+		SolanaAddr:    req.SolanaAddr,
+		ClaimCount:    req.ClaimCount,
+		State:         biz.AccountStates[req.State],
+		EmailVerified: req.EmailVerified,
 	}
 }
 
-func (s *AccountService) solanaChainDataCheckTask() {
-	defer func() {
-		if p := recover(); p != nil {
-			zap.S().Errorf("")
-		}
-		s.solanaChainDataCheckTask()
-	}()
-
-	t := time.NewTicker(time.Second * 24)
-	for range t.C {
-		ar, err := s.account.QueryAccounts(context.Background())
+// checkChainData compares every account's claims with its on-chain record,
+// waiting for the checks before returning.
+func (s *AccountService) checkChainData(ctx context.Context) {
+	tenant.Each(ctx, s.cfg, func(ctx context.Context, tc *config.TenantConfig) {
+		ar, err := s.account.QueryAccounts(ctx)
 		if err != nil {
-			zap.S().Errorf("solanaChainDataCheckTask: query accounts err: %w", err)
-			continue
+			zap.S().Errorf("checkChainData: query accounts tenant: %s err: %v", tc.ID, err)
+			return
 		}
+		var wg sync.WaitGroup
 		for i := range ar {
-			go s.solanaTask(ar[i])
+			wg.Add(1)
+			go func(ar *biz.AccountResponse) {
+				defer wg.Done()
+				s.solanaTask(ctx, tc, ar)
+			}(ar[i])
 		}
-	}
+		wg.Wait()
+	})
 }
 
-func (s *AccountService) solanaTask(ar *biz.AccountResponse) {
+func (s *AccountService) solanaTask(ctx context.Context, tc *config.TenantConfig, ar *biz.AccountResponse) {
 	defer func() {
 		if p := recover(); p != nil {
 			zap.S().Infof("solanaTask: panic: %v", p)
@@ -137,20 +165,25 @@ func (s *AccountService) solanaTask(ar *biz.AccountResponse) {
 		return
 	}
 	endpoint := rpc.DevNet_RPC
-	if config.GetConfig().Env == "pro" {
+	if tc.Env == "pro" {
 		endpoint = rpc.MainNetBeta_RPC
-	} else if config.GetConfig().Env == "test" {
+	} else if tc.Env == "test" {
 		endpoint = rpc.TestNet_RPC
 	}
 	client := rpc.New(endpoint)
 	pubKey := solana.MustPublicKeyFromBase58(ar.SolanaAddr) // serum token
 
 	resp, err := client.GetAccountInfo(
-		context.TODO(),
+		ctx,
 		pubKey,
 	)
 	if err != nil {
 		zap.S().Error(err)
+		return
+	}
+	// another tenant's program may hold a record at the same address
+	if tc.ProgramID != "" && resp.Value != nil && resp.Value.Owner.String() != tc.ProgramID {
+		return
 	}
 
 	var meta UserPoints
@@ -165,28 +198,25 @@ func (s *AccountService) solanaTask(ar *biz.AccountResponse) {
 		return
 	} else {
 		lastSignature := string(meta.LastSignature[:])
-		if _, bol := signatureVerify(ar.AccountID, lastSignature, int(meta.ClaimCount)); !bol {
-			err = s.account.SaveAccount(context.Background(), &biz.AccountRequest{
-				AccountID:  ar.AccountID,
-				Email:      ar.Email,
-				Name:       ar.Name,
-				Provider:   ar.Provider,
-				AvatarURL:  ar.AvatarURL,
-				ClaimCount: ar.ClaimCount,
-				SolanaAddr: ar.SolanaAddr,
-				State:      -1,
+		if _, bol := signatureVerify(tc.PrivatePath, ar.AccountID, lastSignature, int(meta.ClaimCount)); !bol {
+			// refused with NOT_LEADER once a newer leader took over mid-check
+			err = s.account.ChangeAccountState(ctx, &biz.AccountStateRequest{
+				AccountID: ar.AccountID,
+				State:     biz.AccountStateBanned,
+				Reason:    "on-chain claim signature mismatch",
+				Actor:     solanaCheckActor,
 			})
 			if err != nil {
-				zap.S().Error(fmt.Errorf("solanaTask: save account err: %w", err))
+				zap.S().Error(fmt.Errorf("solanaTask: ban account err: %w", err))
 			}
 		}
 		return
 	}
 }
 
-func signatureVerify(user, lastSignature string, claimCount int) (string, bool) {
+func signatureVerify(privatePath, user, lastSignature string, claimCount int) (string, bool) {
 
-	privateKeyFile, err := os.Open("private_key.pem")
+	privateKeyFile, err := os.Open(privatePath)
 	if err != nil {
 		panic(err)
 	}
@@ -251,9 +281,9 @@ func signatureVerify(user, lastSignature string, claimCount int) (string, bool)
 	return base64Sig, true
 }
 
-func signature(user string, claimCount int) (string, error) {
+func signature(privatePath, user string, claimCount int) (string, error) {
 
-	privateKeyFile, err := os.Open(config.GetConfig().PrivatePath)
+	privateKeyFile, err := os.Open(privatePath)
 	if err != nil {
 		return "", fmt.Errorf("signature: open privateKeyFile err: %w", err)
 	}
@@ -305,7 +335,19 @@ func signature(user string, claimCount int) (string, error) {
 }
 
 func (s *AccountService) SavePointsAddr(ctx context.Context, account, addr string) error {
-	if err := s.account.UpdateAddr(ctx, account, addr); err != nil {
+	if err := s.account.CheckAccountState(ctx, account); err != nil {
+		return fmt.Errorf("SavePointsAddr: check account state err: %w", err)
+	}
+	// claims are redeemed against the bound wallet's on-chain record, so a
+	// different wallet would fail the chain check and get the account banned
+	ac, err := s.account.QueryAccount(ctx, account, "", "")
+	if err != nil {
+		return fmt.Errorf("SavePointsAddr: query account err: %w", err)
+	}
+	if ac.ClaimCount > 0 && ac.SolanaAddr != "" && ac.SolanaAddr != addr {
+		return fmt.Errorf("SavePointsAddr: %w", bizerr.ErrWalletMismatch)
+	}
+	if err = s.account.UpdateAddr(ctx, account, addr); err != nil {
 		return fmt.Errorf("SavePointsAddr: save to db err: %w", err)
 	}
 	return nil
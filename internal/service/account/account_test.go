@@ -0,0 +1,310 @@
+package account
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/data/memory"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/tenant"
+	"testing"
+	"time"
+)
+
+// newTestService builds the service on in-memory repos, signing claims with a
+// fresh key. Its background tasks are not started.
+func newTestService(t *testing.T, cfg *configs.Config) (*AccountService, *memory.AccountRepo) {
+	t.Helper()
+	if cfg == nil {
+		cfg = &configs.Config{}
+	}
+	cfg.PrivatePath = writeTestKey(t)
+	accounts := memory.NewAccountRepo()
+	ac := biz.NewAccountUsecase(accounts, nil, nil, memory.NewVerifyRepo(accounts))
+	act := biz.NewActivityUsecase(memory.NewActivityRepo(), memory.NewActivityLogRepo(accounts))
+	return NewAccountService(cfg, ac, act, nil, nil), accounts
+}
+
+func writeTestKey(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "private_key.pem")
+	if err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	return path
+}
+
+func defaultCtx() context.Context {
+	return tenant.NewContext(context.Background(), tenant.Default)
+}
+
+// reason is the bizerr reason of err, empty for other errors.
+func reason(err error) string {
+	var be *bizerr.BizError
+	if errors.As(err, &be) {
+		return be.Reason()
+	}
+	return ""
+}
+
+func TestAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *biz.AccountRequest
+		req      *AccountRequest
+		wantName string
+	}{
+		{
+			name:     "registers with a name from the id",
+			req:      &AccountRequest{AccountID: "0x1234567890", Provider: "Blockchain"},
+			wantName: "0x1234",
+		},
+		{
+			name:     "keeps a given name",
+			req:      &AccountRequest{AccountID: "0x1234567890", Name: "Alice", Provider: "Blockchain"},
+			wantName: "Alice",
+		},
+		{
+			name:     "leaves an existing account as is",
+			existing: &biz.AccountRequest{AccountID: "0x1234567890", Name: "Bob", Provider: "Blockchain"},
+			req:      &AccountRequest{AccountID: "0x1234567890", Name: "Alice", Provider: "Blockchain"},
+			wantName: "Bob",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, accounts := newTestService(t, nil)
+			ctx := defaultCtx()
+			if tt.existing != nil {
+				if err := accounts.SaveAccount(ctx, tt.existing); err != nil {
+					t.Fatalf("SaveAccount: %v", err)
+				}
+			}
+			if err := s.Auth(ctx, tt.req); err != nil {
+				t.Fatalf("Auth: %v", err)
+			}
+			got, err := s.QueryAccount(ctx, tt.req.AccountID)
+			if err != nil {
+				t.Fatalf("QueryAccount: %v", err)
+			}
+			if got.Name != tt.wantName || got.State != "active" {
+				t.Errorf("account = %s %s, want %s active", got.Name, got.State, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestAuthByEmail(t *testing.T) {
+	s, accounts := newTestService(t, nil)
+	ctx := defaultCtx()
+
+	// an account without id gets one, and is found by email on the next sign in
+	for i := 0; i < 2; i++ {
+		if err := s.Auth(ctx, &AccountRequest{Email: "a@x.io", Provider: "google"}); err != nil {
+			t.Fatalf("Auth: %v", err)
+		}
+	}
+	res, err := accounts.SearchAccounts(ctx, &biz.AccountSearchRequest{Sort: biz.AccountSortAccountID, Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchAccounts: %v", err)
+	}
+	if len(res) != 1 || res[0].AccountID == "" || res[0].Email != "a@x.io" {
+		t.Errorf("accounts = %+v, want one with a generated id", res)
+	}
+}
+
+func TestAuthErased(t *testing.T) {
+	s, accounts := newTestService(t, nil)
+	ctx := defaultCtx()
+	if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1", State: biz.AccountStateBanned}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	if err := s.DeleteAccount(ctx, "a1", "a1"); err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+
+	// the id stays taken until the purge, so a banned account can't come
+	// back active by erasing itself
+	if err := s.Auth(ctx, &AccountRequest{AccountID: "a1"}); reason(err) != "ACCOUNT_DELETED" {
+		t.Fatalf("Auth of an erased id = %v, want ACCOUNT_DELETED", err)
+	}
+	if n, err := accounts.PurgeAccounts(ctx, time.Now().Add(time.Second)); err != nil || n != 1 {
+		t.Fatalf("PurgeAccounts = %d, %v, want 1", n, err)
+	}
+	if err := s.Auth(ctx, &AccountRequest{AccountID: "a1"}); err != nil {
+		t.Errorf("Auth after the purge: %v", err)
+	}
+}
+
+func TestDeleteAccountDropsVerifyCodes(t *testing.T) {
+	s, accounts := newTestService(t, nil)
+	ctx := defaultCtx()
+	for _, id := range []string{"a1", "a2"} {
+		if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: id, Email: id + "@x.io"}); err != nil {
+			t.Fatalf("SaveAccount: %v", err)
+		}
+	}
+	policy := &biz.VerifyPolicy{CodeTTL: time.Minute}
+	codes := []*biz.VerifyCode{
+		{Purpose: biz.VerifyPurposeEmail, Subject: "a1", Code: "111111", AccountID: "a1", Email: "a1@x.io"},
+		{Purpose: biz.VerifyPurposeMagicLink, Subject: "hash1", Code: "hash1", AccountID: "a1", Email: "a1@x.io"},
+		{Purpose: biz.VerifyPurposeEmail, Subject: "a2", Code: "222222", AccountID: "a2", Email: "a2@x.io"},
+	}
+	for _, code := range codes {
+		if err := s.account.CreateVerifyCode(ctx, code, policy); err != nil {
+			t.Fatalf("CreateVerifyCode: %v", err)
+		}
+	}
+	if err := s.DeleteAccount(ctx, "a1", "a1"); err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+
+	for _, code := range codes {
+		_, err := s.account.CheckVerifyCode(ctx, code.Purpose, code.Subject, code.Code, policy)
+		if kept := err == nil; kept != (code.AccountID == "a2") {
+			t.Errorf("CheckVerifyCode(%s:%s) = %v, want only a2's code kept", code.Purpose, code.Subject, err)
+		}
+	}
+}
+
+func TestUploadAvatarRejects(t *testing.T) {
+	cfg := &configs.Config{Account: &configs.AccountConfig{}}
+	cfg.Account.Profile.AvatarMaxSize = 64
+	s, accounts := newTestService(t, cfg)
+	ctx := defaultCtx()
+	if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "larger than the max size", data: make([]byte, 65)},
+		{name: "not an image", data: []byte("hello, not an image")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.UploadAvatar(ctx, "a1", tt.data); reason(err) != "INVALID_IMAGE" {
+				t.Errorf("UploadAvatar = %v, want INVALID_IMAGE", err)
+			}
+		})
+	}
+}
+
+func TestClaimPoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		state        int
+		integral     int
+		req          ClaimPointsRequest
+		wantReason   string
+		wantReceived int
+	}{
+		{name: "claims", integral: 100, req: ClaimPointsRequest{Points: 60, IsOK: true}, wantReceived: 60},
+		{name: "signs without saving", integral: 100, req: ClaimPointsRequest{Points: 60}},
+		{name: "claims the whole balance", integral: 100, req: ClaimPointsRequest{Points: 100, IsOK: true}, wantReceived: 100},
+		{name: "not enough points", integral: 50, req: ClaimPointsRequest{Points: 60, IsOK: true}, wantReason: bizerr.ErrNotEnoughPoints.Reason()},
+		{name: "no points", integral: 50, req: ClaimPointsRequest{IsOK: true}, wantReason: bizerr.ErrBadRequest.Reason()},
+		{name: "over the tenant cap", integral: 5000, req: ClaimPointsRequest{Points: 2000, IsOK: true}, wantReason: bizerr.ErrClaimLimitExceeded.Reason()},
+		{name: "banned", state: biz.AccountStateBanned, integral: 100, req: ClaimPointsRequest{Points: 10, IsOK: true}, wantReason: bizerr.ErrAccountBanned.Reason()},
+		{name: "suspended", state: biz.AccountStateSuspended, integral: 100, req: ClaimPointsRequest{Points: 10, IsOK: true}, wantReason: bizerr.ErrAccountSuspended.Reason()},
+		{name: "unknown account", req: ClaimPointsRequest{AccountID: "missing", Points: 10, IsOK: true}, wantReason: bizerr.ErrAccountNotExist.Reason()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, accounts := newTestService(t, &configs.Config{Tenants: []configs.TenantConfig{
+				{ID: tenant.Default, MaxClaimPoints: 1000},
+			}})
+			ctx := defaultCtx()
+			if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1", State: tt.state}); err != nil {
+				t.Fatalf("SaveAccount: %v", err)
+			}
+			if err := accounts.UpdateAccountIntegral(ctx, "a1", tt.integral); err != nil {
+				t.Fatalf("UpdateAccountIntegral: %v", err)
+			}
+			if tt.req.AccountID == "" {
+				tt.req.AccountID = "a1"
+			}
+
+			sig, err := s.ClaimPoints(ctx, &tt.req)
+			if got := reason(err); got != tt.wantReason || (tt.wantReason == "" && err != nil) {
+				t.Fatalf("ClaimPoints err = %v, want %s", err, tt.wantReason)
+			}
+			if err == nil && sig == "" {
+				t.Error("ClaimPoints returned no signature")
+			}
+			a, _ := accounts.QueryAccount(ctx, "a1", "", "")
+			if a.Received != tt.wantReceived {
+				t.Errorf("Received = %d, want %d", a.Received, tt.wantReceived)
+			}
+		})
+	}
+}
+
+func TestClaimPointsOutsideTenant(t *testing.T) {
+	s, _ := newTestService(t, nil)
+	if _, err := s.ClaimPoints(context.Background(), &ClaimPointsRequest{AccountID: "a1", Points: 10}); err == nil {
+		t.Error("ClaimPoints without a tenant succeeded")
+	}
+}
+
+func TestSavePointsAddr(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   *biz.AccountRequest
+		addr       string
+		wantReason string
+		wantAddr   string
+	}{
+		{name: "binds", existing: &biz.AccountRequest{AccountID: "a1"}, addr: "w1", wantAddr: "w1"},
+		{name: "rebinds before claiming", existing: &biz.AccountRequest{AccountID: "a1", SolanaAddr: "w1"}, addr: "w2", wantAddr: "w2"},
+		{
+			name:       "keeps the wallet claimed with",
+			existing:   &biz.AccountRequest{AccountID: "a1", SolanaAddr: "w1", ClaimCount: 1},
+			addr:       "w2",
+			wantReason: bizerr.ErrWalletMismatch.Reason(),
+			wantAddr:   "w1",
+		},
+		{
+			name:       "banned",
+			existing:   &biz.AccountRequest{AccountID: "a1", State: biz.AccountStateBanned},
+			addr:       "w1",
+			wantReason: bizerr.ErrAccountBanned.Reason(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, accounts := newTestService(t, nil)
+			ctx := defaultCtx()
+			if err := accounts.SaveAccount(ctx, tt.existing); err != nil {
+				t.Fatalf("SaveAccount: %v", err)
+			}
+			err := s.SavePointsAddr(ctx, "a1", tt.addr)
+			if got := reason(err); got != tt.wantReason || (tt.wantReason == "" && err != nil) {
+				t.Fatalf("SavePointsAddr err = %v, want %s", err, tt.wantReason)
+			}
+			a, _ := accounts.QueryAccount(ctx, "a1", "", "")
+			if a.SolanaAddr != tt.wantAddr {
+				t.Errorf("SolanaAddr = %q, want %q", a.SolanaAddr, tt.wantAddr)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/util"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+const defaultAvatarMaxSize = 2 << 20
+
+var defaultAvatarSizes = []int{256, 128, 64}
+
+// UploadAvatar stores square thumbnails of the uploaded image and points the
+// account's avatar_url at the largest one. Thumbnails are re-encoded from the
+// decoded pixels, which strips EXIF and other metadata.
+func (s *AccountService) UploadAvatar(ctx context.Context, accountID string, data []byte) (*AvatarResponse, error) {
+	maxSize, sizes := int64(defaultAvatarMaxSize), defaultAvatarSizes
+	if s.cfg.Account != nil {
+		if s.cfg.Account.Profile.AvatarMaxSize > 0 {
+			maxSize = s.cfg.Account.Profile.AvatarMaxSize
+		}
+		if len(s.cfg.Account.Profile.AvatarSizes) > 0 {
+			sizes = s.cfg.Account.Profile.AvatarSizes
+		}
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("UploadAvatar: %w", bizerr.ErrInvalidImage.Errorf("empty file"))
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("UploadAvatar: %w", bizerr.ErrInvalidImage.Errorf("larger than %d bytes", maxSize))
+	}
+	if err := s.account.CheckAccountState(ctx, accountID); err != nil {
+		return nil, fmt.Errorf("UploadAvatar: check account state err: %w", err)
+	}
+
+	img, contentType, err := util.DecodeImage(data)
+	if errors.Is(err, util.ErrUnsupportedImage) {
+		return nil, fmt.Errorf("UploadAvatar: %w", bizerr.ErrInvalidImage.Errorf("only jpeg, png, gif and webp are supported"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("UploadAvatar: %w", bizerr.ErrInvalidImage.Wrap(err))
+	}
+
+	res := &AvatarResponse{Thumbnails: make(map[string]string, len(sizes))}
+	prefix := fmt.Sprintf("avatar/%s/%s", accountID, uuid.NewString())
+	for i, size := range sizes {
+		out, outType, ext, err := util.EncodeImage(util.Thumbnail(img, size), contentType)
+		if err != nil {
+			return nil, fmt.Errorf("UploadAvatar: encode %d err: %w", size, err)
+		}
+		url, err := s.store.Put(ctx, fmt.Sprintf("%s_%d.%s", prefix, size, ext), out, outType)
+		if err != nil {
+			return nil, fmt.Errorf("UploadAvatar: store %d err: %w", size, err)
+		}
+		res.Thumbnails[strconv.Itoa(size)] = url
+		if i == 0 {
+			res.AvatarURL = url
+		}
+	}
+
+	account, err := s.account.UpdateProfile(ctx, &biz.ProfileRequest{
+		AccountID: accountID,
+		AvatarURL: &res.AvatarURL,
+		Actor:     accountID,
+	}, &biz.ProfilePolicy{})
+	if err != nil {
+		return nil, fmt.Errorf("UploadAvatar: update profile err: %w", err)
+	}
+	res.Account = makeBizToAccountResponse(account)
+	return res, nil
+}
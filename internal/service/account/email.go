@@ -0,0 +1,200 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/mailer"
+	"starland-account/internal/pkg/util"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	verifyCodeWidth       = 6
+	defaultCodeTTL        = 10 * time.Minute
+	defaultResendInterval = time.Minute
+	defaultMaxAttempts    = 5
+)
+
+func (s *AccountService) verifyPolicy() *biz.VerifyPolicy {
+	p := &biz.VerifyPolicy{
+		CodeTTL:        defaultCodeTTL,
+		ResendInterval: defaultResendInterval,
+		MaxAttempts:    defaultMaxAttempts,
+	}
+	if ec := s.cfg.Email; ec != nil {
+		if ec.CodeTTL > 0 {
+			p.CodeTTL = ec.CodeTTL * time.Second
+		}
+		if ec.ResendInterval > 0 {
+			p.ResendInterval = ec.ResendInterval * time.Second
+		}
+		if ec.MaxAttempts > 0 {
+			p.MaxAttempts = ec.MaxAttempts
+		}
+	}
+	return p
+}
+
+// SendEmailVerification mails a numeric code to the account's email.
+func (s *AccountService) SendEmailVerification(ctx context.Context, accountID string) error {
+	account, err := s.account.QueryAccount(ctx, accountID, "", "")
+	if err != nil {
+		return fmt.Errorf("SendEmailVerification: query account err: %w", err)
+	}
+	if account.EmailVerified {
+		return nil
+	}
+
+	policy := s.verifyPolicy()
+	code := util.GenValidateCode(verifyCodeWidth)
+	err = s.account.CreateVerifyCode(ctx, &biz.VerifyCode{
+		Purpose:   biz.VerifyPurposeEmail,
+		Subject:   accountID,
+		Code:      code,
+		AccountID: accountID,
+		Email:     account.Email,
+	}, policy)
+	if err != nil {
+		return fmt.Errorf("SendEmailVerification: create code err: %w", err)
+	}
+
+	err = s.mailer.Send(ctx, &mailer.Message{
+		To:      account.Email,
+		Subject: "Your Starland verification code",
+		Body: fmt.Sprintf("Your verification code is %s. It expires in %d minutes.\n\nIf you didn't request this, you can ignore this email.",
+			code, int(policy.CodeTTL.Minutes())),
+	})
+	if err != nil {
+		return fmt.Errorf("SendEmailVerification: send mail err: %w", err)
+	}
+	return nil
+}
+
+func (s *AccountService) VerifyEmail(ctx context.Context, accountID, code string) (*AccountResponse, error) {
+	vc, err := s.account.CheckVerifyCode(ctx, biz.VerifyPurposeEmail, accountID, strings.TrimSpace(code), s.verifyPolicy())
+	if err != nil {
+		return nil, fmt.Errorf("VerifyEmail: check code err: %w", err)
+	}
+	if err = s.account.MarkEmailVerified(ctx, accountID, vc.Email); err != nil {
+		return nil, fmt.Errorf("VerifyEmail: mark verified err: %w", err)
+	}
+	return s.QueryAccount(ctx, accountID)
+}
+
+// SendMagicLink mails a single-use login link to the address. The account is
+// only created once the link is used, so unclaimed addresses leave no trace.
+func (s *AccountService) SendMagicLink(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || !strings.Contains(email, "@") {
+		return fmt.Errorf("SendMagicLink: %w", bizerr.ErrBadRequest.Errorf("invalid email"))
+	}
+	if s.cfg.Email == nil || s.cfg.Email.MagicLinkURL == "" {
+		return fmt.Errorf("SendMagicLink: magic link url is not configured")
+	}
+	link, err := url.Parse(s.cfg.Email.MagicLinkURL)
+	if err != nil {
+		return fmt.Errorf("SendMagicLink: parse magic link url err: %w", err)
+	}
+
+	var accountID string
+	account, err := s.account.QueryAccount(ctx, "", email, biz.EmailProvider)
+	if err != nil && !errors.Is(err, bizerr.ErrAccountNotExist) {
+		return fmt.Errorf("SendMagicLink: query account err: %w", err)
+	}
+	if account != nil {
+		accountID = account.AccountID
+	}
+
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return fmt.Errorf("SendMagicLink: gen token err: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	hash := hashToken(token)
+
+	policy := s.verifyPolicy()
+	err = s.account.CreateVerifyCode(ctx, &biz.VerifyCode{
+		Purpose:   biz.VerifyPurposeMagicLink,
+		Subject:   hash,
+		Code:      hash,
+		AccountID: accountID,
+		Email:     email,
+	}, policy)
+	if err != nil {
+		return fmt.Errorf("SendMagicLink: create token err: %w", err)
+	}
+
+	q := link.Query()
+	q.Set("token", token)
+	link.RawQuery = q.Encode()
+	err = s.mailer.Send(ctx, &mailer.Message{
+		To:      email,
+		Subject: "Sign in to Starland",
+		Body: fmt.Sprintf("Use this link to sign in. It expires in %d minutes and works once.\n\n%s\n\nIf you didn't request this, you can ignore this email.",
+			int(policy.CodeTTL.Minutes()), link.String()),
+	})
+	if err != nil {
+		return fmt.Errorf("SendMagicLink: send mail err: %w", err)
+	}
+	return nil
+}
+
+// LoginMagicLink consumes the token, creating the account on first login, and
+// marks its email verified.
+func (s *AccountService) LoginMagicLink(ctx context.Context, token string) (*AccountResponse, error) {
+	hash := hashToken(strings.TrimSpace(token))
+	vc, err := s.account.CheckVerifyCode(ctx, biz.VerifyPurposeMagicLink, hash, hash, s.verifyPolicy())
+	if err != nil {
+		return nil, fmt.Errorf("LoginMagicLink: check token err: %w", err)
+	}
+
+	accountID := vc.AccountID
+	if accountID == "" {
+		account, err := s.account.QueryAccount(ctx, "", vc.Email, biz.EmailProvider)
+		switch {
+		case err == nil:
+			accountID = account.AccountID
+		case errors.Is(err, bizerr.ErrAccountNotExist):
+			accountID = uuid.NewString()
+			err = s.account.SaveAccount(ctx, &biz.AccountRequest{
+				AccountID: accountID,
+				Email:     vc.Email,
+				Name:      strings.SplitN(vc.Email, "@", 2)[0],
+				Provider:  biz.EmailProvider,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("LoginMagicLink: save account err: %w", err)
+			}
+			zap.S().Infof("LoginMagicLink: registered account %s", accountID)
+		default:
+			return nil, fmt.Errorf("LoginMagicLink: query account err: %w", err)
+		}
+	}
+
+	if err = s.account.CheckAccountState(ctx, accountID); err != nil {
+		return nil, fmt.Errorf("LoginMagicLink: check account state err: %w", err)
+	}
+	if err = s.account.MarkEmailVerified(ctx, accountID, vc.Email); err != nil {
+		return nil, fmt.Errorf("LoginMagicLink: mark verified err: %w", err)
+	}
+	return s.QueryAccount(ctx, accountID)
+}
+
+// hashToken keys magic links by the token's hash so the store never holds a
+// usable token.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
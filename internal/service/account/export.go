@@ -0,0 +1,128 @@
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"starland-account/configs"
+	"starland-account/internal/pkg/tenant"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ExportAccount collects everything stored about the account.
+func (s *AccountService) ExportAccount(ctx context.Context, accountID string) (*AccountExport, error) {
+	account, err := s.account.QueryAccount(ctx, accountID, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("ExportAccount: query account err: %w", err)
+	}
+	logs, err := s.activity.QueryAllActivityLogs(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("ExportAccount: query activity logs err: %w", err)
+	}
+	claims, err := s.account.QueryClaimLogs(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("ExportAccount: query claims err: %w", err)
+	}
+	stateLogs, err := s.QueryAccountStateLogs(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("ExportAccount: query state logs err: %w", err)
+	}
+	profileChanges, err := s.QueryProfileChanges(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("ExportAccount: query profile changes err: %w", err)
+	}
+
+	res := &AccountExport{
+		ExportedAt:     time.Now(),
+		Profile:        makeBizToAccountResponse(account),
+		ActivityLogs:   make([]*ActivityLogExport, len(logs)),
+		Claims:         make([]*ClaimLogResponse, len(claims)),
+		StateLogs:      stateLogs,
+		ProfileChanges: profileChanges,
+	}
+	if account.Email != "" || account.Provider != "" {
+		res.Identities = append(res.Identities, &IdentityResponse{Provider: account.Provider, Email: account.Email})
+	}
+	if account.SolanaAddr != "" {
+		res.Identities = append(res.Identities, &IdentityResponse{Provider: "solana", Address: account.SolanaAddr})
+	}
+	for i := range logs {
+		res.ActivityLogs[i] = &ActivityLogExport{
+			ActivityCode: logs[i].ActivityCode,
+			ActivityName: logs[i].ActivityName,
+			Integral:     logs[i].Integral,
+			CreateAt:     logs[i].CreateAt,
+		}
+	}
+	for i := range claims {
+		res.Claims[i] = &ClaimLogResponse{
+			Points:     claims[i].Points,
+			Received:   claims[i].Received,
+			SolanaAddr: claims[i].SolanaAddr,
+			ClaimCount: claims[i].ClaimCount,
+			CreateAt:   claims[i].CreateAt,
+		}
+	}
+	return res, nil
+}
+
+// WriteExportZip writes the export as a zip with one JSON file per section.
+func WriteExportZip(w io.Writer, export *AccountExport) error {
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"identities.json", export.Identities},
+		{"activity_logs.json", export.ActivityLogs},
+		{"claims.json", export.Claims},
+		{"state_logs.json", export.StateLogs},
+		{"profile_changes.json", export.ProfileChanges},
+	}
+	for _, f := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			return fmt.Errorf("WriteExportZip: create %s err: %w", f.name, err)
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(f.data); err != nil {
+			return fmt.Errorf("WriteExportZip: encode %s err: %w", f.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// DeleteAccount erases the account's personal data. The account is refused by
+// every endpoint from then on, signing up again with its id included, and
+// purged after the retention period.
+func (s *AccountService) DeleteAccount(ctx context.Context, accountID, actor string) error {
+	if err := s.account.EraseAccount(ctx, accountID, actor); err != nil {
+		return fmt.Errorf("DeleteAccount: erase err: %w", err)
+	}
+	return nil
+}
+
+// purgeAccounts hard deletes the accounts erased longer than the retention
+// period ago.
+func (s *AccountService) purgeAccounts(ctx context.Context) {
+	days := 30
+	if s.cfg.Account != nil && s.cfg.Account.DeletedRetentionDays > 0 {
+		days = s.cfg.Account.DeletedRetentionDays
+	}
+	tenant.Each(ctx, s.cfg, func(ctx context.Context, t *configs.TenantConfig) {
+		n, err := s.account.PurgeAccounts(ctx, time.Now().AddDate(0, 0, -days))
+		if err != nil {
+			zap.S().Errorf("purgeAccounts: purge accounts tenant: %s err: %v", t.ID, err)
+			return
+		}
+		if n > 0 {
+			zap.S().Infof("purgeAccounts: purged %d accounts of tenant %s", n, t.ID)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultNameMinLen = 2
+	defaultNameMaxLen = 32
+)
+
+func (s *AccountService) UpdateProfile(ctx context.Context, req *UpdateProfileRequest) (*AccountResponse, error) {
+	var pc configs.ProfileConfig
+	if s.cfg.Account != nil {
+		pc = s.cfg.Account.Profile
+	}
+
+	pr := &biz.ProfileRequest{AccountID: req.AccountID, Actor: req.Actor}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := validateName(name, &pc); err != nil {
+			return nil, fmt.Errorf("UpdateProfile: %w", err)
+		}
+		pr.Name = &name
+	}
+	if req.AvatarURL != nil {
+		avatar := strings.TrimSpace(*req.AvatarURL)
+		if err := validateAvatarURL(avatar, pc.AvatarHosts); err != nil {
+			return nil, fmt.Errorf("UpdateProfile: %w", err)
+		}
+		pr.AvatarURL = &avatar
+	}
+
+	if err := s.account.CheckAccountState(ctx, req.AccountID); err != nil {
+		return nil, fmt.Errorf("UpdateProfile: check account state err: %w", err)
+	}
+	account, err := s.account.UpdateProfile(ctx, pr, &biz.ProfilePolicy{
+		UniqueName:       pc.UniqueName,
+		NameChangeLimit:  pc.NameChangeLimit,
+		NameChangeWindow: pc.NameChangeWindow * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("UpdateProfile: update err: %w", err)
+	}
+	return makeBizToAccountResponse(account), nil
+}
+
+func (s *AccountService) QueryProfileChanges(ctx context.Context, accountID string) ([]*ProfileChangeResponse, error) {
+	changes, err := s.account.QueryProfileChanges(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("QueryProfileChanges: query err: %w", err)
+	}
+	res := make([]*ProfileChangeResponse, len(changes))
+	for i := range changes {
+		res[i] = &ProfileChangeResponse{
+			Field:    changes[i].Field,
+			OldValue: changes[i].OldValue,
+			NewValue: changes[i].NewValue,
+			Actor:    changes[i].Actor,
+			CreateAt: changes[i].CreateAt,
+		}
+	}
+	return res, nil
+}
+
+func validateName(name string, pc *configs.ProfileConfig) error {
+	minLen, maxLen := pc.NameMinLen, pc.NameMaxLen
+	if minLen <= 0 {
+		minLen = defaultNameMinLen
+	}
+	if maxLen <= 0 {
+		maxLen = defaultNameMaxLen
+	}
+	if n := utf8.RuneCountInString(name); n < minLen || n > maxLen {
+		return bizerr.ErrInvalidName.Errorf("length must be between %d and %d", minLen, maxLen)
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return bizerr.ErrInvalidName.Errorf("contains control characters")
+		}
+	}
+	lower := strings.ToLower(name)
+	for _, w := range pc.BannedWords {
+		if w != "" && strings.Contains(lower, strings.ToLower(w)) {
+			return bizerr.ErrInvalidName.Errorf("contains a banned word")
+		}
+	}
+	return nil
+}
+
+// validateAvatarURL accepts an empty URL to clear the avatar, otherwise an
+// https URL on one of the allowed hosts or their subdomains.
+func validateAvatarURL(avatar string, hosts []string) error {
+	if avatar == "" {
+		return nil
+	}
+	u, err := url.Parse(avatar)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil {
+		return bizerr.ErrInvalidAvatarURL.Errorf("must be an https url")
+	}
+	if len(hosts) == 0 {
+		return nil
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, h := range hosts {
+		h = strings.ToLower(h)
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return nil
+		}
+	}
+	return bizerr.ErrInvalidAvatarURL.Errorf("host %s is not allowed", host)
+}
@@ -0,0 +1,134 @@
+package account
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"strconv"
+	"time"
+)
+
+const exportPageSize = 500
+
+func (s *AccountService) SearchAccounts(ctx context.Context, req *SearchAccountsRequest) (*AccountPageResponse, error) {
+	sr, err := makeAccountSearchRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("SearchAccounts: %w", err)
+	}
+	page, err := s.account.SearchAccounts(ctx, sr)
+	if err != nil {
+		return nil, fmt.Errorf("SearchAccounts: search err: %w", err)
+	}
+	res := &AccountPageResponse{
+		Accounts:   make([]*AdminAccountResponse, len(page.Accounts)),
+		NextCursor: page.NextCursor,
+	}
+	for i := range page.Accounts {
+		res.Accounts[i] = makeBizToAdminAccountResponse(page.Accounts[i])
+	}
+	return res, nil
+}
+
+// WriteAccountsCSV writes every account matching the filters, walking the
+// pages with the cursor so memory stays flat however many accounts match.
+func (s *AccountService) WriteAccountsCSV(ctx context.Context, req *SearchAccountsRequest, w io.Writer) error {
+	sr, err := makeAccountSearchRequest(req)
+	if err != nil {
+		return fmt.Errorf("WriteAccountsCSV: %w", err)
+	}
+	sr.Limit = exportPageSize
+
+	cw := csv.NewWriter(w)
+	header := []string{"account_id", "email", "name", "provider", "solana_addr", "integral", "received",
+		"claim_count", "state", "email_verified", "create_at"}
+	if err = cw.Write(header); err != nil {
+		return fmt.Errorf("WriteAccountsCSV: write header err: %w", err)
+	}
+	for {
+		page, err := s.account.SearchAccounts(ctx, sr)
+		if err != nil {
+			return fmt.Errorf("WriteAccountsCSV: search err: %w", err)
+		}
+		for _, a := range page.Accounts {
+			record := []string{
+				a.AccountID,
+				a.Email,
+				a.Name,
+				a.Provider,
+				a.SolanaAddr,
+				strconv.Itoa(a.Integral),
+				strconv.Itoa(a.Received),
+				strconv.Itoa(a.ClaimCount),
+				biz.AccountStates[a.State],
+				strconv.FormatBool(a.EmailVerified),
+				a.CreateAt.UTC().Format(time.RFC3339),
+			}
+			if err = cw.Write(record); err != nil {
+				return fmt.Errorf("WriteAccountsCSV: write(%s) err: %w", a.AccountID, err)
+			}
+		}
+		cw.Flush()
+		if err = cw.Error(); err != nil {
+			return fmt.Errorf("WriteAccountsCSV: flush err: %w", err)
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		if sr.After, err = biz.DecodeAccountCursor(page.NextCursor); err != nil {
+			return fmt.Errorf("WriteAccountsCSV: decode cursor err: %w", err)
+		}
+	}
+}
+
+func makeAccountSearchRequest(req *SearchAccountsRequest) (*biz.AccountSearchRequest, error) {
+	sr := &biz.AccountSearchRequest{
+		AccountIDPrefix: req.AccountIDPrefix,
+		Email:           req.Email,
+		NamePrefix:      req.Name,
+		Provider:        req.Provider,
+		SolanaAddr:      req.SolanaAddr,
+		CreatedFrom:     req.CreatedFrom,
+		CreatedTo:       req.CreatedTo,
+		MinIntegral:     req.MinIntegral,
+		MaxIntegral:     req.MaxIntegral,
+		Sort:            req.Sort,
+		Desc:            req.Desc,
+		Limit:           req.Limit,
+	}
+	if req.State != "" {
+		state, ok := accountStateByName(req.State)
+		if !ok {
+			return nil, bizerr.ErrBadRequest.Errorf("unknown state %s", req.State)
+		}
+		sr.State = &state
+	}
+	if req.Cursor != "" {
+		after, err := biz.DecodeAccountCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		sr.After = after
+	}
+	return sr, nil
+}
+
+func accountStateByName(name string) (int, bool) {
+	for state, n := range biz.AccountStates {
+		if n == name {
+			return state, true
+		}
+	}
+	return 0, false
+}
+
+func makeBizToAdminAccountResponse(req *biz.AccountResponse) *AdminAccountResponse {
+	return &AdminAccountResponse{
+		AccountResponse: makeBizToAccountResponse(req),
+		StateReason:     req.StateReason,
+		StateExpireAt:   req.StateExpireAt,
+		CreateAt:        req.CreateAt,
+	}
+}
@@ -3,6 +3,10 @@ package account
 import (
 	"starland-account/configs"
 	"starland-account/internal/biz"
+	"starland-account/internal/pkg/mailer"
+	"starland-account/internal/pkg/storage"
+	"starland-account/internal/pkg/worker"
+	"time"
 
 	"github.com/google/wire"
 )
@@ -10,26 +14,39 @@ import (
 var ProviderSet = wire.NewSet(NewAccountService)
 
 type AccountService struct {
-	cfg     *configs.Config
-	account *biz.AccountUsecase
+	cfg      *configs.Config
+	account  *biz.AccountUsecase
+	activity *biz.ActivityUsecase
+	store    storage.Storage
+	mailer   mailer.Mailer
 }
 
-func NewAccountService(cfg *configs.Config, account *biz.AccountUsecase) *AccountService {
-	s := &AccountService{cfg: cfg, account: account}
-	go s.solanaChainDataCheckTask()
-	return s
+func NewAccountService(cfg *configs.Config, account *biz.AccountUsecase, activity *biz.ActivityUsecase,
+	store storage.Storage, mail mailer.Mailer) *AccountService {
+	return &AccountService{cfg: cfg, account: account, activity: activity, store: store, mailer: mail}
+}
+
+// Workers checks the claims against the chain and purges erased accounts,
+// on the leading instance only.
+func (s *AccountService) Workers() []worker.Worker {
+	return []worker.Worker{
+		{Name: "account.chain_check", Run: worker.Every(24*time.Second, false, s.checkChainData), Leader: true},
+		{Name: "account.purge", Run: worker.Every(time.Hour, false, s.purgeAccounts), Leader: true},
+	}
 }
 
 type AccountResponse struct {
-	AccountID  string `json:"account_id"`
-	Email      string `json:"email"`
-	Name       string `json:"name"`
-	Provider   string `json:"provider"`
-	AvatarURL  string `json:"avatar_url"`
-	Integral   int    `json:"integral"`
-	Received   int    `json:"received"`
-	SolanaAddr string `json:"solana_addr"`
-	ClaimCount int    `json:"claim_count"`
+	AccountID     string `json:"account_id"`
+	Email         string `json:"email"`
+	Name          string `json:"name"`
+	Provider      string `json:"provider"`
+	AvatarURL     string `json:"avatar_url"`
+	Integral      int    `json:"integral"`
+	Received      int    `json:"received"`
+	SolanaAddr    string `json:"solana_addr"`
+	ClaimCount    int    `json:"claim_count"`
+	State         string `json:"state"`
+	EmailVerified bool   `json:"email_verified"`
 }
 
 type AccountRequest struct {
@@ -45,3 +62,121 @@ type ClaimPointsRequest struct {
 	Points    int
 	IsOK      bool
 }
+
+type ChangeStateRequest struct {
+	AccountID string
+	Reason    string
+	Actor     string
+	Duration  time.Duration
+}
+
+type AccountStateLogResponse struct {
+	FromState string     `json:"from_state"`
+	ToState   string     `json:"to_state"`
+	Reason    string     `json:"reason"`
+	Actor     string     `json:"actor"`
+	ExpireAt  *time.Time `json:"expire_at"`
+	CreateAt  time.Time  `json:"create_at"`
+}
+
+type AppealResponse struct {
+	AppealID  string    `json:"appeal_id"`
+	AccountID string    `json:"account_id"`
+	Content   string    `json:"content"`
+	State     string    `json:"state"`
+	Actor     string    `json:"actor"`
+	Reply     string    `json:"reply"`
+	CreateAt  time.Time `json:"create_at"`
+	UpdateAt  time.Time `json:"update_at"`
+}
+
+type ResolveAppealRequest struct {
+	AppealID string
+	Approve  bool
+	Actor    string
+	Reply    string
+}
+
+type AccountExport struct {
+	ExportedAt     time.Time                  `json:"exported_at"`
+	Profile        *AccountResponse           `json:"profile"`
+	Identities     []*IdentityResponse        `json:"identities"`
+	ActivityLogs   []*ActivityLogExport       `json:"activity_logs"`
+	Claims         []*ClaimLogResponse        `json:"claims"`
+	StateLogs      []*AccountStateLogResponse `json:"state_logs"`
+	ProfileChanges []*ProfileChangeResponse   `json:"profile_changes"`
+}
+
+type IdentityResponse struct {
+	Provider string `json:"provider"`
+	Email    string `json:"email,omitempty"`
+	Address  string `json:"address,omitempty"`
+}
+
+type ActivityLogExport struct {
+	ActivityCode int       `json:"activity_code"`
+	ActivityName string    `json:"activity_name"`
+	Integral     int       `json:"integral"`
+	CreateAt     time.Time `json:"create_at"`
+}
+
+type ClaimLogResponse struct {
+	Points     int       `json:"points"`
+	Received   int       `json:"received"`
+	SolanaAddr string    `json:"solana_addr"`
+	ClaimCount int       `json:"claim_count"`
+	CreateAt   time.Time `json:"create_at"`
+}
+
+// UpdateProfileRequest carries the fields to change; nil fields are left as is.
+type UpdateProfileRequest struct {
+	AccountID string
+	Name      *string
+	AvatarURL *string
+	Actor     string
+}
+
+type ProfileChangeResponse struct {
+	Field    string    `json:"field"`
+	OldValue string    `json:"old_value"`
+	NewValue string    `json:"new_value"`
+	Actor    string    `json:"actor"`
+	CreateAt time.Time `json:"create_at"`
+}
+
+// SearchAccountsRequest filters are combined with AND; empty fields and nil
+// pointers are ignored. Name matches as a prefix.
+type SearchAccountsRequest struct {
+	AccountIDPrefix string
+	Email           string
+	Name            string
+	Provider        string
+	SolanaAddr      string
+	State           string
+	CreatedFrom     *time.Time
+	CreatedTo       *time.Time
+	MinIntegral     *int
+	MaxIntegral     *int
+	Sort            string
+	Desc            bool
+	Limit           int
+	Cursor          string
+}
+
+type AccountPageResponse struct {
+	Accounts   []*AdminAccountResponse `json:"accounts"`
+	NextCursor string                  `json:"next_cursor"`
+}
+
+type AdminAccountResponse struct {
+	*AccountResponse
+	StateReason   string     `json:"state_reason"`
+	StateExpireAt *time.Time `json:"state_expire_at"`
+	CreateAt      time.Time  `json:"create_at"`
+}
+
+type AvatarResponse struct {
+	AvatarURL  string            `json:"avatar_url"`
+	Thumbnails map[string]string `json:"thumbnails"`
+	Account    *AccountResponse  `json:"account"`
+}
@@ -0,0 +1,149 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"strings"
+	"time"
+)
+
+var appealStates = map[int]string{
+	biz.AppealPending:  "pending",
+	biz.AppealApproved: "approved",
+	biz.AppealRejected: "rejected",
+}
+
+// SuspendAccount suspends an active account; a zero Duration suspends it
+// until it is lifted by an admin. A ban is never turned into a suspension.
+func (s *AccountService) SuspendAccount(ctx context.Context, req *ChangeStateRequest) error {
+	var expireAt *time.Time
+	if req.Duration > 0 {
+		t := time.Now().Add(req.Duration)
+		expireAt = &t
+	}
+	err := s.account.ChangeAccountState(ctx, &biz.AccountStateRequest{
+		AccountID: req.AccountID,
+		State:     biz.AccountStateSuspended,
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		ExpireAt:  expireAt,
+		From:      []int{biz.AccountStateActive},
+	})
+	if err != nil {
+		return fmt.Errorf("SuspendAccount: change state err: %w", err)
+	}
+	return nil
+}
+
+// BanAccount bans an active or suspended account; an erased one stays as it
+// is.
+func (s *AccountService) BanAccount(ctx context.Context, req *ChangeStateRequest) error {
+	err := s.account.ChangeAccountState(ctx, &biz.AccountStateRequest{
+		AccountID: req.AccountID,
+		State:     biz.AccountStateBanned,
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		From:      []int{biz.AccountStateActive, biz.AccountStateSuspended},
+	})
+	if err != nil {
+		return fmt.Errorf("BanAccount: change state err: %w", err)
+	}
+	return nil
+}
+
+// UnsuspendAccount restores a suspended account to active; a banned one is
+// left to UnbanAccount.
+func (s *AccountService) UnsuspendAccount(ctx context.Context, req *ChangeStateRequest) error {
+	err := s.account.ChangeAccountState(ctx, &biz.AccountStateRequest{
+		AccountID: req.AccountID,
+		State:     biz.AccountStateActive,
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		From:      []int{biz.AccountStateSuspended},
+	})
+	if err != nil {
+		return fmt.Errorf("UnsuspendAccount: change state err: %w", err)
+	}
+	return nil
+}
+
+// UnbanAccount restores a banned account to active. It takes a reason, kept
+// in the state log with the admin who lifted the ban.
+func (s *AccountService) UnbanAccount(ctx context.Context, req *ChangeStateRequest) error {
+	if strings.TrimSpace(req.Reason) == "" {
+		return bizerr.ErrInvalidArgument.Errorf("a reason is required to lift a ban")
+	}
+	err := s.account.ChangeAccountState(ctx, &biz.AccountStateRequest{
+		AccountID: req.AccountID,
+		State:     biz.AccountStateActive,
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		From:      []int{biz.AccountStateBanned},
+	})
+	if err != nil {
+		return fmt.Errorf("UnbanAccount: change state err: %w", err)
+	}
+	return nil
+}
+
+func (s *AccountService) QueryAccountStateLogs(ctx context.Context, accountID string) ([]*AccountStateLogResponse, error) {
+	logs, err := s.account.QueryAccountStateLogs(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("QueryAccountStateLogs: query err: %w", err)
+	}
+	res := make([]*AccountStateLogResponse, len(logs))
+	for i := range logs {
+		res[i] = &AccountStateLogResponse{
+			FromState: biz.AccountStates[logs[i].FromState],
+			ToState:   biz.AccountStates[logs[i].ToState],
+			Reason:    logs[i].Reason,
+			Actor:     logs[i].Actor,
+			ExpireAt:  logs[i].ExpireAt,
+			CreateAt:  logs[i].CreateAt,
+		}
+	}
+	return res, nil
+}
+
+func (s *AccountService) Appeal(ctx context.Context, accountID, content string) error {
+	if err := s.account.AddAppeal(ctx, &biz.AppealRequest{AccountID: accountID, Content: content}); err != nil {
+		return fmt.Errorf("Appeal: add appeal err: %w", err)
+	}
+	return nil
+}
+
+func (s *AccountService) QueryAppeals(ctx context.Context, state string) ([]*AppealResponse, error) {
+	code := biz.AppealPending
+	for k, v := range appealStates {
+		if v == state {
+			code = k
+		}
+	}
+	appeals, err := s.account.QueryAppeals(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("QueryAppeals: query err: %w", err)
+	}
+	res := make([]*AppealResponse, len(appeals))
+	for i := range appeals {
+		res[i] = &AppealResponse{
+			AppealID:  appeals[i].AppealID,
+			AccountID: appeals[i].AccountID,
+			Content:   appeals[i].Content,
+			State:     appealStates[appeals[i].State],
+			Actor:     appeals[i].Actor,
+			Reply:     appeals[i].Reply,
+			CreateAt:  appeals[i].CreateAt,
+			UpdateAt:  appeals[i].UpdateAt,
+		}
+	}
+	return res, nil
+}
+
+func (s *AccountService) ResolveAppeal(ctx context.Context, req *ResolveAppealRequest) error {
+	if err := s.account.ResolveAppeal(ctx, req.AppealID, req.Approve, req.Actor, req.Reply); err != nil {
+		return fmt.Errorf("ResolveAppeal: resolve err: %w", err)
+	}
+	return nil
+}
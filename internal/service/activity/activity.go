@@ -2,10 +2,11 @@ package activity
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"starland-account/configs"
 	"starland-account/internal/biz"
 	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/tenant"
 	"time"
 
 	"go.uber.org/zap"
@@ -18,44 +19,35 @@ const (
 
 var (
 	ActivityKey = "starland-account:%d_%s"
-	LimitError  = errors.New("You've reached the limit")
+	// DailyPointsKey counts the points an account earned on a UTC day
+	DailyPointsKey = "starland-account:daily_points:%s_%s"
 )
 
-func (s *ActivityService) refreshTask() {
-	defer func() {
-		if p := recover(); p != nil {
-			zap.S().Errorf("refreshTask: panic: %v", p)
-		}
-		s.refreshTask()
-	}()
-	s.refreshActMap()
-	ticker := time.NewTicker(5 * time.Minute)
-	for {
-		select {
-		case <-ticker.C:
-			s.refreshActMap()
+// refreshActMap reloads every tenant's activities.
+func (s *ActivityService) refreshActMap(ctx context.Context) {
+	actMap := make(map[string]map[int]*biz.ActivityResponse)
+	tenant.Each(ctx, s.cfg, func(ctx context.Context, t *configs.TenantConfig) {
+		res, err := s.activity.QueryActivity(ctx)
+		if err != nil {
+			zap.S().Errorf("Play: query activity to map tenant: %s err: %v", t.ID, err)
+			return
 		}
-	}
-}
-
-func (s *ActivityService) refreshActMap() {
-	ctx := context.Background()
+		actMap[t.ID] = res
+	})
 	s.actMaplock.Lock()
 	defer s.actMaplock.Unlock()
-	res, err := s.activity.QueryActivity(ctx)
-	if err != nil {
-		zap.S().Errorf("Play: query activity to map err: %w", err)
-		return
+	for id, res := range actMap {
+		s.actMap[id] = res
 	}
-	s.actMap = res
 }
 
-func (s *ActivityService) QueryActivityLogs(ctx context.Context, account string, page, limit int) ([]*ActivityLogResponse, int64, error) {
-	res, count, err := s.activity.QueryActivityLog(ctx, account, page, limit)
-	if err != nil {
-		return nil, count, fmt.Errorf("QueryActivityLog: query err: %w", err)
-	}
-	return makeActivityLogs(res), count, nil
+// queryActivity looks the activity up among the context tenant's.
+func (s *ActivityService) queryActivity(ctx context.Context, activityCode int) (*biz.ActivityResponse, bool) {
+	id, _ := tenant.FromContext(ctx)
+	s.actMaplock.RLock()
+	defer s.actMaplock.RUnlock()
+	v, ok := s.actMap[id][activityCode]
+	return v, ok
 }
 
 func (s *ActivityService) QueryActivitys(ctx context.Context) ([]*ActivityResponse, error) {
@@ -66,30 +58,82 @@ func (s *ActivityService) QueryActivitys(ctx context.Context) ([]*ActivityRespon
 	return makeActivitys(res), nil
 }
 
-func (s *ActivityService) Play(ctx context.Context, activityCode int, account string) error {
+func (s *ActivityService) Play(ctx context.Context, req *PlayRequest) error {
+	activityCode, account := req.ActivityCode, req.Account
+	if err := s.account.CheckAccountState(ctx, account); err != nil {
+		return fmt.Errorf("Play: check account state err: %w", err)
+	}
 	key := fmt.Sprintf(ActivityKey, activityCode, account)
-	if v, ok := s.actMap[activityCode]; ok {
+	if v, ok := s.queryActivity(ctx, activityCode); ok {
+		ac, err := s.account.QueryAccount(ctx, account, "", "")
+		if err != nil {
+			return fmt.Errorf("Play: query account err: %w", err)
+		}
+		risk := s.risk.Evaluate(ctx, &biz.RiskRequest{
+			AccountID:    account,
+			ActivityCode: activityCode,
+			IP:           req.IP,
+			DeviceID:     req.DeviceID,
+			SolanaAddr:   ac.SolanaAddr,
+			AccountAge:   time.Since(ac.CreateAt),
+		})
+		switch risk.Action {
+		case biz.RiskActionBlock:
+			zap.S().Infof("Play: blocked account: %s score: %d hits: %v", account, risk.Score, risk.Hits)
+			return bizerr.ErrRiskBlocked
+		case biz.RiskActionThrottle:
+			// slowed down rather than refused: one play per cooldown
+			if !s.risk.Throttle(ctx, account) {
+				zap.S().Infof("Play: throttled account: %s score: %d hits: %v", account, risk.Score, risk.Hits)
+				return bizerr.ErrRiskThrottled
+			}
+		}
+
 		expend, err := s.activity.QueryActivityExpend(ctx, key)
 		if err != nil {
 			return fmt.Errorf("Play: query left activity count err: %w", err)
 		}
 		if expend >= v.Limit {
 			zap.S().Infof("Play: Activity Count[account: %s activity: %s count: %d]", account, v.ActivityName, expend)
-			return LimitError
+			return bizerr.ErrActivityLimitReached
 		}
-		err = s.account.UpdateAccountIntegral(ctx, account, v.Integral)
-		if err != nil {
-			return fmt.Errorf("Play: [%s] update account integral err: %w", v.ActivityName, err)
+
+		var t *configs.TenantConfig
+		if id, ok := tenant.FromContext(ctx); ok {
+			t = tenant.Find(s.cfg, id)
 		}
-		log := &biz.ActivityLogRequest{
-			AccountID:    account,
-			ActivityCode: v.ActivityCode,
-			ActivityName: v.ActivityName,
-			Integral:     v.Integral,
+		pointsKey := fmt.Sprintf(DailyPointsKey, time.Now().UTC().Format("2006-01-02"), account)
+		earned := 0
+		if t != nil && t.DailyPoints > 0 {
+			earned, err = s.activity.QueryActivityExpend(ctx, pointsKey)
+			if err != nil {
+				return fmt.Errorf("Play: query daily points err: %w", err)
+			}
+			if earned+v.Integral > t.DailyPoints {
+				zap.S().Infof("Play: Daily Points[account: %s earned: %d cap: %d]", account, earned, t.DailyPoints)
+				return bizerr.ErrDailyPointsReached
+			}
 		}
-		err = s.activity.AddActivityLog(ctx, log)
-		if err != nil {
-			return fmt.Errorf("Play: [%+v] add activity log err: %w", *log, err)
+
+		// shadow awards look successful to the caller but credit nothing
+		if risk.Action == biz.RiskActionShadowAward {
+			zap.S().Infof("Play: shadow award account: %s score: %d hits: %v", account, risk.Score, risk.Hits)
+		} else {
+			log := &biz.ActivityLogRequest{
+				AccountID:    account,
+				ActivityCode: v.ActivityCode,
+				ActivityName: v.ActivityName,
+				Integral:     v.Integral,
+			}
+			err = s.activity.EarnPoints(ctx, log)
+			if err != nil {
+				return fmt.Errorf("Play: [%+v] earn points err: %w", *log, err)
+			}
+			if t != nil && t.DailyPoints > 0 {
+				if err = s.activity.ConsumeActivityLimit(ctx, pointsKey, earned+v.Integral, 48*time.Hour); err != nil {
+					return fmt.Errorf("Play: consume daily points err: %w", err)
+				}
+			}
 		}
 
 		err = s.activity.ConsumeActivityLimit(ctx, key, expend+1, 24*time.Hour)
@@ -97,15 +141,16 @@ func (s *ActivityService) Play(ctx context.Context, activityCode int, account st
 			return fmt.Errorf("Play: ConsumeActivityLimit err: %w", err)
 		}
 	} else {
-		zap.S().Infof("Play: req activityCode:%d activity:(%+v)", activityCode, s.actMap)
+		zap.S().Infof("Play: req activityCode:%d activity not exist", activityCode)
 		return bizerr.ErrActivityNotExist
 	}
 
 	return nil
 }
+
 func (s *ActivityService) QueryIsLimit(ctx context.Context, activityCode int, account string) (bool, error) {
 	key := fmt.Sprintf(ActivityKey, activityCode, account)
-	if v, ok := s.actMap[activityCode]; ok {
+	if v, ok := s.queryActivity(ctx, activityCode); ok {
 		expend, err := s.activity.QueryActivityExpend(ctx, key)
 		if err != nil {
 			zap.S().Errorf("Play: query left activity count err: %w", err)
@@ -116,8 +161,9 @@ func (s *ActivityService) QueryIsLimit(ctx context.Context, activityCode int, ac
 			zap.S().Errorf("Play: Activity Count[account: %s activity: %s count: %d]", account, v.ActivityName, expend)
 			return true, nil
 		}
+		return false, nil
 	}
-	return false, nil
+	return false, bizerr.ErrActivityNotExist
 }
 
 func makeActivityLogs(acts []*biz.ActivityLogResponse) []*ActivityLogResponse {
@@ -127,8 +173,10 @@ func makeActivityLogs(acts []*biz.ActivityLogResponse) []*ActivityLogResponse {
 		res[i] = &ActivityLogResponse{
 			Account:      acts[i].AccountID,
 			CreateAt:     acts[i].CreateAt,
+			ActivityCode: acts[i].ActivityCode,
 			ActivityName: acts[i].ActivityName,
 			Integral:     acts[i].Integral,
+			ClientID:     acts[i].ClientID,
 		}
 	}
 	return res
@@ -0,0 +1,181 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/data/memory"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/tenant"
+	"testing"
+)
+
+// newTestService builds the service on in-memory repos with the activities
+// loaded, without starting its refresh task. Plays are not risk checked.
+func newTestService(t *testing.T, cfg *configs.Config, acts ...*biz.ActivityResponse) (*ActivityService, *memory.AccountRepo) {
+	t.Helper()
+	if cfg == nil {
+		cfg = &configs.Config{}
+	}
+	accounts, activities := memory.NewAccountRepo(), memory.NewActivityRepo()
+	for _, act := range acts {
+		if err := activities.AddActivity(defaultCtx(), act); err != nil {
+			t.Fatalf("AddActivity: %v", err)
+		}
+	}
+	s := NewActivityService(cfg,
+		biz.NewActivityUsecase(activities, memory.NewActivityLogRepo(accounts)),
+		biz.NewAccountUsecase(accounts, nil, nil, memory.NewVerifyRepo(accounts)),
+		biz.NewRiskUsecase(cfg, nil))
+	s.refreshActMap(context.Background())
+	return s, accounts
+}
+
+func defaultCtx() context.Context {
+	return tenant.NewContext(context.Background(), tenant.Default)
+}
+
+// reason is the bizerr reason of err, empty for other errors.
+func reason(err error) string {
+	var be *bizerr.BizError
+	if errors.As(err, &be) {
+		return be.Reason()
+	}
+	return ""
+}
+
+func TestPlay(t *testing.T) {
+	game := &biz.ActivityResponse{ActivityCode: 1, ActivityName: "game", Integral: 10, Limit: 2}
+	tests := []struct {
+		name         string
+		state        int
+		dailyPoints  int
+		plays        []int
+		wantReasons  []string
+		wantIntegral int
+	}{
+		{name: "earns points", plays: []int{1}, wantReasons: []string{""}, wantIntegral: 10},
+		{
+			name:         "stops at the activity limit",
+			plays:        []int{1, 1, 1},
+			wantReasons:  []string{"", "", bizerr.ErrActivityLimitReached.Reason()},
+			wantIntegral: 20,
+		},
+		{
+			name:         "stops at the daily points cap",
+			dailyPoints:  15,
+			plays:        []int{1, 1},
+			wantReasons:  []string{"", bizerr.ErrDailyPointsReached.Reason()},
+			wantIntegral: 10,
+		},
+		{name: "unknown activity", plays: []int{9}, wantReasons: []string{bizerr.ErrActivityNotExist.Reason()}},
+		{name: "banned", state: biz.AccountStateBanned, plays: []int{1}, wantReasons: []string{bizerr.ErrAccountBanned.Reason()}},
+		{name: "deleted", state: biz.AccountStateDeleted, plays: []int{1}, wantReasons: []string{bizerr.ErrAccountDeleted.Reason()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, accounts := newTestService(t, &configs.Config{Tenants: []configs.TenantConfig{
+				{ID: tenant.Default, DailyPoints: tt.dailyPoints},
+			}}, game)
+			ctx := defaultCtx()
+			if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1", State: tt.state}); err != nil {
+				t.Fatalf("SaveAccount: %v", err)
+			}
+			for i, code := range tt.plays {
+				err := s.Play(ctx, &PlayRequest{ActivityCode: code, Account: "a1"})
+				if got := reason(err); got != tt.wantReasons[i] || (got == "" && err != nil) {
+					t.Fatalf("play %d err = %v, want %s", i, err, tt.wantReasons[i])
+				}
+			}
+			a, _ := accounts.QueryAccount(ctx, "a1", "", "")
+			if a.Integral != tt.wantIntegral {
+				t.Errorf("Integral = %d, want %d", a.Integral, tt.wantIntegral)
+			}
+		})
+	}
+}
+
+func TestPlayUnknownAccount(t *testing.T) {
+	s, _ := newTestService(t, nil, &biz.ActivityResponse{ActivityCode: 1, Integral: 10, Limit: 1})
+	err := s.Play(defaultCtx(), &PlayRequest{ActivityCode: 1, Account: "missing"})
+	if got := reason(err); got != bizerr.ErrAccountNotExist.Reason() {
+		t.Errorf("Play err = %v, want %s", err, bizerr.ErrAccountNotExist.Reason())
+	}
+}
+
+func TestPlayPerTenant(t *testing.T) {
+	cfg := &configs.Config{Tenants: []configs.TenantConfig{{ID: "other"}}}
+	s, accounts := newTestService(t, cfg, &biz.ActivityResponse{ActivityCode: 1, Integral: 10, Limit: 1})
+	other := tenant.NewContext(context.Background(), "other")
+	if err := accounts.SaveAccount(other, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	// the activity belongs to the default tenant only
+	err := s.Play(other, &PlayRequest{ActivityCode: 1, Account: "a1"})
+	if got := reason(err); got != bizerr.ErrActivityNotExist.Reason() {
+		t.Errorf("Play err = %v, want %s", err, bizerr.ErrActivityNotExist.Reason())
+	}
+}
+
+func TestQueryIsLimit(t *testing.T) {
+	s, accounts := newTestService(t, nil, &biz.ActivityResponse{ActivityCode: 1, Integral: 10, Limit: 1})
+	ctx := defaultCtx()
+	if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+
+	for i, want := range []bool{false, true} {
+		limited, err := s.QueryIsLimit(ctx, 1, "a1")
+		if err != nil || limited != want {
+			t.Fatalf("QueryIsLimit %d = %v, %v, want %v", i, limited, err, want)
+		}
+		if !limited {
+			if err = s.Play(ctx, &PlayRequest{ActivityCode: 1, Account: "a1"}); err != nil {
+				t.Fatalf("Play: %v", err)
+			}
+		}
+	}
+	if _, err := s.QueryIsLimit(ctx, 9, "a1"); reason(err) != bizerr.ErrActivityNotExist.Reason() {
+		t.Errorf("QueryIsLimit(unknown) err = %v", err)
+	}
+}
+
+func TestQueryActivityLogs(t *testing.T) {
+	s, accounts := newTestService(t, nil,
+		&biz.ActivityResponse{ActivityCode: 1, ActivityName: "game", Integral: 10, Limit: 5},
+		&biz.ActivityResponse{ActivityCode: 2, ActivityName: "quiz", Integral: 5, Limit: 5})
+	ctx := defaultCtx()
+	if err := accounts.SaveAccount(ctx, &biz.AccountRequest{AccountID: "a1"}); err != nil {
+		t.Fatalf("SaveAccount: %v", err)
+	}
+	for _, code := range []int{1, 2, 1} {
+		if err := s.Play(ctx, &PlayRequest{ActivityCode: code, Account: "a1"}); err != nil {
+			t.Fatalf("Play: %v", err)
+		}
+	}
+
+	first, err := s.QueryActivityLogs(ctx, &ActivityLogQueryRequest{Account: "a1", Limit: 2})
+	if err != nil {
+		t.Fatalf("QueryActivityLogs: %v", err)
+	}
+	if len(first.Data) != 2 || first.Count != 3 || len(first.Totals) != 2 || first.Totals[0].Integral != 20 || first.NextCursor == "" {
+		t.Fatalf("first page = %+v, want 2 of 3 logs with totals", first)
+	}
+	next, err := s.QueryActivityLogs(ctx, &ActivityLogQueryRequest{Account: "a1", Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("QueryActivityLogs(next): %v", err)
+	}
+	if len(next.Data) != 1 || next.Data[0].ActivityCode != 1 || next.Totals != nil {
+		t.Errorf("next page = %+v, want the oldest log without totals", next)
+	}
+
+	// v1 clients paging by offset read count as the total on every page
+	second, err := s.QueryActivityLogs(ctx, &ActivityLogQueryRequest{Account: "a1", Limit: 2, Page: 2})
+	if err != nil {
+		t.Fatalf("QueryActivityLogs(page 2): %v", err)
+	}
+	if len(second.Data) != 1 || second.Count != 3 {
+		t.Errorf("page 2 = %+v, want the oldest log with count 3", second)
+	}
+}
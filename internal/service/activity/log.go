@@ -0,0 +1,149 @@
+package activity
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"strconv"
+	"time"
+)
+
+const (
+	LogFormatCSV    = "csv"
+	LogFormatNDJSON = "ndjson"
+
+	exportPageSize = 1000
+)
+
+// QueryActivityLogs returns a page of logs. Count and the totals are only
+// summed for the first page when walking with the cursor, but for every page
+// when paging by page, as v1 clients read count as the total on each.
+func (s *ActivityService) QueryActivityLogs(ctx context.Context, req *ActivityLogQueryRequest) (*ActivityLogPageResponse, error) {
+	query, err := makeActivityLogQuery(req)
+	if err != nil {
+		return nil, fmt.Errorf("QueryActivityLogs: %w", err)
+	}
+	page, err := s.activity.QueryActivityLog(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("QueryActivityLogs: query err: %w", err)
+	}
+	res := &ActivityLogPageResponse{
+		Data:       makeActivityLogs(page.Logs),
+		NextCursor: page.NextCursor,
+	}
+	if query.After != nil {
+		return res, nil
+	}
+
+	totals, err := s.activity.SumActivityLogs(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("QueryActivityLogs: sum err: %w", err)
+	}
+	res.Totals = make([]*ActivityLogTotalResponse, len(totals))
+	for i := range totals {
+		res.Count += totals[i].Count
+		res.Totals[i] = &ActivityLogTotalResponse{
+			ActivityCode: totals[i].ActivityCode,
+			ActivityName: totals[i].ActivityName,
+			Count:        totals[i].Count,
+			Integral:     totals[i].Integral,
+		}
+	}
+	return res, nil
+}
+
+// WriteActivityLogs writes every log matching the filters as csv or ndjson,
+// walking the pages with the cursor so memory stays flat for long histories.
+func (s *ActivityService) WriteActivityLogs(ctx context.Context, req *ActivityLogQueryRequest, format string, w io.Writer) error {
+	query, err := makeActivityLogQuery(req)
+	if err != nil {
+		return fmt.Errorf("WriteActivityLogs: %w", err)
+	}
+	query.Limit, query.Page = exportPageSize, 1
+
+	var (
+		write func(*biz.ActivityLogResponse) error
+		flush func() error
+	)
+	switch format {
+	case "", LogFormatCSV:
+		cw := csv.NewWriter(w)
+		if err = cw.Write([]string{"id", "account_id", "activity_code", "activity_name", "integral", "create_at", "client_id"}); err != nil {
+			return fmt.Errorf("WriteActivityLogs: write header err: %w", err)
+		}
+		write = func(l *biz.ActivityLogResponse) error {
+			return cw.Write([]string{
+				strconv.FormatUint(uint64(l.ID), 10),
+				l.AccountID,
+				strconv.Itoa(l.ActivityCode),
+				l.ActivityName,
+				strconv.Itoa(l.Integral),
+				l.CreateAt.UTC().Format(time.RFC3339),
+				l.ClientID,
+			})
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case LogFormatNDJSON:
+		enc := json.NewEncoder(w)
+		write = func(l *biz.ActivityLogResponse) error {
+			return enc.Encode(&ActivityLogResponse{
+				CreateAt:     l.CreateAt,
+				Account:      l.AccountID,
+				ActivityCode: l.ActivityCode,
+				ActivityName: l.ActivityName,
+				Integral:     l.Integral,
+				ClientID:     l.ClientID,
+			})
+		}
+		flush = func() error { return nil }
+	default:
+		return fmt.Errorf("WriteActivityLogs: %w", bizerr.ErrBadRequest.Errorf("unknown format %s", format))
+	}
+
+	for {
+		page, err := s.activity.QueryActivityLog(ctx, query)
+		if err != nil {
+			return fmt.Errorf("WriteActivityLogs: query err: %w", err)
+		}
+		for _, l := range page.Logs {
+			if err = write(l); err != nil {
+				return fmt.Errorf("WriteActivityLogs: write(%d) err: %w", l.ID, err)
+			}
+		}
+		if err = flush(); err != nil {
+			return fmt.Errorf("WriteActivityLogs: flush err: %w", err)
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		if query.After, err = biz.DecodeActivityLogCursor(page.NextCursor); err != nil {
+			return fmt.Errorf("WriteActivityLogs: decode cursor err: %w", err)
+		}
+	}
+}
+
+func makeActivityLogQuery(req *ActivityLogQueryRequest) (*biz.ActivityLogQuery, error) {
+	query := &biz.ActivityLogQuery{
+		AccountID:    req.Account,
+		ActivityCode: req.ActivityCode,
+		From:         req.From,
+		To:           req.To,
+		Limit:        req.Limit,
+		Page:         req.Page,
+	}
+	if req.Cursor != "" {
+		after, err := biz.DecodeActivityLogCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query.After = after
+	}
+	return query, nil
+}
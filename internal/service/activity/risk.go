@@ -0,0 +1,53 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"starland-account/internal/biz"
+)
+
+var riskReviewStates = map[int]string{
+	biz.RiskReviewPending:   "pending",
+	biz.RiskReviewCleared:   "cleared",
+	biz.RiskReviewConfirmed: "confirmed",
+}
+
+func (s *ActivityService) QueryRiskReviews(ctx context.Context, state string) ([]*RiskReviewResponse, error) {
+	code := biz.RiskReviewPending
+	for k, v := range riskReviewStates {
+		if v == state {
+			code = k
+		}
+	}
+	reviews, err := s.risk.QueryRiskReviews(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("QueryRiskReviews: query err: %w", err)
+	}
+	res := make([]*RiskReviewResponse, len(reviews))
+	for i := range reviews {
+		res[i] = &RiskReviewResponse{
+			ID:           reviews[i].ID,
+			AccountID:    reviews[i].AccountID,
+			ActivityCode: reviews[i].ActivityCode,
+			IP:           reviews[i].IP,
+			DeviceID:     reviews[i].DeviceID,
+			Score:        reviews[i].Score,
+			Action:       reviews[i].Action,
+			Hits:         reviews[i].Hits,
+			State:        riskReviewStates[reviews[i].State],
+			Actor:        reviews[i].Actor,
+			CreateAt:     reviews[i].CreateAt,
+			UpdateAt:     reviews[i].UpdateAt,
+		}
+	}
+	return res, nil
+}
+
+// ResolveRiskReview clears a pending flag, or confirms it and bans the
+// account.
+func (s *ActivityService) ResolveRiskReview(ctx context.Context, req *ResolveRiskReviewRequest) error {
+	if err := s.risk.ResolveRiskReview(ctx, req.ID, req.Ban, req.Actor); err != nil {
+		return fmt.Errorf("ResolveRiskReview: resolve err: %w", err)
+	}
+	return nil
+}
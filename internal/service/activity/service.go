@@ -3,6 +3,7 @@ package activity
 import (
 	"starland-account/configs"
 	"starland-account/internal/biz"
+	"starland-account/internal/pkg/worker"
 	"sync"
 	"time"
 
@@ -15,25 +16,63 @@ type ActivityService struct {
 	cfg        *configs.Config
 	activity   *biz.ActivityUsecase
 	account    *biz.AccountUsecase
-	actMap     map[int]*biz.ActivityResponse
+	risk       *biz.RiskUsecase
+	actMap     map[string]map[int]*biz.ActivityResponse
 	actMaplock sync.RWMutex
 }
 
 func NewActivityService(cfg *configs.Config,
-	act *biz.ActivityUsecase, ac *biz.AccountUsecase) *ActivityService {
-	s := &ActivityService{cfg: cfg,
+	act *biz.ActivityUsecase, ac *biz.AccountUsecase, risk *biz.RiskUsecase) *ActivityService {
+	return &ActivityService{cfg: cfg,
 		activity: act,
 		account:  ac,
-		actMap:   make(map[int]*biz.ActivityResponse)}
-	go s.refreshTask()
-	return s
+		risk:     risk,
+		actMap:   make(map[string]map[int]*biz.ActivityResponse)}
+}
+
+// Workers loads the activities and refreshes them; plays of an activity fail
+// until it is loaded.
+func (s *ActivityService) Workers() []worker.Worker {
+	return []worker.Worker{
+		{Name: "activity.refresh", Run: worker.Every(5*time.Minute, true, s.refreshActMap)},
+	}
 }
 
 type ActivityLogResponse struct {
 	CreateAt     time.Time `json:"create_at"`
 	Account      string    `json:"account"`
+	ActivityCode int       `json:"activity_code"`
 	ActivityName string    `json:"activity_name"`
 	Integral     int       `json:"integral"`
+	ClientID     string    `json:"client_id,omitempty"`
+}
+
+// ActivityLogQueryRequest filters an account's logs; nil filters are ignored
+// and To is exclusive. Cursor takes precedence over the legacy Page.
+type ActivityLogQueryRequest struct {
+	Account      string
+	ActivityCode *int
+	From         *time.Time
+	To           *time.Time
+	Limit        int
+	Page         int
+	Cursor       string
+}
+
+// ActivityLogPageResponse carries Count and Totals on the first page only,
+// so following pages cost a single indexed query.
+type ActivityLogPageResponse struct {
+	Data       []*ActivityLogResponse      `json:"data"`
+	Count      int64                       `json:"count"`
+	NextCursor string                      `json:"next_cursor"`
+	Totals     []*ActivityLogTotalResponse `json:"totals,omitempty"`
+}
+
+type ActivityLogTotalResponse struct {
+	ActivityCode int    `json:"activity_code"`
+	ActivityName string `json:"activity_name"`
+	Count        int64  `json:"count"`
+	Integral     int64  `json:"integral"`
 }
 
 type ActivityResponse struct {
@@ -41,3 +80,31 @@ type ActivityResponse struct {
 	ActivityCode int    `json:"activity_code"`
 	Integral     int    `json:"integral"`
 }
+
+type PlayRequest struct {
+	ActivityCode int
+	Account      string
+	IP           string
+	DeviceID     string
+}
+
+type RiskReviewResponse struct {
+	ID           uint      `json:"id"`
+	AccountID    string    `json:"account_id"`
+	ActivityCode int       `json:"activity_code"`
+	IP           string    `json:"ip"`
+	DeviceID     string    `json:"device_id"`
+	Score        int       `json:"score"`
+	Action       string    `json:"action"`
+	Hits         string    `json:"hits"`
+	State        string    `json:"state"`
+	Actor        string    `json:"actor"`
+	CreateAt     time.Time `json:"create_at"`
+	UpdateAt     time.Time `json:"update_at"`
+}
+
+type ResolveRiskReviewRequest struct {
+	ID    uint
+	Ban   bool
+	Actor string
+}
@@ -0,0 +1,259 @@
+package airdrop
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/tenant"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	airdropBatchSize = 200
+	airdropMaxRows   = 100000
+	resumeInterval   = time.Minute
+	// jobClaimTTL bounds how long a crashed instance keeps its job from the
+	// others; the claim is renewed after every batch.
+	jobClaimTTL = 5 * time.Minute
+)
+
+var (
+	jobStates  = map[int]string{biz.AirdropJobPending: "pending", biz.AirdropJobRunning: "running", biz.AirdropJobFinished: "finished"}
+	itemStates = map[int]string{biz.AirdropItemPending: "pending", biz.AirdropItemSucceeded: "succeeded", biz.AirdropItemFailed: "failed"}
+)
+
+// airdropTask resumes the jobs left unfinished by a previous run, then runs
+// the queued ones until ctx is done. The jobs StartJob could not queue are
+// picked up by the sweep every resumeInterval.
+func (s *AirdropService) airdropTask(ctx context.Context) {
+	s.resumeJobs(ctx)
+	t := time.NewTicker(resumeInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.jobs:
+			if err := s.RunJob(tenant.NewContext(ctx, job.tenant), job.id, nil); err != nil {
+				zap.S().Errorf("airdropTask: run job(%s) err: %v", job.id, err)
+			}
+		case <-t.C:
+			s.resumeJobs(ctx)
+		}
+	}
+}
+
+func (s *AirdropService) resumeJobs(ctx context.Context) {
+	tenant.Each(ctx, s.cfg, func(ctx context.Context, t *configs.TenantConfig) {
+		ids, err := s.airdrop.QueryUnfinishedAirdropJobs(ctx)
+		if err != nil {
+			zap.S().Errorf("resumeJobs: query unfinished jobs tenant: %s err: %v", t.ID, err)
+		}
+		for i := range ids {
+			zap.S().Infof("resumeJobs: resume job %s", ids[i])
+			err = s.RunJob(ctx, ids[i], nil)
+			if errors.Is(err, bizerr.ErrAirdropJobRunning) {
+				continue
+			}
+			if err != nil {
+				zap.S().Errorf("resumeJobs: resume job(%s) err: %v", ids[i], err)
+			}
+		}
+	})
+}
+
+// CreateJob parses a CSV of account_id,points,reason and stores it as a new
+// job. The header row is optional.
+func (s *AirdropService) CreateJob(ctx context.Context, req *CreateAirdropJobRequest, r io.Reader) (*AirdropJobResponse, error) {
+	items, err := parseAirdropCSV(r)
+	if err != nil {
+		return nil, fmt.Errorf("CreateJob: parse csv err: %w", err)
+	}
+
+	job := &biz.AirdropJobRequest{
+		JobID:    uuid.NewString(),
+		Operator: req.Operator,
+		FileName: req.FileName,
+		Items:    items,
+	}
+	if err = s.airdrop.CreateAirdropJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("CreateJob: create job err: %w", err)
+	}
+	return s.QueryJob(ctx, job.JobID)
+}
+
+// StartJob queues the job for the background worker, which runs it in the
+// context's tenant. It never blocks: when the queue is full it returns false
+// and the job, still pending, is resumed by the worker's next sweep.
+func (s *AirdropService) StartJob(ctx context.Context, jobID string) bool {
+	id, _ := tenant.FromContext(ctx)
+	select {
+	case s.jobs <- queuedJob{tenant: id, id: jobID}:
+		return true
+	default:
+		return false
+	}
+}
+
+func jobClaimKey(jobID string) string {
+	return "starland-account:airdrop:" + jobID
+}
+
+func (s *AirdropService) QueryJob(ctx context.Context, jobID string) (*AirdropJobResponse, error) {
+	res, err := s.airdrop.QueryAirdropJob(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("QueryJob: query job(%s) err: %w", jobID, err)
+	}
+	return makeAirdropJob(res), nil
+}
+
+// RunJob applies every pending item of the job. It is safe to call again after
+// an interruption: items already applied are skipped. The job is claimed for
+// the run, failing with ErrAirdropJobRunning while another instance, or the
+// airdrop command, holds it.
+func (s *AirdropService) RunJob(ctx context.Context, jobID string, progress func(*AirdropJobResponse)) error {
+	claim, err := s.leader.AcquireLease(ctx, jobClaimKey(jobID), s.owner, jobClaimTTL)
+	if err != nil {
+		return fmt.Errorf("RunJob: claim job err: %w", err)
+	}
+	if claim == nil {
+		return fmt.Errorf("RunJob: job %s: %w", jobID, bizerr.ErrAirdropJobRunning)
+	}
+	defer func() {
+		if err := s.leader.ReleaseLease(context.WithoutCancel(ctx), claim); err != nil {
+			zap.S().Errorf("RunJob: release job(%s) err: %v", jobID, err)
+		}
+	}()
+
+	if err := s.airdrop.UpdateAirdropJobState(ctx, jobID, biz.AirdropJobRunning); err != nil {
+		return fmt.Errorf("RunJob: mark running err: %w", err)
+	}
+	for {
+		items, err := s.airdrop.QueryPendingAirdropItems(ctx, jobID, airdropBatchSize)
+		if err != nil {
+			return fmt.Errorf("RunJob: query pending items err: %w", err)
+		}
+		if len(items) == 0 {
+			break
+		}
+		for i := range items {
+			if err = s.airdrop.ApplyAirdropItem(ctx, jobID, items[i]); err != nil {
+				return fmt.Errorf("RunJob: apply item err: %w", err)
+			}
+		}
+		if progress != nil {
+			if job, err := s.QueryJob(ctx, jobID); err == nil {
+				progress(job)
+			}
+		}
+		ok, err := s.leader.RenewLease(ctx, claim, jobClaimTTL)
+		if err != nil {
+			return fmt.Errorf("RunJob: renew claim err: %w", err)
+		}
+		if !ok {
+			return fmt.Errorf("RunJob: claim of job %s lost: %w", jobID, bizerr.ErrAirdropJobRunning)
+		}
+	}
+	if err := s.airdrop.UpdateAirdropJobState(ctx, jobID, biz.AirdropJobFinished); err != nil {
+		return fmt.Errorf("RunJob: mark finished err: %w", err)
+	}
+	zap.S().Infof("RunJob: job %s finished", jobID)
+	return nil
+}
+
+// WriteReport writes the per-row outcome of the job as CSV.
+func (s *AirdropService) WriteReport(ctx context.Context, jobID string, w io.Writer) error {
+	if _, err := s.airdrop.QueryAirdropJob(ctx, jobID); err != nil {
+		return fmt.Errorf("WriteReport: query job(%s) err: %w", jobID, err)
+	}
+	items, err := s.airdrop.QueryAirdropItems(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("WriteReport: query items err: %w", err)
+	}
+
+	cw := csv.NewWriter(w)
+	if err = cw.Write([]string{"line", "account_id", "points", "reason", "state", "error"}); err != nil {
+		return fmt.Errorf("WriteReport: write header err: %w", err)
+	}
+	for i := range items {
+		record := []string{
+			strconv.Itoa(items[i].Line),
+			items[i].AccountID,
+			strconv.Itoa(items[i].Points),
+			items[i].Reason,
+			itemStates[items[i].State],
+			items[i].Err,
+		}
+		if err = cw.Write(record); err != nil {
+			return fmt.Errorf("WriteReport: write line(%d) err: %w", items[i].Line, err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func parseAirdropCSV(r io.Reader) ([]*biz.AirdropItemRequest, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var items []*biz.AirdropItemRequest
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, bizerr.NewBizError(err.Error(), bizerr.BadRequest)
+		}
+		if line == 1 && len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), "account_id") {
+			continue
+		}
+		if len(items) >= airdropMaxRows {
+			return nil, bizerr.NewBizError(fmt.Sprintf("too many rows, max %d", airdropMaxRows), bizerr.BadRequest)
+		}
+
+		item := &biz.AirdropItemRequest{Line: line, State: biz.AirdropItemPending}
+		items = append(items, item)
+		if len(record) < 2 || len(record) > 3 {
+			item.State, item.Err = biz.AirdropItemFailed, "expect account_id,points,reason"
+			continue
+		}
+		item.AccountID = strings.TrimSpace(record[0])
+		if len(record) == 3 {
+			item.Reason = strings.TrimSpace(record[2])
+		}
+		if item.Points, err = strconv.Atoi(strings.TrimSpace(record[1])); err != nil {
+			item.State, item.Err = biz.AirdropItemFailed, "points is not a number"
+		}
+	}
+	if len(items) == 0 {
+		return nil, bizerr.NewBizError("csv is empty", bizerr.BadRequest)
+	}
+	return items, nil
+}
+
+func makeAirdropJob(req *biz.AirdropJobResponse) *AirdropJobResponse {
+	return &AirdropJobResponse{
+		JobID:     req.JobID,
+		Operator:  req.Operator,
+		FileName:  req.FileName,
+		State:     jobStates[req.State],
+		Total:     req.Total,
+		Pending:   req.Pending,
+		Succeeded: req.Succeeded,
+		Failed:    req.Failed,
+		CreateAt:  req.CreateAt,
+		UpdateAt:  req.UpdateAt,
+	}
+}
@@ -0,0 +1,26 @@
+package airdrop
+
+import (
+	"context"
+	"errors"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/data/memory"
+	"starland-account/internal/pkg/bizerr"
+	"testing"
+	"time"
+)
+
+func TestRunJobClaimed(t *testing.T) {
+	leases := memory.NewLeaseRepo()
+	s := NewAirdropService(&configs.Config{}, nil, biz.NewLeaderUsecase(leases))
+	ctx := context.Background()
+
+	// another instance runs the job
+	if claim, err := leases.AcquireLease(ctx, jobClaimKey("j1"), "other", time.Minute); err != nil || claim == nil {
+		t.Fatalf("AcquireLease = %v, %v", claim, err)
+	}
+	if err := s.RunJob(ctx, "j1", nil); !errors.Is(err, bizerr.ErrAirdropJobRunning) {
+		t.Errorf("RunJob of a claimed job = %v, want AIRDROP_JOB_RUNNING", err)
+	}
+}
@@ -0,0 +1,62 @@
+package airdrop
+
+import (
+	"fmt"
+	"os"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/worker"
+	"time"
+
+	"github.com/google/wire"
+)
+
+var ProviderSet = wire.NewSet(NewAirdropService)
+
+type AirdropService struct {
+	cfg     *configs.Config
+	airdrop *biz.AirdropUsecase
+	leader  *biz.LeaderUsecase
+	// owner names this instance in the job claims
+	owner string
+	jobs  chan queuedJob
+}
+
+type queuedJob struct {
+	tenant string
+	id     string
+}
+
+func NewAirdropService(cfg *configs.Config, airdrop *biz.AirdropUsecase, leader *biz.LeaderUsecase) *AirdropService {
+	host, _ := os.Hostname()
+	s := &AirdropService{cfg: cfg,
+		airdrop: airdrop,
+		leader:  leader,
+		owner:   fmt.Sprintf("%s-%d", host, os.Getpid()),
+		jobs:    make(chan queuedJob, 64)}
+	return s
+}
+
+// Workers runs the started jobs one at a time, resuming the interrupted ones
+// first. Every instance runs it; a job is claimed by the one running it.
+func (s *AirdropService) Workers() []worker.Worker {
+	return []worker.Worker{{Name: "airdrop.run", Run: s.airdropTask}}
+}
+
+type AirdropJobResponse struct {
+	JobID     string    `json:"job_id"`
+	Operator  string    `json:"operator"`
+	FileName  string    `json:"file_name"`
+	State     string    `json:"state"`
+	Total     int       `json:"total"`
+	Pending   int       `json:"pending"`
+	Succeeded int       `json:"succeeded"`
+	Failed    int       `json:"failed"`
+	CreateAt  time.Time `json:"create_at"`
+	UpdateAt  time.Time `json:"update_at"`
+}
+
+type CreateAirdropJobRequest struct {
+	Operator string
+	FileName string
+}
@@ -0,0 +1,219 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"starland-account/internal/pkg/tenant"
+	"starland-account/internal/pkg/worker"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRollupInterval = 10 * time.Minute
+	defaultBackfillDays   = 30
+	defaultStatRangeDays  = 30
+	maxStatRangeDays      = 366
+)
+
+func (s *AnalyticsService) location() *time.Location {
+	if s.cfg.Analytics == nil || s.cfg.Analytics.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.cfg.Analytics.Timezone)
+	if err != nil {
+		zap.S().Errorf("location: load %s err: %v", s.cfg.Analytics.Timezone, err)
+		return time.UTC
+	}
+	return loc
+}
+
+// rollupTask fills in missing days once, then keeps rebuilding yesterday and
+// today so late logs and claims are picked up.
+func (s *AnalyticsService) rollupTask(ctx context.Context) {
+	interval, backfillDays := defaultRollupInterval, defaultBackfillDays
+	if ac := s.cfg.Analytics; ac != nil {
+		if ac.Interval > 0 {
+			interval = ac.Interval * time.Second
+		}
+		if ac.BackfillDays > 0 {
+			backfillDays = ac.BackfillDays
+		}
+	}
+
+	tenant.Each(ctx, s.cfg, func(ctx context.Context, _ *configs.TenantConfig) {
+		s.backfill(ctx, backfillDays)
+	})
+
+	worker.Every(interval, true, func(ctx context.Context) {
+		tenant.Each(ctx, s.cfg, func(ctx context.Context, _ *configs.TenantConfig) {
+			s.rollupRecent(ctx)
+		})
+	})(ctx)
+}
+
+func (s *AnalyticsService) backfill(ctx context.Context, backfillDays int) {
+	now := time.Now().In(s.location())
+	from := now.AddDate(0, 0, -backfillDays)
+	if latest, err := s.analytics.LatestRollupDay(ctx); err != nil {
+		zap.S().Errorf("rollupTask: query latest day err: %v", err)
+	} else if latest != "" {
+		if t, err := time.ParseInLocation(biz.StatDayLayout, latest, now.Location()); err == nil {
+			from = t
+		}
+	}
+	for d := from; d.Before(now.AddDate(0, 0, -1)) && ctx.Err() == nil; d = d.AddDate(0, 0, 1) {
+		if err := s.analytics.RollupDay(ctx, d); err != nil {
+			zap.S().Errorf("rollupTask: backfill %s err: %v", d.Format(biz.StatDayLayout), err)
+		}
+	}
+}
+
+func (s *AnalyticsService) rollupRecent(ctx context.Context) {
+	now := time.Now().In(s.location())
+	for _, d := range []time.Time{now.AddDate(0, 0, -1), now} {
+		if err := s.analytics.RollupDay(ctx, d); err != nil {
+			zap.S().Errorf("rollupRecent: rollup %s err: %v", d.Format(biz.StatDayLayout), err)
+		}
+	}
+	if err := s.refreshTodayGauges(ctx, now.Format(biz.StatDayLayout)); err != nil {
+		zap.S().Errorf("rollupRecent: refresh gauges err: %v", err)
+	}
+}
+
+func (s *AnalyticsService) refreshTodayGauges(ctx context.Context, today string) error {
+	activities, err := s.QueryActivityStats(ctx, &StatRangeRequest{From: today, To: today})
+	if err != nil {
+		return err
+	}
+	totals, err := s.QueryDailyTotals(ctx, &StatRangeRequest{From: today, To: today})
+	if err != nil {
+		return err
+	}
+	var total *DailyTotalResponse
+	if len(totals) > 0 {
+		total = totals[0]
+	}
+	id, _ := tenant.FromContext(ctx)
+	setTodayGauges(id, activities, total)
+	return nil
+}
+
+// Rollup rebuilds the stats of the given days, for backfills after fixes to
+// the logs.
+func (s *AnalyticsService) Rollup(ctx context.Context, req *StatRangeRequest) error {
+	from, to, err := s.statRange(req)
+	if err != nil {
+		return fmt.Errorf("Rollup: %w", err)
+	}
+	loc := s.location()
+	start, _ := time.ParseInLocation(biz.StatDayLayout, from, loc)
+	end, _ := time.ParseInLocation(biz.StatDayLayout, to, loc)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if err = s.analytics.RollupDay(ctx, d); err != nil {
+			return fmt.Errorf("Rollup: rollup %s err: %w", d.Format(biz.StatDayLayout), err)
+		}
+	}
+	return nil
+}
+
+func (s *AnalyticsService) QueryActivityStats(ctx context.Context, req *StatRangeRequest) ([]*DailyActivityStatResponse, error) {
+	from, to, err := s.statRange(req)
+	if err != nil {
+		return nil, fmt.Errorf("QueryActivityStats: %w", err)
+	}
+	stats, err := s.analytics.QueryActivityStats(ctx, from, to, req.ActivityCode)
+	if err != nil {
+		return nil, fmt.Errorf("QueryActivityStats: query err: %w", err)
+	}
+	res := make([]*DailyActivityStatResponse, len(stats))
+	for i := range stats {
+		res[i] = &DailyActivityStatResponse{
+			Day:          stats[i].Day,
+			ActivityCode: stats[i].ActivityCode,
+			ActivityName: stats[i].ActivityName,
+			Plays:        stats[i].Plays,
+			UniqueUsers:  stats[i].UniqueUsers,
+			PointsIssued: stats[i].PointsIssued,
+		}
+	}
+	return res, nil
+}
+
+func (s *AnalyticsService) QueryAccountStats(ctx context.Context, req *StatRangeRequest) ([]*DailyAccountStatResponse, error) {
+	from, to, err := s.statRange(req)
+	if err != nil {
+		return nil, fmt.Errorf("QueryAccountStats: %w", err)
+	}
+	stats, err := s.analytics.QueryAccountStats(ctx, req.AccountID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("QueryAccountStats: query err: %w", err)
+	}
+	res := make([]*DailyAccountStatResponse, len(stats))
+	for i := range stats {
+		res[i] = &DailyAccountStatResponse{
+			Day:           stats[i].Day,
+			AccountID:     stats[i].AccountID,
+			Plays:         stats[i].Plays,
+			PointsIssued:  stats[i].PointsIssued,
+			Claims:        stats[i].Claims,
+			PointsClaimed: stats[i].PointsClaimed,
+		}
+	}
+	return res, nil
+}
+
+func (s *AnalyticsService) QueryDailyTotals(ctx context.Context, req *StatRangeRequest) ([]*DailyTotalResponse, error) {
+	from, to, err := s.statRange(req)
+	if err != nil {
+		return nil, fmt.Errorf("QueryDailyTotals: %w", err)
+	}
+	totals, err := s.analytics.QueryDailyTotals(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("QueryDailyTotals: query err: %w", err)
+	}
+	res := make([]*DailyTotalResponse, len(totals))
+	for i := range totals {
+		res[i] = &DailyTotalResponse{
+			Day:           totals[i].Day,
+			Plays:         totals[i].Plays,
+			UniqueUsers:   totals[i].UniqueUsers,
+			PointsIssued:  totals[i].PointsIssued,
+			PointsClaimed: totals[i].PointsClaimed,
+		}
+	}
+	return res, nil
+}
+
+// statRange validates the requested days, defaulting to the last 30 days.
+func (s *AnalyticsService) statRange(req *StatRangeRequest) (string, string, error) {
+	loc := s.location()
+	now := time.Now().In(loc)
+	to := now
+	if req.To != "" {
+		t, err := time.ParseInLocation(biz.StatDayLayout, req.To, loc)
+		if err != nil {
+			return "", "", bizerr.ErrBadRequest.Errorf("invalid to: %s", req.To)
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -defaultStatRangeDays+1)
+	if req.From != "" {
+		t, err := time.ParseInLocation(biz.StatDayLayout, req.From, loc)
+		if err != nil {
+			return "", "", bizerr.ErrBadRequest.Errorf("invalid from: %s", req.From)
+		}
+		from = t
+	}
+	if from.After(to) {
+		return "", "", bizerr.ErrBadRequest.Errorf("from is after to")
+	}
+	if to.Sub(from) > maxStatRangeDays*24*time.Hour {
+		return "", "", bizerr.ErrBadRequest.Errorf("range is longer than %d days", maxStatRangeDays)
+	}
+	return from.Format(biz.StatDayLayout), to.Format(biz.StatDayLayout), nil
+}
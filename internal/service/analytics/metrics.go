@@ -0,0 +1,47 @@
+package analytics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Gauges for the current day, refreshed after each rollup. They are served by
+// the /metrics endpoint through the default registry.
+var (
+	todayPlays = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starland_account_today_plays",
+		Help: "Activity plays logged today.",
+	}, []string{"tenant", "activity_code"})
+	todayUniqueUsers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starland_account_today_unique_users",
+		Help: "Distinct accounts that played an activity today.",
+	}, []string{"tenant", "activity_code"})
+	todayPointsIssued = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starland_account_today_points_issued",
+		Help: "Points issued today.",
+	}, []string{"tenant", "activity_code"})
+	todayPointsClaimed = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starland_account_today_points_claimed",
+		Help: "Points claimed today.",
+	}, []string{"tenant"})
+)
+
+func setTodayGauges(tenantID string, activities []*DailyActivityStatResponse, total *DailyTotalResponse) {
+	labels := prometheus.Labels{"tenant": tenantID}
+	todayPlays.DeletePartialMatch(labels)
+	todayUniqueUsers.DeletePartialMatch(labels)
+	todayPointsIssued.DeletePartialMatch(labels)
+	for _, a := range activities {
+		code := strconv.Itoa(a.ActivityCode)
+		todayPlays.WithLabelValues(tenantID, code).Set(float64(a.Plays))
+		todayUniqueUsers.WithLabelValues(tenantID, code).Set(float64(a.UniqueUsers))
+		todayPointsIssued.WithLabelValues(tenantID, code).Set(float64(a.PointsIssued))
+	}
+	var claimed int64
+	if total != nil {
+		claimed = total.PointsClaimed
+	}
+	todayPointsClaimed.WithLabelValues(tenantID).Set(float64(claimed))
+}
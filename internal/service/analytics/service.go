@@ -0,0 +1,60 @@
+package analytics
+
+import (
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/worker"
+
+	"github.com/google/wire"
+)
+
+var ProviderSet = wire.NewSet(NewAnalyticsService)
+
+type AnalyticsService struct {
+	cfg       *configs.Config
+	analytics *biz.AnalyticsUsecase
+}
+
+func NewAnalyticsService(cfg *configs.Config, analytics *biz.AnalyticsUsecase) *AnalyticsService {
+	return &AnalyticsService{cfg: cfg, analytics: analytics}
+}
+
+// Workers keeps the daily stats rolled up, on the leading instance only.
+func (s *AnalyticsService) Workers() []worker.Worker {
+	return []worker.Worker{{Name: "analytics.rollup", Run: s.rollupTask, Leader: true}}
+}
+
+type DailyActivityStatResponse struct {
+	Day          string `json:"day"`
+	ActivityCode int    `json:"activity_code"`
+	ActivityName string `json:"activity_name"`
+	Plays        int64  `json:"plays"`
+	UniqueUsers  int64  `json:"unique_users"`
+	PointsIssued int64  `json:"points_issued"`
+}
+
+type DailyAccountStatResponse struct {
+	Day           string `json:"day"`
+	AccountID     string `json:"account_id"`
+	Plays         int64  `json:"plays"`
+	PointsIssued  int64  `json:"points_issued"`
+	Claims        int64  `json:"claims"`
+	PointsClaimed int64  `json:"points_claimed"`
+}
+
+type DailyTotalResponse struct {
+	Day           string `json:"day"`
+	Plays         int64  `json:"plays"`
+	UniqueUsers   int64  `json:"unique_users"`
+	PointsIssued  int64  `json:"points_issued"`
+	PointsClaimed int64  `json:"points_claimed"`
+}
+
+// StatRangeRequest selects days From through To inclusive, as YYYY-MM-DD;
+// empty bounds default to the last 30 days.
+type StatRangeRequest struct {
+	From         string
+	To           string
+	ActivityCode *int
+	AccountID    string
+}
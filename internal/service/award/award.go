@@ -0,0 +1,125 @@
+package award
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMaxBatch   = 100
+	maxExternalRefLen = 128
+)
+
+// Award applies a batch of awards from a partner client. Items are answered in
+// request order with their own status; only a malformed batch fails as a
+// whole.
+func (s *AwardService) Award(ctx context.Context, req *AwardBatchRequest) (*AwardBatchResponse, error) {
+	client := s.client(req.ClientID)
+	if client == nil {
+		return nil, fmt.Errorf("Award: %w", bizerr.ErrBadRequest.Errorf("unknown client %s", req.ClientID))
+	}
+	maxBatch := defaultMaxBatch
+	if s.cfg.Award.MaxBatch > 0 {
+		maxBatch = s.cfg.Award.MaxBatch
+	}
+	if len(req.Items) == 0 || len(req.Items) > maxBatch {
+		return nil, fmt.Errorf("Award: %w", bizerr.ErrBadRequest.Errorf("batch must have 1 to %d items", maxBatch))
+	}
+
+	res := &AwardBatchResponse{Items: make([]*AwardItemResponse, len(req.Items))}
+	seen := make(map[string]bool, len(req.Items))
+	for i, item := range req.Items {
+		r := s.awardItem(ctx, client, item, seen)
+		switch r.Status {
+		case biz.AwardStatusAwarded:
+			res.Awarded++
+		case biz.AwardStatusDuplicate:
+			res.Duplicates++
+		default:
+			res.Rejected++
+		}
+		res.Items[i] = r
+	}
+	return res, nil
+}
+
+func (s *AwardService) awardItem(ctx context.Context, client *configs.AwardClientConfig, item *AwardItem, seen map[string]bool) *AwardItemResponse {
+	res := &AwardItemResponse{ExternalRef: item.ExternalRef, AccountID: item.AccountID, Points: item.Points}
+	if err := validateAwardItem(client, item, seen); err != nil {
+		return rejectAward(res, err)
+	}
+	seen[item.ExternalRef] = true
+	if err := s.account.CheckAccountState(ctx, item.AccountID); err != nil {
+		return rejectAward(res, err)
+	}
+
+	activityCode, activityName := client.ActivityCode, client.ActivityName
+	if item.ActivityCode != 0 {
+		activityCode = item.ActivityCode
+	}
+	if item.Reason != "" {
+		activityName = item.Reason
+	}
+	r := s.award.Award(ctx, &biz.AwardRequest{
+		ClientID:     client.ID,
+		ExternalRef:  item.ExternalRef,
+		AccountID:    item.AccountID,
+		ActivityCode: activityCode,
+		ActivityName: activityName,
+		Points:       item.Points,
+	}, client.DailyQuota)
+	if r.Err != nil {
+		return rejectAward(res, r.Err)
+	}
+	res.Status, res.ActivityLogID = r.Status, r.Award.ActivityLogID
+	return res
+}
+
+func validateAwardItem(client *configs.AwardClientConfig, item *AwardItem, seen map[string]bool) error {
+	switch {
+	case item.ExternalRef == "" || len(item.ExternalRef) > maxExternalRefLen:
+		return bizerr.ErrBadRequest.Errorf("external_ref must have 1 to %d characters", maxExternalRefLen)
+	case seen[item.ExternalRef]:
+		return bizerr.ErrBadRequest.Errorf("external_ref repeated in batch")
+	case item.AccountID == "":
+		return bizerr.ErrBadRequest.Errorf("account_id is required")
+	case item.Points <= 0:
+		return bizerr.ErrBadRequest.Errorf("points must be positive")
+	case client.MaxPoints > 0 && item.Points > client.MaxPoints:
+		return bizerr.ErrBadRequest.Errorf("points must be at most %d", client.MaxPoints)
+	}
+	return nil
+}
+
+// rejectAward fills in the error as the HTTP error handler would, with its
+// reason as code; internal errors are logged and reported without their
+// cause.
+func rejectAward(res *AwardItemResponse, err error) *AwardItemResponse {
+	res.Status = biz.AwardStatusRejected
+	var e *bizerr.BizError
+	if !errors.As(err, &e) || e.Code() == bizerr.InternalError {
+		zap.S().Errorf("Award: %s err: %v", res.ExternalRef, err)
+		e = bizerr.ErrInternalError
+	}
+	res.Code = e.Reason()
+	res.Msg = e.ClientMessage()
+	return res
+}
+
+func (s *AwardService) client(id string) *configs.AwardClientConfig {
+	if s.cfg.Award == nil {
+		return nil
+	}
+	for i := range s.cfg.Award.Clients {
+		if s.cfg.Award.Clients[i].ID == id {
+			return &s.cfg.Award.Clients[i]
+		}
+	}
+	return nil
+}
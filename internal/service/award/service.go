@@ -0,0 +1,52 @@
+package award
+
+import (
+	"starland-account/configs"
+	"starland-account/internal/biz"
+
+	"github.com/google/wire"
+)
+
+var ProviderSet = wire.NewSet(NewAwardService)
+
+type AwardService struct {
+	cfg     *configs.Config
+	account *biz.AccountUsecase
+	award   *biz.AwardUsecase
+}
+
+func NewAwardService(cfg *configs.Config, account *biz.AccountUsecase, award *biz.AwardUsecase) *AwardService {
+	return &AwardService{cfg: cfg, account: account, award: award}
+}
+
+type AwardItem struct {
+	ExternalRef  string `json:"external_ref"`
+	AccountID    string `json:"account_id"`
+	Points       int    `json:"points"`
+	ActivityCode int    `json:"activity_code"`
+	Reason       string `json:"reason"`
+}
+
+// AwardBatchRequest is applied item by item; a failed item doesn't stop the
+// others.
+type AwardBatchRequest struct {
+	ClientID string
+	Items    []*AwardItem
+}
+
+type AwardItemResponse struct {
+	ExternalRef   string `json:"external_ref"`
+	AccountID     string `json:"account_id"`
+	Points        int    `json:"points"`
+	Status        string `json:"status"`
+	ActivityLogID uint   `json:"activity_log_id,omitempty"`
+	Code          string `json:"code,omitempty"`
+	Msg           string `json:"msg,omitempty"`
+}
+
+type AwardBatchResponse struct {
+	Awarded    int                  `json:"awarded"`
+	Duplicates int                  `json:"duplicates"`
+	Rejected   int                  `json:"rejected"`
+	Items      []*AwardItemResponse `json:"items"`
+}
@@ -0,0 +1,67 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultBatchSize     = 100
+	defaultRelayInterval = time.Second
+	defaultRetentionDays = 7
+	maxRetryBackoff      = 5 * time.Minute
+)
+
+// relayEvents relays the outbox, draining the backlog before waiting for the
+// next tick.
+func (s *EventService) relayEvents(ctx context.Context) {
+	batch := defaultBatchSize
+	if s.cfg.Event != nil && s.cfg.Event.BatchSize > 0 {
+		batch = s.cfg.Event.BatchSize
+	}
+	for ctx.Err() == nil {
+		n, err := s.outbox.RelayEvents(ctx, batch, retryBackoff)
+		if err != nil {
+			zap.S().Errorf("relayEvents: relay events err: %v", err)
+		}
+		if err != nil || n < batch {
+			return
+		}
+	}
+}
+
+func (s *EventService) relayInterval() time.Duration {
+	if s.cfg.Event != nil && s.cfg.Event.Interval > 0 {
+		return s.cfg.Event.Interval * time.Second
+	}
+	return defaultRelayInterval
+}
+
+func (s *EventService) purgeEvents(ctx context.Context) {
+	days := defaultRetentionDays
+	if s.cfg.Event != nil && s.cfg.Event.RetentionDays > 0 {
+		days = s.cfg.Event.RetentionDays
+	}
+	n, err := s.outbox.PurgePublishedEvents(ctx, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		zap.S().Errorf("purgeEvents: purge events err: %v", err)
+		return
+	}
+	if n > 0 {
+		zap.S().Infof("purgeEvents: purged %d events", n)
+	}
+}
+
+// retryBackoff doubles from one second per attempt, capped at five minutes.
+func retryBackoff(attempt int) time.Duration {
+	if attempt > 9 {
+		return maxRetryBackoff
+	}
+	d := time.Second << uint(attempt-1)
+	if d > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return d
+}
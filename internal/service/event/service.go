@@ -0,0 +1,32 @@
+package event
+
+import (
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/worker"
+	"time"
+
+	"github.com/google/wire"
+)
+
+var ProviderSet = wire.NewSet(NewEventService)
+
+// EventService relays domain events from the outbox table to the event
+// stream.
+type EventService struct {
+	cfg    *configs.Config
+	outbox *biz.OutboxUsecase
+}
+
+func NewEventService(cfg *configs.Config, outbox *biz.OutboxUsecase) *EventService {
+	return &EventService{cfg: cfg, outbox: outbox}
+}
+
+// Workers relays the outbox and purges the events published long ago, on the
+// leading instance only.
+func (s *EventService) Workers() []worker.Worker {
+	return []worker.Worker{
+		{Name: "event.relay", Run: worker.Every(s.relayInterval(), false, s.relayEvents), Leader: true},
+		{Name: "event.purge", Run: worker.Every(time.Hour, false, s.purgeEvents), Leader: true},
+	}
+}
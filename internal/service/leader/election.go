@@ -0,0 +1,109 @@
+package leader
+
+import (
+	"context"
+	"starland-account/internal/biz"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// releaseTimeout bounds giving the lease up on the way out, after the
+// campaign's context is done.
+const releaseTimeout = time.Second
+
+// campaign tries to take the lease every third of its ttl while another
+// instance holds it, and renews it as often while leading. It gives the lease
+// up when ctx is done, so another instance takes over without waiting for it
+// to expire.
+func (s *LeaderService) campaign(ctx context.Context) {
+	interval := s.ttl() / 3
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		s.elect(ctx, interval)
+		select {
+		case <-ctx.Done():
+			s.resign()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (s *LeaderService) elect(ctx context.Context, interval time.Duration) {
+	s.mu.Lock()
+	t := s.term
+	s.mu.Unlock()
+
+	if t == nil {
+		lease, err := s.leader.AcquireLease(ctx, s.key(), s.owner, s.ttl())
+		if err != nil {
+			zap.S().Errorf("elect: acquire lease err: %v", err)
+			return
+		}
+		if lease != nil {
+			s.begin(lease)
+		}
+		return
+	}
+
+	ok, err := s.leader.RenewLease(ctx, t.lease, s.ttl())
+	if err != nil {
+		zap.S().Errorf("elect: renew lease err: %v", err)
+		// step down before the lease may run out and another instance take
+		// over, one renewal early so the workers have time to stop
+		if time.Since(t.renewed) >= s.ttl()-interval {
+			s.end(t, "lease not renewed in time")
+		}
+		return
+	}
+	if !ok {
+		s.end(t, "lease lost")
+		return
+	}
+	s.mu.Lock()
+	t.renewed = time.Now()
+	s.mu.Unlock()
+}
+
+func (s *LeaderService) begin(lease *biz.Lease) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mu.Lock()
+	s.term = &term{lease: lease, renewed: time.Now(), ctx: ctx, cancel: cancel}
+	close(s.changed)
+	s.changed = make(chan struct{})
+	s.mu.Unlock()
+
+	setLeading(s.owner, true, lease.Token)
+	leaderTerms.WithLabelValues(s.owner).Inc()
+	zap.S().Infof("begin: %s leads with token %d", s.owner, lease.Token)
+}
+
+func (s *LeaderService) end(t *term, reason string) {
+	s.mu.Lock()
+	if s.term == t {
+		s.term = nil
+	}
+	s.mu.Unlock()
+	t.cancel()
+
+	setLeading(s.owner, false, t.lease.Token)
+	zap.S().Infof("end: %s stops leading with token %d: %s", s.owner, t.lease.Token, reason)
+}
+
+func (s *LeaderService) resign() {
+	s.mu.Lock()
+	t := s.term
+	s.mu.Unlock()
+	if t == nil {
+		return
+	}
+	s.end(t, "shutting down")
+
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	if err := s.leader.ReleaseLease(ctx, t.lease); err != nil {
+		zap.S().Errorf("resign: release lease err: %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package leader
+
+import (
+	"context"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/data/memory"
+	"testing"
+	"time"
+)
+
+const testKey = "test:leader"
+
+func newTestService(t *testing.T, repo *memory.LeaseRepo, owner string) (*LeaderService, *biz.LeaderUsecase) {
+	t.Helper()
+	cfg := &configs.Config{Leader: &configs.LeaderConfig{Enabled: true, Key: testKey, TTL: 1}}
+	uc := biz.NewLeaderUsecase(repo)
+	s := NewLeaderService(cfg, uc)
+	s.owner = owner
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.campaign(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return s, uc
+}
+
+// lead waits for s to lead, failing the test after timeout.
+func lead(t *testing.T, s *LeaderService, timeout time.Duration) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	t.Cleanup(cancel)
+	term, release, err := s.Lead(ctx)
+	if err != nil {
+		t.Fatalf("%s does not lead: %v", s.owner, err)
+	}
+	t.Cleanup(release)
+	return term
+}
+
+func TestElection(t *testing.T) {
+	repo := memory.NewLeaseRepo()
+	a, _ := newTestService(t, repo, "a")
+	term := lead(t, a, time.Second)
+	b, _ := newTestService(t, repo, "b")
+
+	// b waits while a renews its lease
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	if _, _, err := b.Lead(ctx); err == nil {
+		t.Fatal("b leads while a holds the lease")
+	}
+
+	// a's lease runs out without it noticing: it steps down at its next
+	// renewal and b takes over with a newer token
+	repo.Expire(testKey)
+	next := lead(t, b, 2*time.Second)
+	select {
+	case <-term.Done():
+	case <-time.After(time.Second):
+		t.Fatal("a's term is not canceled")
+	}
+	if got, prev := biz.LeaseFromContext(next).Token, biz.LeaseFromContext(term).Token; got <= prev {
+		t.Errorf("token %d after %d, want it to grow", got, prev)
+	}
+}
+
+func TestResign(t *testing.T) {
+	repo := memory.NewLeaseRepo()
+	uc := biz.NewLeaderUsecase(repo)
+	cfg := &configs.Config{Leader: &configs.LeaderConfig{Enabled: true, Key: testKey, TTL: 60}}
+	a := NewLeaderService(cfg, uc)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		a.campaign(ctx)
+		close(done)
+	}()
+	term := lead(t, a, time.Second)
+
+	cancel()
+	<-done
+	select {
+	case <-term.Done():
+	case <-time.After(time.Second):
+		t.Error("term not canceled on shutdown")
+	}
+	// the lease is given up rather than left to expire in a minute
+	if l, err := uc.QueryLease(context.Background(), testKey); err != nil || l != nil {
+		t.Errorf("lease after resign = %+v, %v, want none", l, err)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	s := NewLeaderService(&configs.Config{}, biz.NewLeaderUsecase(memory.NewLeaseRepo()))
+	if w := s.Workers(); len(w) != 0 {
+		t.Errorf("Workers = %v, want no election", w)
+	}
+	// every instance leads, with no lease to fence its writes
+	term := lead(t, s, 10*time.Millisecond)
+	if biz.LeaseFromContext(term) != nil {
+		t.Error("lease in a term without election")
+	}
+}
@@ -0,0 +1,32 @@
+package leader
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Every instance reports itself, so summing starland_account_leader over the
+// instances shows how many lead, and the one at 1 is the leader.
+var (
+	leading = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starland_account_leader",
+		Help: "1 while the instance leads, 0 otherwise.",
+	}, []string{"instance"})
+	leaderToken = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "starland_account_leader_token",
+		Help: "Fencing token of the instance's latest term.",
+	}, []string{"instance"})
+	leaderTerms = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "starland_account_leader_terms_total",
+		Help: "Terms the instance began leading.",
+	}, []string{"instance"})
+)
+
+func setLeading(instance string, lead bool, token int64) {
+	v := 0.0
+	if lead {
+		v = 1
+	}
+	leading.WithLabelValues(instance).Set(v)
+	leaderToken.WithLabelValues(instance).Set(float64(token))
+}
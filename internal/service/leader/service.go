@@ -0,0 +1,110 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/worker"
+	"sync"
+	"time"
+
+	"github.com/google/wire"
+)
+
+var ProviderSet = wire.NewSet(NewLeaderService)
+
+const (
+	defaultKey = "starland-account:leader"
+	defaultTTL = 15 * time.Second
+)
+
+// LeaderService elects one instance, holding a Redis lease, to run the
+// workers that want a leader. It implements worker.Elector.
+type LeaderService struct {
+	cfg    *configs.Config
+	leader *biz.LeaderUsecase
+	owner  string
+
+	mu sync.Mutex
+	// term is the current term, nil while another instance leads
+	term *term
+	// changed is closed, and replaced, when a term starts
+	changed chan struct{}
+}
+
+type term struct {
+	lease   *biz.Lease
+	renewed time.Time
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func NewLeaderService(cfg *configs.Config, leader *biz.LeaderUsecase) *LeaderService {
+	host, _ := os.Hostname()
+	s := &LeaderService{
+		cfg:     cfg,
+		leader:  leader,
+		owner:   fmt.Sprintf("%s-%d", host, os.Getpid()),
+		changed: make(chan struct{}),
+	}
+	setLeading(s.owner, !s.enabled(), 0)
+	return s
+}
+
+// Workers campaigns for the lease and renews it while leading; there is no
+// election when leader.enabled is off, every instance leads then.
+func (s *LeaderService) Workers() []worker.Worker {
+	if !s.enabled() {
+		return nil
+	}
+	return []worker.Worker{{Name: "leader.election", Run: s.campaign}}
+}
+
+// Owner names this instance in the lease and the metrics.
+func (s *LeaderService) Owner() string {
+	return s.owner
+}
+
+// Lead implements worker.Elector. The returned context carries the lease, for
+// the repos to fence the workers' writes with.
+func (s *LeaderService) Lead(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	if !s.enabled() {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, nil
+	}
+	for {
+		s.mu.Lock()
+		t, changed := s.term, s.changed
+		s.mu.Unlock()
+		if t != nil {
+			ctx, cancel := context.WithCancel(biz.NewLeaseContext(ctx, t.lease))
+			stop := context.AfterFunc(t.ctx, cancel)
+			return ctx, func() { stop(); cancel() }, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (s *LeaderService) enabled() bool {
+	return s.cfg.Leader != nil && s.cfg.Leader.Enabled
+}
+
+func (s *LeaderService) key() string {
+	if s.cfg.Leader != nil && s.cfg.Leader.Key != "" {
+		return s.cfg.Leader.Key
+	}
+	return defaultKey
+}
+
+func (s *LeaderService) ttl() time.Duration {
+	if s.cfg.Leader != nil && s.cfg.Leader.TTL > 0 {
+		return s.cfg.Leader.TTL * time.Second
+	}
+	return defaultTTL
+}
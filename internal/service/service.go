@@ -1,19 +1,61 @@
 package service
 
 import (
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/util"
+	"starland-account/internal/pkg/worker"
 	"starland-account/internal/service/account"
 	"starland-account/internal/service/activity"
+	"starland-account/internal/service/airdrop"
+	"starland-account/internal/service/analytics"
+	"starland-account/internal/service/award"
+	"starland-account/internal/service/event"
+	"starland-account/internal/service/leader"
+	"starland-account/internal/service/webhook"
 
 	"github.com/google/wire"
+	"go.uber.org/zap"
 )
 
 var ProviderSet = wire.NewSet(NewService)
 
 type Service struct {
-	Account  *account.AccountService
-	Activity *activity.ActivityService
+	Account   *account.AccountService
+	Activity  *activity.ActivityService
+	Airdrop   *airdrop.AirdropService
+	Analytics *analytics.AnalyticsService
+	Event     *event.EventService
+	Webhook   *webhook.WebhookService
+	Award     *award.AwardService
+	Leader    *leader.LeaderService
+
+	RateLimit *biz.RateLimitUsecase
+
+	// Workers runs the background tasks of the services once started.
+	Workers *worker.Runner
 }
 
-func NewService(account *account.AccountService, activity *activity.ActivityService) *Service {
-	return &Service{Account: account, Activity: activity}
+func NewService(cfg *configs.Config, account *account.AccountService, activity *activity.ActivityService,
+	airdrop *airdrop.AirdropService, analytics *analytics.AnalyticsService, event *event.EventService,
+	webhook *webhook.WebhookService, award *award.AwardService, leader *leader.LeaderService,
+	rateLimit *biz.RateLimitUsecase) *Service {
+	workers := worker.NewRunner(func(msg string) {
+		if cfg.FeiShuAlertURL == "" {
+			return
+		}
+		if err := util.SendAlertMsg(msg); err != nil {
+			zap.S().Errorf("NewService: send alert err: %v", err)
+		}
+	})
+	workers.Elect(leader)
+	workers.Add(leader.Workers()...)
+	workers.Add(account.Workers()...)
+	workers.Add(activity.Workers()...)
+	workers.Add(airdrop.Workers()...)
+	workers.Add(analytics.Workers()...)
+	workers.Add(event.Workers()...)
+	workers.Add(webhook.Workers()...)
+	return &Service{Account: account, Activity: activity, Airdrop: airdrop, Analytics: analytics, Event: event,
+		Webhook: webhook, Award: award, Leader: leader, RateLimit: rateLimit, Workers: workers}
 }
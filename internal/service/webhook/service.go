@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/worker"
+	"time"
+
+	"github.com/google/wire"
+)
+
+var ProviderSet = wire.NewSet(NewWebhookService)
+
+type WebhookService struct {
+	cfg     *configs.Config
+	webhook *biz.WebhookUsecase
+}
+
+func NewWebhookService(cfg *configs.Config, webhook *biz.WebhookUsecase) *WebhookService {
+	return &WebhookService{cfg: cfg, webhook: webhook}
+}
+
+// Workers turns events into deliveries and sends them.
+func (s *WebhookService) Workers() []worker.Worker {
+	return []worker.Worker{
+		{Name: "webhook.dispatch", Run: s.dispatchTask},
+		{Name: "webhook.deliver", Run: s.deliverTask},
+	}
+}
+
+type CreateSubscriptionRequest struct {
+	URL         string
+	Secret      string
+	Events      []string
+	Description string
+}
+
+// SubscriptionResponse only carries the secret when the subscription is
+// created.
+type SubscriptionResponse struct {
+	SubscriptionID      string    `json:"subscription_id"`
+	URL                 string    `json:"url"`
+	Secret              string    `json:"secret,omitempty"`
+	Events              []string  `json:"events"`
+	Description         string    `json:"description"`
+	Enabled             bool      `json:"enabled"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	DisabledReason      string    `json:"disabled_reason"`
+	CreateAt            time.Time `json:"create_at"`
+}
+
+type DeliveryResponse struct {
+	DeliveryID     string     `json:"delivery_id"`
+	SubscriptionID string     `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	State          string     `json:"state"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	LastStatus     int        `json:"last_status"`
+	LastErr        string     `json:"last_err"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreateAt       time.Time  `json:"create_at"`
+}
+
+type AttemptResponse struct {
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"`
+	Err        string    `json:"err"`
+	DurationMs int64     `json:"duration_ms"`
+	CreateAt   time.Time `json:"create_at"`
+}
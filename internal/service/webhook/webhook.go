@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/bizerr"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const defaultDeliveryLimit = 100
+
+var deliveryStates = map[int]string{
+	biz.WebhookDeliveryPending:   "pending",
+	biz.WebhookDeliverySucceeded: "succeeded",
+	biz.WebhookDeliveryDead:      "dead",
+}
+
+var eventTypes = map[string]bool{
+	biz.WebhookAllEvents:    true,
+	biz.EventAccountCreated: true,
+	biz.EventPointsEarned:   true,
+	biz.EventPointsClaimed:  true,
+	biz.EventAccountBanned:  true,
+}
+
+// CreateSubscription registers an endpoint; a secret is generated when none
+// is given and is only returned here.
+func (s *WebhookService) CreateSubscription(ctx context.Context, req *CreateSubscriptionRequest) (*SubscriptionResponse, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("CreateSubscription: %w", bizerr.ErrBadRequest.Errorf("invalid url"))
+	}
+	if len(req.Events) == 0 {
+		req.Events = []string{biz.WebhookAllEvents}
+	}
+	for _, e := range req.Events {
+		if !eventTypes[e] {
+			return nil, fmt.Errorf("CreateSubscription: %w", bizerr.ErrBadRequest.Errorf("unknown event %s", e))
+		}
+	}
+	secret := req.Secret
+	if secret == "" {
+		b := make([]byte, 32)
+		if _, err = rand.Read(b); err != nil {
+			return nil, fmt.Errorf("CreateSubscription: gen secret err: %w", err)
+		}
+		secret = "whsec_" + hex.EncodeToString(b)
+	}
+
+	sub := &biz.WebhookSubscription{
+		SubscriptionID: uuid.NewString(),
+		URL:            req.URL,
+		Secret:         secret,
+		Events:         req.Events,
+		Description:    req.Description,
+	}
+	if err = s.webhook.CreateSubscription(ctx, sub); err != nil {
+		return nil, fmt.Errorf("CreateSubscription: create err: %w", err)
+	}
+	res := makeSubscriptionResponse(sub)
+	res.Secret = secret
+	return res, nil
+}
+
+func (s *WebhookService) QuerySubscriptions(ctx context.Context) ([]*SubscriptionResponse, error) {
+	subs, err := s.webhook.QuerySubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("QuerySubscriptions: query err: %w", err)
+	}
+	res := make([]*SubscriptionResponse, len(subs))
+	for i := range subs {
+		res[i] = makeSubscriptionResponse(subs[i])
+	}
+	return res, nil
+}
+
+func (s *WebhookService) EnableSubscription(ctx context.Context, id string) error {
+	if err := s.webhook.SetSubscriptionEnabled(ctx, id, true, ""); err != nil {
+		return fmt.Errorf("EnableSubscription: save err: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookService) DisableSubscription(ctx context.Context, id, reason string) error {
+	if reason == "" {
+		reason = "disabled by admin"
+	}
+	if err := s.webhook.SetSubscriptionEnabled(ctx, id, false, reason); err != nil {
+		return fmt.Errorf("DisableSubscription: save err: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	if err := s.webhook.DeleteSubscription(ctx, id); err != nil {
+		return fmt.Errorf("DeleteSubscription: delete err: %w", err)
+	}
+	return nil
+}
+
+func (s *WebhookService) QueryDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*DeliveryResponse, error) {
+	if limit <= 0 || limit > defaultDeliveryLimit {
+		limit = defaultDeliveryLimit
+	}
+	ds, err := s.webhook.QueryDeliveries(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("QueryDeliveries: query err: %w", err)
+	}
+	res := make([]*DeliveryResponse, len(ds))
+	for i := range ds {
+		res[i] = &DeliveryResponse{
+			DeliveryID:     ds[i].DeliveryID,
+			SubscriptionID: ds[i].SubscriptionID,
+			EventID:        ds[i].EventID,
+			EventType:      ds[i].EventType,
+			State:          deliveryStates[ds[i].State],
+			Attempts:       ds[i].Attempts,
+			NextAttemptAt:  ds[i].NextAttemptAt,
+			LastStatus:     ds[i].LastStatus,
+			LastErr:        ds[i].LastErr,
+			DeliveredAt:    ds[i].DeliveredAt,
+			CreateAt:       ds[i].CreateAt,
+		}
+	}
+	return res, nil
+}
+
+func (s *WebhookService) QueryAttempts(ctx context.Context, deliveryID string) ([]*AttemptResponse, error) {
+	as, err := s.webhook.QueryAttempts(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("QueryAttempts: query err: %w", err)
+	}
+	res := make([]*AttemptResponse, len(as))
+	for i := range as {
+		res[i] = &AttemptResponse{
+			Attempt:    as[i].Attempt,
+			StatusCode: as[i].StatusCode,
+			Err:        as[i].Err,
+			DurationMs: as[i].Duration.Milliseconds(),
+			CreateAt:   as[i].CreateAt,
+		}
+	}
+	return res, nil
+}
+
+func (s *WebhookService) ReplayDelivery(ctx context.Context, deliveryID string) error {
+	if err := s.webhook.ReplayDelivery(ctx, deliveryID); err != nil {
+		return fmt.Errorf("ReplayDelivery: replay err: %w", err)
+	}
+	return nil
+}
+
+func makeSubscriptionResponse(sub *biz.WebhookSubscription) *SubscriptionResponse {
+	return &SubscriptionResponse{
+		SubscriptionID:      sub.SubscriptionID,
+		URL:                 sub.URL,
+		Events:              sub.Events,
+		Description:         strings.TrimSpace(sub.Description),
+		Enabled:             sub.Enabled,
+		ConsecutiveFailures: sub.ConsecutiveFailures,
+		DisabledReason:      sub.DisabledReason,
+		CreateAt:            sub.CreateAt,
+	}
+}
@@ -0,0 +1,252 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"starland-account/configs"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/httpclientutil"
+	"starland-account/internal/pkg/tenant"
+	"starland-account/internal/pkg/worker"
+	"strconv"
+	"time"
+
+	"github.com/gojektech/heimdall/v6/httpclient"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	consumerGroup = "webhooks"
+
+	defaultTimeout      = 10 * time.Second
+	defaultMaxAttempts  = 8
+	defaultMaxBackoff   = time.Hour
+	defaultDisableAfter = 50
+	defaultBatchSize    = 50
+
+	streamBlock     = 5 * time.Second
+	deliverInterval = time.Second
+	// leaseMargin keeps a claimed delivery from being picked up again while
+	// its request is still within the timeout.
+	leaseMargin = 30 * time.Second
+	// claimIdle is how long an event stays pending with another consumer
+	// before it is taken over, well above the time a dispatch takes.
+	claimIdle     = time.Minute
+	claimInterval = time.Minute
+)
+
+// dispatchTask turns events from the stream into deliveries. Messages are
+// acked only after their deliveries are stored. The consumer's own pending
+// messages are read first, and every claimInterval the ones other consumers
+// left pending longer than claimIdle, e.g. before a restart under another
+// name, are taken over. A failed dispatch is retried from the pending list
+// after a growing pause.
+func (s *WebhookService) dispatchTask(ctx context.Context) {
+	consumer, _ := os.Hostname()
+	consumer = fmt.Sprintf("%s-%d", consumer, os.Getpid())
+	start, failures := "0", 0
+	var claimed time.Time
+	// a read blocks for up to streamBlock, which bounds how long a stop waits
+	for ctx.Err() == nil {
+		var (
+			msgs []*biz.StreamEvent
+			err  error
+		)
+		if start == ">" && time.Since(claimed) >= claimInterval {
+			claimed = time.Now()
+			msgs, err = s.webhook.ClaimStreamEvents(ctx, consumerGroup, consumer, claimIdle, s.batchSize())
+			if err != nil {
+				zap.S().Errorf("dispatchTask: claim events err: %v", err)
+			}
+			if len(msgs) > 0 {
+				zap.S().Infof("dispatchTask: claimed %d pending events", len(msgs))
+			}
+		}
+		if len(msgs) == 0 {
+			msgs, err = s.webhook.ReadStreamEvents(ctx, consumerGroup, consumer, start, s.batchSize(), streamBlock)
+			if err != nil {
+				zap.S().Errorf("dispatchTask: read events err: %v", err)
+				sleep(ctx, time.Second)
+				continue
+			}
+			if len(msgs) == 0 {
+				start = ">"
+				continue
+			}
+		}
+
+		if s.handle(ctx, msgs) {
+			failures = 0
+			continue
+		}
+		// the failed ones stay pending, read them again after a pause
+		failures++
+		start = "0"
+		sleep(ctx, dispatchBackoff(failures))
+	}
+}
+
+// dispatchBackoff doubles from one second per failed dispatch in a row,
+// capped at 64 seconds.
+func dispatchBackoff(failures int) time.Duration {
+	return time.Second << min(failures-1, 6)
+}
+
+// handle dispatches and acks the messages, false if a dispatch failed.
+func (s *WebhookService) handle(ctx context.Context, msgs []*biz.StreamEvent) bool {
+	ok := true
+	for _, m := range msgs {
+		if m.Event != nil {
+			if err := s.dispatch(ctx, m.Event); err != nil {
+				zap.S().Errorf("dispatchTask: dispatch(%s) err: %v", m.Event.ID, err)
+				ok = false
+				continue
+			}
+		}
+		if err := s.webhook.AckStreamEvents(ctx, consumerGroup, m.MessageID); err != nil {
+			zap.S().Errorf("dispatchTask: ack(%s) err: %v", m.MessageID, err)
+		}
+	}
+	return ok
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+// dispatch enqueues the event for its tenant's subscriptions; events from
+// before tenants existed belong to the default tenant.
+func (s *WebhookService) dispatch(ctx context.Context, e *biz.Event) error {
+	id := e.TenantID
+	if id == "" {
+		id = tenant.Default
+	}
+	ctx = tenant.NewContext(ctx, id)
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = s.webhook.EnqueueDeliveries(ctx, e, string(payload), uuid.NewString)
+	return err
+}
+
+func (s *WebhookService) deliverTask(ctx context.Context) {
+	client := httpclientutil.NewHttpClient(s.timeout())
+	worker.Every(deliverInterval, false, func(ctx context.Context) {
+		tenant.Each(ctx, s.cfg, func(ctx context.Context, _ *configs.TenantConfig) {
+			s.deliver(ctx, client)
+		})
+	})(ctx)
+}
+
+func (s *WebhookService) deliver(ctx context.Context, client *httpclient.Client) {
+	ds, err := s.webhook.ListDueDeliveries(ctx, s.batchSize())
+	if err != nil {
+		zap.S().Errorf("deliverTask: list deliveries err: %v", err)
+		return
+	}
+	for _, d := range ds {
+		ok, err := s.webhook.ClaimDelivery(ctx, d, s.timeout()+leaseMargin)
+		if err != nil {
+			zap.S().Errorf("deliverTask: claim(%s) err: %v", d.DeliveryID, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		sub, err := s.webhook.QuerySubscription(ctx, d.SubscriptionID)
+		if err != nil {
+			zap.S().Errorf("deliverTask: query subscription(%s) err: %v", d.SubscriptionID, err)
+			continue
+		}
+		res := send(ctx, client, sub, d)
+		if err = s.webhook.RecordResult(ctx, d, res, s.policy()); err != nil {
+			zap.S().Errorf("deliverTask: record result(%s) err: %v", d.DeliveryID, err)
+		}
+	}
+}
+
+// send posts the payload signed as documented in docs/webhooks.md.
+func send(ctx context.Context, client *httpclient.Client, sub *biz.WebhookSubscription, d *biz.WebhookDelivery) *biz.WebhookResult {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewBufferString(d.Payload))
+	if err != nil {
+		return &biz.WebhookResult{Err: err.Error()}
+	}
+	header := req.Header
+	header.Set("Content-Type", "application/json")
+	header.Set("User-Agent", "starland-account-webhook")
+	header.Set("X-Starland-Event", d.EventType)
+	header.Set("X-Starland-Delivery", d.DeliveryID)
+	header.Set("X-Starland-Timestamp", ts)
+	header.Set("X-Starland-Signature", "sha256="+Sign(sub.Secret, ts, d.Payload))
+
+	begin := time.Now()
+	resp, err := client.Do(req)
+	res := &biz.WebhookResult{Duration: time.Since(begin)}
+	if err != nil {
+		res.Err = err.Error()
+		return res
+	}
+	defer resp.Body.Close()
+	res.StatusCode = resp.StatusCode
+	if !res.OK() {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		res.Err = fmt.Sprintf("status %d: %s", resp.StatusCode, body)
+	}
+	return res
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<payload>" keyed by the
+// subscription secret.
+func Sign(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookService) timeout() time.Duration {
+	if s.cfg.Webhook != nil && s.cfg.Webhook.Timeout > 0 {
+		return s.cfg.Webhook.Timeout * time.Second
+	}
+	return defaultTimeout
+}
+
+func (s *WebhookService) batchSize() int {
+	if s.cfg.Webhook != nil && s.cfg.Webhook.BatchSize > 0 {
+		return s.cfg.Webhook.BatchSize
+	}
+	return defaultBatchSize
+}
+
+func (s *WebhookService) policy() *biz.WebhookPolicy {
+	p := &biz.WebhookPolicy{
+		MaxAttempts:  defaultMaxAttempts,
+		MaxBackoff:   defaultMaxBackoff,
+		DisableAfter: defaultDisableAfter,
+	}
+	if wc := s.cfg.Webhook; wc != nil {
+		if wc.MaxAttempts > 0 {
+			p.MaxAttempts = wc.MaxAttempts
+		}
+		if wc.MaxBackoff > 0 {
+			p.MaxBackoff = wc.MaxBackoff * time.Second
+		}
+		if wc.DisableAfter > 0 {
+			p.DisableAfter = wc.DisableAfter
+		}
+	}
+	return p
+}
@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"starland-account/internal/biz"
+	"starland-account/internal/pkg/httpclientutil"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	const want = "6a85cb117c993612a34c72b4cfb5a16baed25a80d26e298ce0e6671320fd07c8"
+	if got := Sign("whsec_test", "1700000000", `{"id":"e1"}`); got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+	if Sign("whsec_other", "1700000000", `{"id":"e1"}`) == want {
+		t.Errorf("Sign ignores the secret")
+	}
+	if Sign("whsec_test", "1700000001", `{"id":"e1"}`) == want {
+		t.Errorf("Sign ignores the timestamp")
+	}
+}
+
+func TestSend(t *testing.T) {
+	sub := &biz.WebhookSubscription{Secret: "whsec_test"}
+	d := &biz.WebhookDelivery{DeliveryID: "d1", EventType: biz.EventPointsEarned, Payload: `{"id":"e1"}`}
+
+	var header http.Header
+	secret, status := sub.Secret, http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ := io.ReadAll(r.Body)
+		// verify as a receiver would
+		ts := r.Header.Get("X-Starland-Timestamp")
+		sig := "sha256=" + Sign(secret, ts, string(body))
+		if !hmac.Equal([]byte(sig), []byte(r.Header.Get("X-Starland-Signature"))) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, "busy")
+	}))
+	defer srv.Close()
+	sub.URL = srv.URL
+	client := httpclientutil.NewHttpClient(time.Second)
+
+	res := send(context.Background(), client, sub, d)
+	if !res.OK() {
+		t.Fatalf("send = %+v, want a signed delivery accepted", res)
+	}
+	for name, want := range map[string]string{
+		"Content-Type":        "application/json",
+		"X-Starland-Event":    biz.EventPointsEarned,
+		"X-Starland-Delivery": "d1",
+	} {
+		if got := header.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	ts, err := strconv.ParseInt(header.Get("X-Starland-Timestamp"), 10, 64)
+	if err != nil || time.Since(time.Unix(ts, 0)) > time.Minute {
+		t.Errorf("X-Starland-Timestamp = %q, want the current unix time", header.Get("X-Starland-Timestamp"))
+	}
+
+	status = http.StatusServiceUnavailable
+	res = send(context.Background(), client, sub, d)
+	if res.OK() || res.StatusCode != status || !strings.HasPrefix(res.Err, "status 503: busy") {
+		t.Errorf("send = %+v, want a failed attempt with the status and body", res)
+	}
+
+	// a receiver holding another secret refuses the signature
+	secret = "whsec_rotated"
+	if res = send(context.Background(), client, sub, d); res.StatusCode != http.StatusUnauthorized {
+		t.Errorf("send with a stale secret = %+v, want 401", res)
+	}
+}
+
+func TestDispatchBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		3:  4 * time.Second,
+		7:  64 * time.Second,
+		8:  64 * time.Second,
+		50: 64 * time.Second,
+	}
+	for failures, want := range cases {
+		if got := dispatchBackoff(failures); got != want {
+			t.Errorf("dispatchBackoff(%d) = %v, want %v", failures, got, want)
+		}
+	}
+}